@@ -0,0 +1,85 @@
+// Command loadgen generates a realistic volume of services and
+// submissions against a running prrserver instance, for manual load
+// testing and for catching throughput regressions before release.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+func main() {
+	target := flag.String("target", "http://localhost:8080", "base URL of the prrserver instance")
+	services := flag.Int("services", 50, "number of services to create")
+	submissionsPerService := flag.Int("submissions", 5, "number of submissions to create per service")
+	flag.Parse()
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	start := time.Now()
+	total := 0
+	for i := 0; i < *services; i++ {
+		svcID, err := createService(client, *target, fmt.Sprintf("svc-%d", i))
+		if err != nil {
+			log.Fatalf("create service: %v", err)
+		}
+		for j := 0; j < *submissionsPerService; j++ {
+			if err := createSubmission(client, *target, svcID); err != nil {
+				log.Fatalf("create submission: %v", err)
+			}
+			total++
+		}
+	}
+
+	elapsed := time.Since(start)
+	log.Printf("created %d services and %d submissions in %s (%.1f submissions/sec)",
+		*services, total, elapsed, float64(total)/elapsed.Seconds())
+}
+
+func createService(client *http.Client, target, name string) (string, error) {
+	body, _ := json.Marshal(map[string]string{"name": name})
+	resp, err := client.Post(target+"/services", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	var svc struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&svc); err != nil {
+		return "", err
+	}
+	return svc.ID, nil
+}
+
+func createSubmission(client *http.Client, target, serviceID string) error {
+	answers := make([]map[string]string, 0, 5)
+	for i := 0; i < 5; i++ {
+		answers = append(answers, map[string]string{
+			"question_id": fmt.Sprintf("q-%d", i),
+			"value":       []string{"yes", "no", "n/a"}[rand.Intn(3)],
+		})
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"service_id": serviceID,
+		"answers":    answers,
+	})
+	resp, err := client.Post(target+"/submissions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+// Command prrctl is a command-line client for submitters to list
+// services, submit PRR questionnaires and inspect submissions without
+// hand-crafting HTTP requests. It's thin by design: each subcommand maps
+// to one API call and prints the raw (pretty-printed) JSON response, so
+// it composes with CI pipelines and tools like jq rather than imposing
+// its own output format.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd := os.Args[1]
+	args := os.Args[2:]
+
+	var err error
+	switch cmd {
+	case "submit":
+		err = runSubmit(args)
+	case "list-services":
+		err = runListServices(args)
+	case "get-submission":
+		err = runGetSubmission(args)
+	case "history":
+		err = runHistory(args)
+	case "compare":
+		err = runCompare(args)
+	case "questions":
+		err = runQuestions(args)
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "prrctl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage:
+  prrctl submit --server=URL --service=ID --answer=question_id=value [--answer=... repeatable]
+  prrctl submit --server=URL --service=ID --file=answers.json
+  prrctl list-services --server=URL
+  prrctl get-submission --server=URL --id=ID
+  prrctl history --server=URL --service=ID [--limit=N] [--offset=N]
+  prrctl compare --server=URL --service=ID --latest
+  prrctl questions export --server=URL`)
+}
+
+func runSubmit(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	server := fs.String("server", "http://localhost:8080", "prrserver base URL")
+	service := fs.String("service", "", "service ID to submit for")
+	file := fs.String("file", "", "path to a JSON file of [{\"question_id\":...,\"value\":...}, ...] answers, as an alternative to repeated --answer flags")
+	var answers answerFlags
+	fs.Var(&answers, "answer", "question_id=value, repeatable")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *service == "" {
+		return fmt.Errorf("--service is required")
+	}
+
+	var answerValues interface{} = answers.toAnswers()
+	if *file != "" {
+		if len(answers) > 0 {
+			return fmt.Errorf("--file and --answer are mutually exclusive")
+		}
+		raw, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		var fileAnswers []map[string]string
+		if err := json.Unmarshal(raw, &fileAnswers); err != nil {
+			return fmt.Errorf("parsing %s: %w", *file, err)
+		}
+		answerValues = fileAnswers
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"service_id": *service,
+		"answers":    answerValues,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(*server+"/submissions", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return printResponse(resp)
+}
+
+func runListServices(args []string) error {
+	fs := flag.NewFlagSet("list-services", flag.ExitOnError)
+	server := fs.String("server", "http://localhost:8080", "prrserver base URL")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resp, err := http.Get(*server + "/services")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return printResponse(resp)
+}
+
+func runGetSubmission(args []string) error {
+	fs := flag.NewFlagSet("get-submission", flag.ExitOnError)
+	server := fs.String("server", "http://localhost:8080", "prrserver base URL")
+	id := fs.String("id", "", "submission ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return fmt.Errorf("--id is required")
+	}
+
+	resp, err := http.Get(*server + "/submissions/" + *id)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return printResponse(resp)
+}
+
+func runHistory(args []string) error {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	server := fs.String("server", "http://localhost:8080", "prrserver base URL")
+	service := fs.String("service", "", "service ID")
+	limit := fs.Int("limit", 0, "max submissions to return (0 uses the server default)")
+	offset := fs.Int("offset", 0, "number of submissions to skip")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *service == "" {
+		return fmt.Errorf("--service is required")
+	}
+
+	u := *server + "/services/" + url.PathEscape(*service) + "/submissions"
+	q := url.Values{}
+	if *limit > 0 {
+		q.Set("limit", fmt.Sprint(*limit))
+	}
+	if *offset > 0 {
+		q.Set("offset", fmt.Sprint(*offset))
+	}
+	if encoded := q.Encode(); encoded != "" {
+		u += "?" + encoded
+	}
+
+	resp, err := http.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return printResponse(resp)
+}
+
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	server := fs.String("server", "http://localhost:8080", "prrserver base URL")
+	service := fs.String("service", "", "service ID")
+	latest := fs.Bool("latest", false, "compare the two most recent submissions (currently the only supported mode)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *service == "" {
+		return fmt.Errorf("--service is required")
+	}
+	if !*latest {
+		return fmt.Errorf("--latest is required")
+	}
+
+	u := *server + "/prr/compare/latest?service_id=" + url.QueryEscape(*service)
+	resp, err := http.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return printResponse(resp)
+}
+
+func runQuestions(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: prrctl questions export --server=URL")
+	}
+	switch sub, rest := args[0], args[1:]; sub {
+	case "export":
+		fs := flag.NewFlagSet("questions export", flag.ExitOnError)
+		server := fs.String("server", "http://localhost:8080", "prrserver base URL")
+		if err := fs.Parse(rest); err != nil {
+			return err
+		}
+		resp, err := http.Get(*server + "/admin/questions/export")
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		return printResponse(resp)
+	default:
+		return fmt.Errorf("unknown questions subcommand %q", sub)
+	}
+}
+
+func printResponse(resp *http.Response) error {
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, mustReadAll(resp), "", "  "); err != nil {
+		return err
+	}
+	fmt.Println(pretty.String())
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func mustReadAll(resp *http.Response) []byte {
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(resp.Body)
+	return buf.Bytes()
+}
+
+// answerFlags collects repeated --answer=question_id=value flags.
+type answerFlags []string
+
+func (a *answerFlags) String() string { return strings.Join(*a, ",") }
+
+func (a *answerFlags) Set(v string) error {
+	if !strings.Contains(v, "=") {
+		return fmt.Errorf("--answer must be in the form question_id=value, got %q", v)
+	}
+	*a = append(*a, v)
+	return nil
+}
+
+func (a *answerFlags) toAnswers() []map[string]string {
+	out := make([]map[string]string, 0, len(*a))
+	for _, entry := range *a {
+		parts := strings.SplitN(entry, "=", 2)
+		out = append(out, map[string]string{"question_id": parts[0], "value": parts[1]})
+	}
+	return out
+}
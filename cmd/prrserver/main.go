@@ -0,0 +1,306 @@
+// Command prrserver runs the production readiness review API server.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/api"
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/blob"
+	"github.com/Eambo/vxcvxvc/internal/demo"
+	"github.com/Eambo/vxcvxvc/internal/es"
+	"github.com/Eambo/vxcvxvc/internal/esconfig"
+	"github.com/Eambo/vxcvxvc/internal/gitops"
+	"github.com/Eambo/vxcvxvc/internal/jobqueue"
+	"github.com/Eambo/vxcvxvc/internal/notify"
+	"github.com/Eambo/vxcvxvc/internal/policy"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/seed"
+	"github.com/Eambo/vxcvxvc/internal/store"
+	"github.com/Eambo/vxcvxvc/internal/store/cache"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+	"github.com/Eambo/vxcvxvc/internal/store/postgres"
+)
+
+const (
+	defaultReadTimeout      = 15 * time.Second
+	defaultWriteTimeout     = 30 * time.Second
+	defaultShutdownTimeout  = 15 * time.Second
+	defaultExpiryCheckEvery = 1 * time.Hour
+)
+
+func main() {
+	dev := flag.Bool("dev", false, "enable development-only endpoints such as /dev/seed (never use in production)")
+	demoMode := flag.Bool("demo", false, "pre-load the in-memory backend with a curated sample org and exit-ready data")
+	questionBankDir := flag.String("questionbank-dir", "", "sync the question bank from this directory (a checkout of the question bank git repo) on startup")
+	seedQuestionBank := flag.Bool("seed", false, "load the embedded curated default question bank on startup (idempotent; safe to run against an existing store)")
+	policyFile := flag.String("policy-file", "", "rego policy file used to evaluate the release gate (requires the opa CLI on PATH)")
+	esConfigFile := flag.String("es-config", "", "JSON file with Elasticsearch connection settings (falls back to ES_ADDRESSES/ES_USERNAME/ES_PASSWORD/ES_API_KEY)")
+	esMigrate := flag.Bool("migrate", false, "create any missing Elasticsearch indices with their expected mappings, then exit without serving")
+	esReindex := flag.String("reindex", "", "zero-downtime reindex of the given Elasticsearch alias (e.g. \"questions\") onto a new physical index with the current mapping, swap its read/write aliases onto it, then exit without serving")
+	flag.Parse()
+
+	logger := log.New(os.Stdout, "prrserver: ", log.LstdFlags)
+
+	var st store.Store = memory.New()
+	if dsn := os.Getenv("PRR_POSTGRES_DSN"); dsn != "" {
+		driver := os.Getenv("PRR_POSTGRES_DRIVER")
+		if driver == "" {
+			driver = "postgres"
+		}
+		// sql.Open only validates that a driver named driver has been
+		// registered; since this module has no third-party
+		// dependencies, the binary embedding this package must
+		// blank-import one (e.g. _ "github.com/lib/pq") for this to
+		// actually connect.
+		pg, err := postgres.Open(context.Background(), driver, dsn)
+		if err != nil {
+			logger.Fatalf("failed to connect to PostgreSQL: %v", err)
+		}
+		defer pg.Close()
+		st = pg
+		logger.Printf("using PostgreSQL storage backend")
+	}
+	if ttlSpec := os.Getenv("PRR_QUESTION_CACHE_TTL"); ttlSpec != "" {
+		ttl, err := time.ParseDuration(ttlSpec)
+		if err != nil {
+			logger.Fatalf("invalid PRR_QUESTION_CACHE_TTL: %v", err)
+		}
+		st = cache.New(st, prr.SystemClock{}, ttl)
+		logger.Printf("question bank caching enabled with ttl %s", ttl)
+	}
+	a := api.New(st, prr.SystemClock{}, prr.UUIDGenerator{}, logger)
+	a.SLogger = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	a.DevMode = *dev
+	if a.DevMode {
+		logger.Printf("dev mode enabled: /dev/seed is reachable")
+	}
+
+	if os.Getenv("PRR_DISABLE_STRICT_VALIDATION") == "true" {
+		a.DisableStrictValidation = true
+		logger.Printf("strict answer validation disabled")
+	}
+
+	if *demoMode {
+		if err := demo.Load(context.Background(), st, a.Clock, a.IDGen); err != nil {
+			logger.Fatalf("failed to load demo data: %v", err)
+		}
+		logger.Printf("demo mode enabled: sample org loaded")
+	}
+
+	if spec := os.Getenv("PRR_TOKENS"); spec != "" {
+		tokens, err := auth.ParseTokens(spec)
+		if err != nil {
+			logger.Fatalf("failed to parse PRR_TOKENS: %v", err)
+		}
+		a.Tokens = tokens
+		logger.Printf("authentication enabled with %d tokens", len(tokens))
+	}
+
+	if webhookURL := os.Getenv("TEAMS_WEBHOOK_URL"); webhookURL != "" {
+		a.Teams = notify.NewTeamsClient(webhookURL)
+		logger.Printf("teams notifications enabled")
+	}
+	if apiKey := os.Getenv("OPSGENIE_API_KEY"); apiKey != "" {
+		a.Opsgenie = notify.NewOpsgenieClient(apiKey)
+		logger.Printf("opsgenie alerting enabled")
+	}
+	if webhookURL := os.Getenv("SLACK_WEBHOOK_URL"); webhookURL != "" {
+		a.Slack = notify.NewSlackClient(webhookURL)
+		logger.Printf("slack notifications enabled")
+	}
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		a.Email = notify.NewEmailClient(smtpHost, os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("SMTP_FROM"))
+		logger.Printf("email notifications enabled")
+	}
+	a.BaseURL = os.Getenv("PRR_BASE_URL")
+	a.SigningKey = os.Getenv("PRR_SIGNING_KEY")
+
+	if rps := os.Getenv("PRR_RATE_LIMIT_PER_SECOND"); rps != "" {
+		rate, err := strconv.ParseFloat(rps, 64)
+		if err != nil {
+			logger.Fatalf("invalid PRR_RATE_LIMIT_PER_SECOND: %v", err)
+		}
+		a.RateLimitPerSecond = rate
+		if burstSpec := os.Getenv("PRR_RATE_LIMIT_BURST"); burstSpec != "" {
+			burst, err := strconv.Atoi(burstSpec)
+			if err != nil {
+				logger.Fatalf("invalid PRR_RATE_LIMIT_BURST: %v", err)
+			}
+			a.RateLimitBurst = burst
+		}
+		logger.Printf("rate limiting enabled: %.2f req/s, burst %d", a.RateLimitPerSecond, a.RateLimitBurst)
+	}
+	if maxBody := os.Getenv("PRR_MAX_REQUEST_BODY_BYTES"); maxBody != "" {
+		limit, err := strconv.ParseInt(maxBody, 10, 64)
+		if err != nil {
+			logger.Fatalf("invalid PRR_MAX_REQUEST_BODY_BYTES: %v", err)
+		}
+		a.MaxRequestBodySizeBytes = limit
+		logger.Printf("max request body size set to %d bytes", limit)
+	}
+
+	if origins := os.Getenv("PRR_CORS_ALLOWED_ORIGINS"); origins != "" {
+		a.CORSAllowedOrigins = strings.Split(origins, ",")
+		if methods := os.Getenv("PRR_CORS_ALLOWED_METHODS"); methods != "" {
+			a.CORSAllowedMethods = strings.Split(methods, ",")
+		}
+		if headers := os.Getenv("PRR_CORS_ALLOWED_HEADERS"); headers != "" {
+			a.CORSAllowedHeaders = strings.Split(headers, ",")
+		}
+		logger.Printf("CORS enabled for origins: %s", origins)
+	}
+
+	if *policyFile != "" {
+		a.Gate = policy.NewOPAEvaluator(*policyFile, "data.prr.allow")
+		logger.Printf("policy gate enabled using %s", *policyFile)
+	}
+
+	if dir := os.Getenv("PRR_ATTACHMENTS_DIR"); dir != "" {
+		disk, err := blob.NewDiskStore(dir)
+		if err != nil {
+			logger.Fatalf("failed to initialize attachments directory %s: %v", dir, err)
+		}
+		a.Blob = disk
+		logger.Printf("submission attachments enabled, storing under %s", dir)
+	}
+
+	if workersSpec := os.Getenv("PRR_ASYNC_SUBMISSION_WORKERS"); workersSpec != "" {
+		workers, err := strconv.Atoi(workersSpec)
+		if err != nil || workers < 1 {
+			logger.Fatalf("invalid PRR_ASYNC_SUBMISSION_WORKERS %q: must be a positive integer", workersSpec)
+		}
+		a.Jobs = jobqueue.New(workers)
+		logger.Printf("asynchronous submission processing enabled with %d worker(s)", workers)
+	}
+
+	esCfg := esconfig.FromEnv()
+	if *esConfigFile != "" {
+		fileCfg, err := esconfig.FromFile(*esConfigFile)
+		if err != nil {
+			logger.Fatalf("failed to load ES config: %v", err)
+		}
+		esCfg = fileCfg
+	}
+	if len(esCfg.Addresses) > 0 {
+		esClient, err := es.NewClient(esCfg)
+		if err != nil {
+			logger.Fatalf("invalid ES config: %v", err)
+		}
+		if err := esClient.Ping(context.Background()); err != nil {
+			logger.Fatalf("failed to reach Elasticsearch: %v", err)
+		}
+		a.ES = esClient
+		logger.Printf("connected to Elasticsearch at %v", esCfg.Addresses)
+
+		if *esMigrate {
+			if err := esClient.Bootstrap(context.Background()); err != nil {
+				logger.Fatalf("failed to migrate Elasticsearch indices: %v", err)
+			}
+			logger.Printf("Elasticsearch indices up to date")
+			return
+		}
+		if *esReindex != "" {
+			body, err := esIndexMappingBody(*esReindex)
+			if err != nil {
+				logger.Fatalf("%v", err)
+			}
+			oldIndex, newIndex, err := esClient.Reindex(context.Background(), *esReindex, body)
+			if err != nil {
+				logger.Fatalf("failed to reindex %s: %v", *esReindex, err)
+			}
+			logger.Printf("reindexed %s: %s -> %s (old index left in place; delete it once you've verified the swap)", *esReindex, oldIndex, newIndex)
+			return
+		}
+	} else if *esMigrate {
+		logger.Fatalf("-migrate requires Elasticsearch connection settings (-es-config or ES_ADDRESSES)")
+	} else if *esReindex != "" {
+		logger.Fatalf("-reindex requires Elasticsearch connection settings (-es-config or ES_ADDRESSES)")
+	}
+
+	if *questionBankDir != "" {
+		summary, err := gitops.Sync(context.Background(), st, *questionBankDir)
+		if err != nil {
+			logger.Fatalf("failed to sync question bank: %v", err)
+		}
+		logger.Printf("synced question bank: %d sections, %d questions", summary.Sections, summary.Questions)
+	}
+	if *seedQuestionBank {
+		summary, err := seed.Load(context.Background(), st)
+		if err != nil {
+			logger.Fatalf("failed to seed question bank: %v", err)
+		}
+		logger.Printf("seeded default question bank: %d sections, %d questions", summary.Sections, summary.Questions)
+	}
+
+	addr := os.Getenv("PRR_ADDR")
+	if addr == "" {
+		addr = ":8080"
+	}
+
+	srv := &http.Server{
+		Addr:         addr,
+		Handler:      a.Router(),
+		ReadTimeout:  defaultReadTimeout,
+		WriteTimeout: defaultWriteTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go a.RunExpiryScheduler(ctx, defaultExpiryCheckEvery)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Printf("listening on %s", addr)
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatalf("server error: %v", err)
+		}
+	case <-ctx.Done():
+		stop()
+		logger.Printf("shutting down, waiting up to %s for in-flight requests", defaultShutdownTimeout)
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Fatalf("graceful shutdown failed: %v", err)
+		}
+		logger.Printf("shutdown complete")
+	}
+}
+
+// esIndexMappingBody returns the mapping body -reindex should create the
+// new physical index with, i.e. es.Indices' current entry for alias.
+func esIndexMappingBody(alias string) (string, error) {
+	for _, idx := range es.Indices {
+		if idx.Alias == alias {
+			return idx.Body, nil
+		}
+	}
+	return "", fmt.Errorf("unknown Elasticsearch alias %q, want one of %s", alias, strings.Join(esAliasNames(), ", "))
+}
+
+func esAliasNames() []string {
+	names := make([]string, len(es.Indices))
+	for i, idx := range es.Indices {
+		names[i] = idx.Alias
+	}
+	return names
+}
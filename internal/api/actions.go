@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// createActionItemRequest is the body of POST /prr/{id}/actions.
+type createActionItemRequest struct {
+	QuestionID  string    `json:"question_id,omitempty"`
+	Description string    `json:"description"`
+	Owner       string    `json:"owner"`
+	DueDate     time.Time `json:"due_date"`
+}
+
+// handleCreateActionItem attaches a remediation task to the submission
+// identified by id, optionally scoped to one of its answers via
+// QuestionID.
+func (a *API) handleCreateActionItem(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	var req createActionItemRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Description == "" {
+		writeAPIError(w, "description is required", http.StatusBadRequest)
+		return
+	}
+	if req.Owner == "" {
+		writeAPIError(w, "owner is required", http.StatusBadRequest)
+		return
+	}
+	if req.DueDate.IsZero() {
+		writeAPIError(w, "due_date is required", http.StatusBadRequest)
+		return
+	}
+
+	item := prr.ActionItem{
+		ID:           a.IDGen.NewID(),
+		SubmissionID: sub.ID,
+		ServiceID:    sub.ServiceID,
+		QuestionID:   req.QuestionID,
+		Description:  req.Description,
+		Owner:        req.Owner,
+		DueDate:      req.DueDate,
+		Status:       prr.ActionItemOpen,
+		CreatedAt:    a.Clock.Now(),
+		UpdatedAt:    a.Clock.Now(),
+	}
+	if err := a.Store.CreateActionItem(r.Context(), item); err != nil {
+		writeAPIError(w, "failed to record action item", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "action_item", item.ID, prr.AuditActionCreate, nil, item)
+	writeJSON(w, http.StatusCreated, item)
+}
+
+// handleListActionItemsBySubmission lists the action items attached to
+// the submission identified by id, oldest first.
+func (a *API) handleListActionItemsBySubmission(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := a.authorizedSubmission(r.Context(), r, id); err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	items, err := a.Store.ListActionItemsBySubmission(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, "failed to list action items", http.StatusInternalServerError)
+		return
+	}
+	sortActionItems(items)
+	writeJSON(w, http.StatusOK, items)
+}
+
+// handleListActionItemsByService lists every action item attached to
+// any submission for the service identified by id, oldest first,
+// optionally narrowed to a single Status via the "status" query
+// parameter.
+func (a *API) handleListActionItemsByService(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := a.authorizedService(r.Context(), r, id); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	items, err := a.Store.ListActionItemsByService(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, "failed to list action items", http.StatusInternalServerError)
+		return
+	}
+	if status := r.URL.Query().Get("status"); status != "" {
+		filtered := items[:0]
+		for _, item := range items {
+			if string(item.Status) == status {
+				filtered = append(filtered, item)
+			}
+		}
+		items = filtered
+	}
+	sortActionItems(items)
+	writeJSON(w, http.StatusOK, items)
+}
+
+func sortActionItems(items []prr.ActionItem) {
+	sort.Slice(items, func(i, j int) bool { return items[i].CreatedAt.Before(items[j].CreatedAt) })
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestCreateActionItemAndListBySubmission(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	reqBody, _ := json.Marshal(map[string]interface{}{
+		"question_id": "q-backups",
+		"description": "add automated backups",
+		"owner":       "alice",
+		"due_date":    time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/"+sub.ID+"/actions", bytes.NewReader(reqBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var item prr.ActionItem
+	if err := json.Unmarshal(rec.Body.Bytes(), &item); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if item.Status != prr.ActionItemOpen || item.ServiceID != svc.ID || item.Owner != "alice" {
+		t.Fatalf("unexpected action item: %+v", item)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/actions", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var listed []prr.ActionItem
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	if len(listed) != 1 || listed[0].ID != item.ID {
+		t.Fatalf("unexpected list: %+v", listed)
+	}
+}
+
+func TestActionItemEndpointsRejectCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/prr/"+sub.ID+"/actions", bytes.NewReader([]byte(`{}`))),
+		httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/actions", nil),
+		httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/actions", nil),
+	} {
+		req.Header.Set(tenantHeader, "tenant-b")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s %s: status = %d, want 404, body=%s", req.Method, req.URL.Path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestListActionItemsByServiceFiltersStatus(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	open := prr.ActionItem{ID: "ai-1", SubmissionID: sub.ID, ServiceID: svc.ID, Description: "open item", Owner: "alice", DueDate: time.Now(), Status: prr.ActionItemOpen}
+	closed := prr.ActionItem{ID: "ai-2", SubmissionID: sub.ID, ServiceID: svc.ID, Description: "closed item", Owner: "bob", DueDate: time.Now(), Status: prr.ActionItemClosed}
+	if err := st.CreateActionItem(ctx, open); err != nil {
+		t.Fatalf("create open: %v", err)
+	}
+	if err := st.CreateActionItem(ctx, closed); err != nil {
+		t.Fatalf("create closed: %v", err)
+	}
+
+	router := a.Router()
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/actions?status=open", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var listed []prr.ActionItem
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	if len(listed) != 1 || listed[0].ID != open.ID {
+		t.Fatalf("unexpected filtered list: %+v", listed)
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+type amendAnswersRequest struct {
+	Answers []prr.Answer `json:"answers"`
+	Reason  string       `json:"reason"`
+}
+
+// handleAmendSubmissionAnswers corrects one or more answers on the
+// submission identified by id without mutating it: it creates a new
+// submission that carries the corrected answers over the original's
+// unchanged ones, recomputes its grade and blocking issues, and links
+// back to the original via AmendsSubmissionID/Amendment. Because the
+// result is an ordinary submission of the same service, it shows up in
+// /services/{id}/submissions and can be diffed against any other
+// revision via /prr/compare like any other submission.
+func (a *API) handleAmendSubmissionAnswers(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPatch {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	original, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	var req amendAnswersRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Answers) == 0 {
+		writeAPIError(w, "answers is required", http.StatusBadRequest)
+		return
+	}
+	if req.Reason == "" {
+		writeAPIError(w, "reason is required", http.StatusBadRequest)
+		return
+	}
+
+	if !a.DisableStrictValidation {
+		allowed, err := a.questionsForTemplateID(r.Context(), original.TemplateID)
+		if err != nil {
+			writeAPIError(w, "failed to validate answers", http.StatusInternalServerError)
+			return
+		}
+		if errs := prr.ValidateAnswers(req.Answers, allowed); len(errs) > 0 {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": errs})
+			return
+		}
+	}
+
+	corrections, err := a.stampAnswerVersions(r.Context(), req.Answers)
+	if err != nil {
+		writeAPIError(w, "failed to stamp question versions", http.StatusInternalServerError)
+		return
+	}
+
+	answers, changed := mergeAnswers(original.Answers, corrections)
+
+	amendedBy := "anonymous"
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		amendedBy = principal.Subject
+	}
+
+	now := a.Clock.Now()
+	amended := original
+	amended.ID = a.IDGen.NewID()
+	amended.Answers = answers
+	amended.Approval = prr.ApprovalPending
+	amended.Approvals = nil
+	amended.CreatedAt = now
+	amended.UpdatedAt = now
+	amended.IdempotencyKey = ""
+	amended.IsBaseline = false
+	amended.AmendsSubmissionID = original.ID
+	amended.Amendment = &prr.Amendment{By: amendedBy, Reason: req.Reason, At: now, Changed: changed}
+
+	grade, blocking, err := a.evaluateSubmission(r.Context(), amended)
+	if err != nil {
+		writeAPIError(w, "failed to grade amended submission", http.StatusInternalServerError)
+		return
+	}
+	amended.Grade = grade
+	amended.BlockingIssues = blocking
+	amended = a.stampContentHash(amended)
+
+	if err := a.Store.CreateSubmission(r.Context(), amended); err != nil {
+		if errors.Is(err, store.ErrLocked) {
+			writeAPIError(w, "submission is locked and cannot be amended", http.StatusConflict)
+			return
+		}
+		writeAPIError(w, "failed to create amended submission", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "submission", amended.ID, prr.AuditActionCreate, original, amended)
+	a.refreshServiceReadiness(r.Context(), amended.ServiceID)
+
+	if svc, err := a.Store.GetService(r.Context(), amended.ServiceID); err == nil {
+		a.dispatchWebhookEvent(r.Context(), prr.WebhookEventSubmissionAmended, svc, amended)
+		if amended.Grade == prr.GradeRed {
+			a.dispatchWebhookEvent(r.Context(), prr.WebhookEventReadinessDegraded, svc, amended)
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, amended)
+}
+
+// mergeAnswers layers corrections on top of base, replacing base's
+// answer to a question corrections also answers and appending the
+// rest, preserving base's order for everything it already covered. It
+// returns the merged answers and the IDs of the questions corrections
+// changed, for recording alongside the derived submission.
+func mergeAnswers(base, corrections []prr.Answer) ([]prr.Answer, []string) {
+	byQuestion := make(map[string]prr.Answer, len(corrections))
+	var changed []string
+	for _, ans := range corrections {
+		byQuestion[ans.QuestionID] = ans
+		changed = append(changed, ans.QuestionID)
+	}
+
+	merged := make([]prr.Answer, 0, len(base)+len(corrections))
+	seen := make(map[string]bool, len(corrections))
+	for _, ans := range base {
+		if corrected, ok := byQuestion[ans.QuestionID]; ok {
+			merged = append(merged, corrected)
+			seen[ans.QuestionID] = true
+			continue
+		}
+		merged = append(merged, ans)
+	}
+	for _, ans := range corrections {
+		if !seen[ans.QuestionID] {
+			merged = append(merged, ans)
+		}
+	}
+	return merged, changed
+}
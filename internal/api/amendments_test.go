@@ -0,0 +1,127 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestAmendSubmissionAnswersCreatesNewRecordWithoutMutatingOriginal(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "q-oncall", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, want 201, body=%s", rec.Code, rec.Body.String())
+	}
+	var original prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &original)
+
+	amendBody, _ := json.Marshal(amendAnswersRequest{
+		Answers: []prr.Answer{{QuestionID: "q-oncall", Value: "no"}},
+		Reason:  "on-call rotation was not actually staffed",
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/prr/"+original.ID+"/answers", bytes.NewReader(amendBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("amend status = %d, want 201, body=%s", rec.Code, rec.Body.String())
+	}
+	var amended prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &amended)
+
+	if amended.ID == original.ID {
+		t.Fatal("amended submission reused the original's ID, want a new record")
+	}
+	if amended.AmendsSubmissionID != original.ID {
+		t.Fatalf("AmendsSubmissionID = %q, want %q", amended.AmendsSubmissionID, original.ID)
+	}
+	if amended.Amendment == nil {
+		t.Fatal("Amendment = nil, want it populated")
+	}
+	if amended.Amendment.Reason != "on-call rotation was not actually staffed" {
+		t.Fatalf("Amendment.Reason = %q", amended.Amendment.Reason)
+	}
+	if len(amended.Amendment.Changed) != 1 || amended.Amendment.Changed[0] != "q-oncall" {
+		t.Fatalf("Amendment.Changed = %v, want [q-oncall]", amended.Amendment.Changed)
+	}
+
+	stored, err := st.GetSubmission(context.Background(), original.ID)
+	if err != nil {
+		t.Fatalf("GetSubmission() error = %v", err)
+	}
+	if stored.Answers[0].Value != "yes" {
+		t.Fatalf("original submission was mutated: Answers[0].Value = %q, want %q", stored.Answers[0].Value, "yes")
+	}
+
+	subs, err := st.ListSubmissionsByService(context.Background(), svc.ID)
+	if err != nil {
+		t.Fatalf("ListSubmissionsByService() error = %v", err)
+	}
+	if len(subs) != 2 {
+		t.Fatalf("len(subs) = %d, want 2 (original + amendment)", len(subs))
+	}
+}
+
+func TestAmendSubmissionAnswersRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	amendBody, _ := json.Marshal(amendAnswersRequest{
+		Answers: []prr.Answer{{QuestionID: "q-oncall", Value: "no"}},
+		Reason:  "typo fix",
+	})
+	req := httptest.NewRequest(http.MethodPatch, "/prr/"+sub.ID+"/answers", bytes.NewReader(amendBody))
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAmendSubmissionAnswersUnknownSubmission(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	amendBody, _ := json.Marshal(amendAnswersRequest{
+		Answers: []prr.Answer{{QuestionID: "q-oncall", Value: "no"}},
+		Reason:  "typo fix",
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/prr/missing-id/answers", bytes.NewReader(amendBody)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
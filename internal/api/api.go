@@ -0,0 +1,197 @@
+// Package api implements the HTTP handlers for the PRR service.
+package api
+
+import (
+	"log"
+	"log/slog"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/blob"
+	"github.com/Eambo/vxcvxvc/internal/es"
+	"github.com/Eambo/vxcvxvc/internal/jobqueue"
+	"github.com/Eambo/vxcvxvc/internal/notify"
+	"github.com/Eambo/vxcvxvc/internal/policy"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+	"github.com/Eambo/vxcvxvc/internal/webhook"
+)
+
+// defaultRequestTimeout bounds how long a single request may run before
+// its context is cancelled, so a slow downstream (Elasticsearch, OPA,
+// Teams/Opsgenie/Confluence) can't hold a handler open indefinitely.
+const defaultRequestTimeout = 10 * time.Second
+
+// defaultIdempotencyWindow bounds how long a submission's Idempotency-Key
+// is honored before a repeated key is treated as a new submission rather
+// than a retry.
+const defaultIdempotencyWindow = 24 * time.Hour
+
+// defaultMaxAttachmentSizeBytes caps an uploaded attachment when
+// MaxAttachmentSizeBytes is left at zero.
+const defaultMaxAttachmentSizeBytes = 25 << 20 // 25 MiB
+
+// API holds the dependencies shared by all HTTP handlers. Clock and
+// IDGenerator are injected rather than calling time.Now/uuid.New directly
+// so that submission timestamps and IDs are deterministic in tests.
+type API struct {
+	Store  store.Store
+	Clock  prr.Clock
+	IDGen  prr.IDGenerator
+	Logger *log.Logger
+
+	// DevMode enables development-only endpoints, such as /dev/seed. It
+	// must never be set in production.
+	DevMode bool
+
+	// Teams notifies a Microsoft Teams channel about PRR lifecycle
+	// events. It is nil when Teams notifications are not configured.
+	Teams *notify.TeamsClient
+
+	// Opsgenie raises alerts for submissions that indicate a service is
+	// not ready for production. It is nil when Opsgenie is not
+	// configured.
+	Opsgenie *notify.OpsgenieClient
+
+	// Slack posts a PRR summary to a Slack channel whenever a submission
+	// is finalized. It is nil when Slack notifications are not
+	// configured.
+	Slack *notify.SlackClient
+
+	// BaseURL, if set, is prepended to submission IDs in outgoing
+	// notifications to build a clickable link back to this server's UI.
+	// Empty means notifications reference the submission by ID only.
+	BaseURL string
+
+	// Gate evaluates submissions against the policy-as-code release gate.
+	// It is nil when no gate policy is configured.
+	Gate *policy.OPAEvaluator
+
+	// Tokens maps API tokens to principals. When empty, authentication is
+	// disabled (suitable for local development and --demo).
+	Tokens auth.TokenStore
+
+	// ES is pinged by handleReadyz to verify Elasticsearch connectivity.
+	// It's an es.Pinger rather than *es.Client so tests can substitute a
+	// fake instead of standing up a real cluster.
+	// It is nil when the service is running without an Elasticsearch
+	// backend, in which case readiness does not depend on it.
+	ES es.Pinger
+
+	// RequestTimeout bounds how long a single request's context stays
+	// live before being cancelled. Zero means defaultRequestTimeout.
+	RequestTimeout time.Duration
+
+	// SLogger, if set, emits one structured JSON log line per request
+	// (method, path, status, latency, request ID). Nil disables request
+	// logging.
+	SLogger *slog.Logger
+
+	// DisableStrictValidation turns off strict validation of submitted
+	// answers. By default (false) a submission containing an unknown
+	// question ID, a duplicate answer for the same question, or an
+	// unrecognized response value is rejected with 422 rather than
+	// silently accepted.
+	DisableStrictValidation bool
+
+	// WebhookDeliverer delivers signed event payloads to registered
+	// webhooks. Unlike Teams/Opsgenie it's always set by New, since
+	// webhooks are core functionality rather than an optional
+	// integration.
+	WebhookDeliverer *webhook.Deliverer
+
+	// RateLimitPerSecond caps how many requests a single client (keyed
+	// by bearer token, or source IP when unauthenticated) may make per
+	// second, enforced by a token bucket. Zero disables rate limiting,
+	// so ES doesn't need protecting from a well-behaved deployment's
+	// own traffic by default.
+	RateLimitPerSecond float64
+
+	// RateLimitBurst is the token bucket's capacity, i.e. how large a
+	// burst above RateLimitPerSecond a client may make before being
+	// throttled. Zero (or any value below 1) is treated as 1, the
+	// strictest useful burst. Ignored when RateLimitPerSecond is zero.
+	RateLimitBurst int
+
+	// MaxRequestBodySizeBytes caps the size of a POST/PUT request body.
+	// Requests over the limit are rejected with 413. Zero disables the
+	// limit.
+	MaxRequestBodySizeBytes int64
+
+	// CORSAllowedOrigins lists the origins browsers may call this API
+	// from, or ["*"] to allow any origin. Empty (the default) disables
+	// CORS entirely, since a same-origin deployment doesn't need it.
+	CORSAllowedOrigins []string
+
+	// CORSAllowedMethods lists the methods advertised in
+	// Access-Control-Allow-Methods. Empty uses a sensible default of
+	// GET, POST, PUT, DELETE, OPTIONS. Ignored when CORSAllowedOrigins
+	// is empty.
+	CORSAllowedMethods []string
+
+	// CORSAllowedHeaders lists the headers advertised in
+	// Access-Control-Allow-Headers. Empty uses a sensible default of
+	// Content-Type and Authorization. Ignored when CORSAllowedOrigins
+	// is empty.
+	CORSAllowedHeaders []string
+
+	// IdempotencyWindow bounds how long a POST /submissions retried with
+	// the same Idempotency-Key returns the original submission instead
+	// of creating a duplicate. Zero means defaultIdempotencyWindow.
+	IdempotencyWindow time.Duration
+
+	// Blob stores the file contents of submission attachments. It is
+	// nil when attachment uploads are disabled, in which case uploads
+	// to /prr/{id}/attachments and downloads return 501.
+	Blob blob.Store
+
+	// MaxAttachmentSizeBytes caps the size of an uploaded attachment.
+	// Zero means defaultMaxAttachmentSizeBytes.
+	MaxAttachmentSizeBytes int64
+
+	// AllowedAttachmentContentTypes lists the Content-Types accepted by
+	// /prr/{id}/attachments. Empty allows any type.
+	AllowedAttachmentContentTypes []string
+
+	// Jobs runs submission processing in the background when POST
+	// /submissions?async=true is used, so a caller doesn't block on
+	// scoring, template resolution and notifications. It is nil when
+	// async mode is disabled, in which case async=true is rejected with
+	// 501.
+	Jobs *jobqueue.Queue
+
+	// Email sends PRR lifecycle notifications (submission received,
+	// approved/rejected, expiry approaching) over SMTP. It is nil when
+	// email notifications are not configured.
+	Email *notify.EmailClient
+
+	// EmailTemplates customizes the subject/body of each lifecycle
+	// email. Any field left at its zero value uses the built-in default
+	// for that event. Ignored when Email is nil.
+	EmailTemplates EmailTemplates
+
+	// SigningKey, if set, HMACs every submission's content hash at
+	// submission time, so GET /prr/{id}/verify can additionally prove
+	// the hash itself came from this server rather than being forged
+	// alongside a tampered answer. Empty means tamper-evidence relies
+	// on the content hash alone.
+	SigningKey string
+
+	// Events fans out submission, approval, and readiness lifecycle
+	// events to GET /events subscribers. Unlike Teams/Opsgenie it's
+	// always set by New, since streaming live updates is core
+	// functionality rather than an optional integration.
+	Events *eventHub
+}
+
+// New builds an API with the given dependencies.
+func New(st store.Store, clock prr.Clock, idGen prr.IDGenerator, logger *log.Logger) *API {
+	return &API{
+		Store:            st,
+		Clock:            clock,
+		IDGen:            idGen,
+		Logger:           logger,
+		WebhookDeliverer: webhook.NewDeliverer(),
+		Events:           newEventHub(),
+	}
+}
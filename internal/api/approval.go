@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type approvalRequest struct {
+	Comment string `json:"comment"`
+}
+
+// handleApproveSubmission records a reviewer sign-off for a final
+// submission. The reviewer identity comes from the authenticated
+// principal, or "anonymous" when authentication is disabled.
+func (a *API) handleApproveSubmission(w http.ResponseWriter, r *http.Request, id string) {
+	a.recordApproval(w, r, id, prr.ApprovalApproved)
+}
+
+// handleRejectSubmission records a reviewer rejection for a final
+// submission.
+func (a *API) handleRejectSubmission(w http.ResponseWriter, r *http.Request, id string) {
+	a.recordApproval(w, r, id, prr.ApprovalRejected)
+}
+
+func (a *API) recordApproval(w http.ResponseWriter, r *http.Request, id string, decision prr.ApprovalDecision) {
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	if sub.Status != prr.SubmissionFinal {
+		writeAPIError(w, "only final submissions can be reviewed", http.StatusConflict)
+		return
+	}
+	before := sub
+
+	var req approvalRequest
+	if r.Body != nil {
+		_ = jsonvalidate.Decode(r.Body, &req)
+	}
+
+	reviewer := "anonymous"
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		reviewer = principal.Subject
+	}
+
+	sub.Approval = decision
+	sub.Approvals = append(sub.Approvals, prr.Approval{
+		Reviewer: reviewer,
+		Decision: decision,
+		Comment:  req.Comment,
+		At:       a.Clock.Now(),
+	})
+	sub.UpdatedAt = a.Clock.Now()
+	if decision == prr.ApprovalApproved {
+		sub.Locked = true
+	}
+
+	if err := a.Store.UpdateSubmission(r.Context(), sub); err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	a.recordAudit(r.Context(), "submission", sub.ID, prr.AuditActionUpdate, before, sub)
+	a.refreshServiceReadiness(r.Context(), sub.ServiceID)
+
+	if svc, err := a.Store.GetService(r.Context(), sub.ServiceID); err == nil {
+		event := prr.WebhookEventSubmissionApproved
+		tmpl, fallback := a.EmailTemplates.Approved, defaultEmailTemplates.Approved
+		if decision == prr.ApprovalRejected {
+			event = prr.WebhookEventSubmissionRejected
+			tmpl, fallback = a.EmailTemplates.Rejected, defaultEmailTemplates.Rejected
+		}
+		a.sendLifecycleEmail(sub.SubmitterEmail, tmpl, fallback, emailTemplateData{Service: svc, Submission: sub})
+		a.dispatchWebhookEvent(r.Context(), event, svc, sub)
+	}
+
+	writeJSON(w, http.StatusOK, sub)
+}
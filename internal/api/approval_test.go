@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestApprovalWorkflow(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.UpsertQuestion(httptest.NewRequest(http.MethodGet, "/", nil).Context(), prr.Question{ID: "q1"}); err != nil {
+		t.Fatalf("seed question: %v", err)
+	}
+
+	createBody, _ := json.Marshal(map[string]string{"name": "svc"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(map[string]interface{}{
+		"service_id": svc.ID,
+		"answers":    []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+	if sub.Approval != prr.ApprovalPending {
+		t.Fatalf("approval = %q, want pending", sub.Approval)
+	}
+
+	approveBody, _ := json.Marshal(map[string]string{"comment": "looks good"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/approve", bytes.NewReader(approveBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("approve status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var approved prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &approved)
+	if approved.Approval != prr.ApprovalApproved {
+		t.Fatalf("approval = %q, want approved", approved.Approval)
+	}
+	if len(approved.Approvals) != 1 || approved.Approvals[0].Reviewer != "anonymous" {
+		t.Fatalf("approvals = %+v", approved.Approvals)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/reject", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reject status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var rejected prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &rejected)
+	if rejected.Approval != prr.ApprovalRejected || len(rejected.Approvals) != 2 {
+		t.Fatalf("rejected = %+v", rejected)
+	}
+
+	draftBody, _ := json.Marshal(map[string]interface{}{
+		"service_id": svc.ID,
+		"status":     "draft",
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(draftBody)))
+	var draft prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &draft)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+draft.ID+"/approve", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("approve draft status = %d, want 409", rec.Code)
+	}
+}
+
+func TestApprovalEndpointsRejectCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID, Status: prr.SubmissionFinal}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	for _, path := range []string{"/submissions/" + sub.ID + "/approve", "/submissions/" + sub.ID + "/reject"} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.Header.Set(tenantHeader, "tenant-b")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s: status = %d, want 404, body=%s", path, rec.Code, rec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// handleUploadAttachment stores a multipart file upload ("file" field)
+// as evidence attached to the submission identified by id. It is
+// rejected with 501 when a.Blob is nil (attachments disabled), 413 when
+// the file exceeds MaxAttachmentSizeBytes, and 415 when its
+// Content-Type isn't in AllowedAttachmentContentTypes (when set).
+func (a *API) handleUploadAttachment(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if a.Blob == nil {
+		writeAPIError(w, "attachments are not enabled on this deployment", http.StatusNotImplemented)
+		return
+	}
+	if _, err := a.authorizedSubmission(r.Context(), r, id); err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	maxSize := a.MaxAttachmentSizeBytes
+	if maxSize <= 0 {
+		maxSize = defaultMaxAttachmentSizeBytes
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+	if err := r.ParseMultipartForm(maxSize); err != nil {
+		writeAPIError(w, "attachment exceeds maximum size or is not a valid multipart upload", http.StatusRequestEntityTooLarge)
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeAPIError(w, `missing "file" in multipart form`, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	if !a.attachmentContentTypeAllowed(contentType) {
+		writeAPIError(w, "content type "+contentType+" is not allowed", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	att := prr.Attachment{
+		ID:           a.IDGen.NewID(),
+		SubmissionID: id,
+		Filename:     header.Filename,
+		ContentType:  contentType,
+		CreatedAt:    a.Clock.Now(),
+	}
+	size, err := a.Blob.Put(r.Context(), att.ID, file)
+	if err != nil {
+		writeAPIError(w, "failed to store attachment", http.StatusInternalServerError)
+		return
+	}
+	att.Size = size
+
+	if err := a.Store.CreateAttachment(r.Context(), att); err != nil {
+		if errors.Is(err, store.ErrLocked) {
+			writeAPIError(w, "submission is locked and cannot receive new attachments", http.StatusConflict)
+			return
+		}
+		writeAPIError(w, "failed to record attachment", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "attachment", att.ID, prr.AuditActionCreate, nil, att)
+	writeJSON(w, http.StatusCreated, att)
+}
+
+// attachmentContentTypeAllowed reports whether contentType may be
+// uploaded, per AllowedAttachmentContentTypes. An empty allowlist
+// permits any type.
+func (a *API) attachmentContentTypeAllowed(contentType string) bool {
+	if len(a.AllowedAttachmentContentTypes) == 0 {
+		return true
+	}
+	for _, allowed := range a.AllowedAttachmentContentTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// handleListAttachments lists the attachments uploaded against the
+// submission identified by id, oldest first.
+func (a *API) handleListAttachments(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := a.authorizedSubmission(r.Context(), r, id); err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	attachments, err := a.Store.ListAttachmentsBySubmission(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, "failed to list attachments", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].CreatedAt.Before(attachments[j].CreatedAt) })
+	writeJSON(w, http.StatusOK, attachments)
+}
+
+// handleDownloadAttachment streams the file contents of the attachment
+// identified by id.
+func (a *API) handleDownloadAttachment(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if a.Blob == nil {
+		writeAPIError(w, "attachments are not enabled on this deployment", http.StatusNotImplemented)
+		return
+	}
+	att, err := a.authorizedAttachment(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "attachment")
+		return
+	}
+	rc, err := a.Blob.Get(r.Context(), att.ID)
+	if err != nil {
+		writeAPIError(w, "failed to load attachment contents", http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	if att.ContentType != "" {
+		w.Header().Set("Content-Type", att.ContentType)
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+att.Filename+`"`)
+	io.Copy(w, rc)
+}
+
+// handleDeleteAttachment removes an attachment's metadata and file
+// contents.
+func (a *API) handleDeleteAttachment(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodDelete {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	att, err := a.authorizedAttachment(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "attachment")
+		return
+	}
+	if a.Blob != nil {
+		if err := a.Blob.Delete(r.Context(), att.ID); err != nil {
+			writeAPIError(w, "failed to delete attachment contents", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := a.Store.DeleteAttachment(r.Context(), id); err != nil {
+		writeStoreError(w, err, "attachment")
+		return
+	}
+	a.recordAudit(r.Context(), "attachment", id, prr.AuditActionDelete, att, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/blob"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func newMultipartUpload(t *testing.T, field, filename, contentType string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreatePart(map[string][]string{
+		"Content-Disposition": {`form-data; name="` + field + `"; filename="` + filename + `"`},
+		"Content-Type":        {contentType},
+	})
+	if err != nil {
+		t.Fatalf("CreatePart: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("write part: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close writer: %v", err)
+	}
+	return body, w.FormDataContentType()
+}
+
+func TestAttachmentUploadListDownloadDelete(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	disk, err := blob.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.Blob = disk
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	body, contentType := newMultipartUpload(t, "file", "diagram.png", "image/png", []byte("fake-png-bytes"))
+	req := httptest.NewRequest(http.MethodPost, "/prr/"+sub.ID+"/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("upload status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var att prr.Attachment
+	if err := json.Unmarshal(rec.Body.Bytes(), &att); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if att.Filename != "diagram.png" || att.Size != int64(len("fake-png-bytes")) {
+		t.Fatalf("unexpected attachment metadata: %+v", att)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/attachments", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var listed []prr.Attachment
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	if len(listed) != 1 || listed[0].ID != att.ID {
+		t.Fatalf("list = %+v, want [%s]", listed, att.ID)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/attachments/"+att.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("download status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if rec.Body.String() != "fake-png-bytes" {
+		t.Fatalf("downloaded body = %q, want %q", rec.Body.String(), "fake-png-bytes")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/prr/attachments/"+att.ID, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if _, err := st.GetAttachment(ctx, att.ID); err == nil {
+		t.Fatalf("expected attachment metadata to be deleted")
+	}
+}
+
+func TestAttachmentEndpointsRejectCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	disk, err := blob.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.Blob = disk
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	att := prr.Attachment{ID: "att-1", SubmissionID: sub.ID, Filename: "diagram.png"}
+	if err := st.CreateAttachment(ctx, att); err != nil {
+		t.Fatalf("create attachment: %v", err)
+	}
+
+	body, contentType := newMultipartUpload(t, "file", "diagram.png", "image/png", []byte("fake-png-bytes"))
+	uploadReq := httptest.NewRequest(http.MethodPost, "/prr/"+sub.ID+"/attachments", body)
+	uploadReq.Header.Set("Content-Type", contentType)
+
+	for _, req := range []*http.Request{
+		uploadReq,
+		httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/attachments", nil),
+		httptest.NewRequest(http.MethodGet, "/prr/attachments/"+att.ID, nil),
+		httptest.NewRequest(http.MethodDelete, "/prr/attachments/"+att.ID, nil),
+	} {
+		req.Header.Set(tenantHeader, "tenant-b")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s %s: status = %d, want 404, body=%s", req.Method, req.URL.Path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestAttachmentUploadRejectsDisallowedContentType(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	disk, err := blob.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.Blob = disk
+	a.AllowedAttachmentContentTypes = []string{"application/pdf"}
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	st.CreateService(ctx, svc)
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	st.CreateSubmission(ctx, sub)
+
+	body, contentType := newMultipartUpload(t, "file", "diagram.png", "image/png", []byte("data"))
+	req := httptest.NewRequest(http.MethodPost, "/prr/"+sub.ID+"/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("status = %d, want 415, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAttachmentUploadDisabledWithoutBlobStore(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	st.CreateService(ctx, svc)
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	st.CreateSubmission(ctx, sub)
+
+	body, contentType := newMultipartUpload(t, "file", "diagram.png", "image/png", []byte("data"))
+	req := httptest.NewRequest(http.MethodPost, "/prr/"+sub.ID+"/attachments", body)
+	req.Header.Set("Content-Type", contentType)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("status = %d, want 501, body=%s", rec.Code, rec.Body.String())
+	}
+}
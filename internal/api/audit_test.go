@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestAuditLogRecordsServiceMutations(t *testing.T) {
+	a := New(memory.New(), prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	body, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(body)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/services/"+svc.ID, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, want 204", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/audit?entity=service&id="+svc.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("audit log status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp auditLogResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(resp.Entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2 (create + delete)", len(resp.Entries))
+	}
+	if resp.Entries[0].Action != prr.AuditActionDelete {
+		t.Fatalf("newest entry action = %q, want %q", resp.Entries[0].Action, prr.AuditActionDelete)
+	}
+	if resp.Entries[1].Action != prr.AuditActionCreate {
+		t.Fatalf("oldest entry action = %q, want %q", resp.Entries[1].Action, prr.AuditActionCreate)
+	}
+}
+
+func TestAuditLogFiltersByEntity(t *testing.T) {
+	a := New(memory.New(), prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+
+	secBody, _ := json.Marshal(map[string]string{"name": "security"})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/admin/sections", bytes.NewReader(secBody)))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/audit?entity=section", nil))
+	var resp auditLogResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(resp.Entries) != 1 || resp.Entries[0].Entity != "section" {
+		t.Fatalf("entries = %+v, want exactly one section entry", resp.Entries)
+	}
+}
@@ -0,0 +1,274 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// backupVersion identifies the shape of a backupArchive, so a future
+// change to what's captured can refuse to load an archive it doesn't
+// know how to restore instead of silently dropping fields.
+const backupVersion = 1
+
+// backupArchive is a portable, point-in-time dump of everything a Store
+// holds, produced by handleBackup and consumed by handleRestore. There's
+// no snapshot repository or object storage configured for most
+// installs, so this is the disaster-recovery path: one JSON document a
+// caller can save wherever they like and replay later, rather than
+// relying on Elasticsearch's own snapshot machinery.
+type backupArchive struct {
+	Version     int              `json:"version"`
+	Services    []prr.Service    `json:"services,omitempty"`
+	Submissions []prr.Submission `json:"submissions,omitempty"`
+	Sections    []prr.Section    `json:"sections,omitempty"`
+	Questions   []prr.Question   `json:"questions,omitempty"`
+	Templates   []prr.Template   `json:"templates,omitempty"`
+	Teams       []prr.Team       `json:"teams,omitempty"`
+	Tenants     []prr.Tenant     `json:"tenants,omitempty"`
+	Attachments []prr.Attachment `json:"attachments,omitempty"`
+	ActionItems []prr.ActionItem `json:"action_items,omitempty"`
+	Webhooks    []prr.Webhook    `json:"webhooks,omitempty"`
+
+	ScoringThresholds prr.ScoringThresholds `json:"scoring_thresholds"`
+	ExpiryPolicy      prr.ExpiryPolicy      `json:"expiry_policy"`
+	JiraConfig        prr.JiraConfig        `json:"jira_config"`
+}
+
+// handleBackup returns a backupArchive of the whole Store to the
+// caller. The in-memory and Postgres backends both have to answer every
+// List* call anyway, so there's no separate snapshot operation to
+// trigger: the archive is just those results, assembled in full and then
+// written as a single JSON response.
+func (a *API) handleBackup(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+
+	services, err := a.Store.ListServices(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list services", http.StatusInternalServerError)
+		return
+	}
+	var submissions []prr.Submission
+	for _, svc := range services {
+		subs, err := a.Store.ListSubmissionsByService(ctx, svc.ID)
+		if err != nil {
+			writeAPIError(w, fmt.Sprintf("failed to list submissions for %s: %v", svc.ID, err), http.StatusInternalServerError)
+			return
+		}
+		submissions = append(submissions, subs...)
+	}
+	sections, err := a.Store.ListSections(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list sections", http.StatusInternalServerError)
+		return
+	}
+	questions, err := a.Store.ListQuestions(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+		return
+	}
+	templates, err := a.Store.ListTemplates(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list templates", http.StatusInternalServerError)
+		return
+	}
+	teams, err := a.Store.ListTeams(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list teams", http.StatusInternalServerError)
+		return
+	}
+	tenants, err := a.Store.ListTenants(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list tenants", http.StatusInternalServerError)
+		return
+	}
+	var attachments []prr.Attachment
+	for _, sub := range submissions {
+		atts, err := a.Store.ListAttachmentsBySubmission(ctx, sub.ID)
+		if err != nil {
+			writeAPIError(w, fmt.Sprintf("failed to list attachments for %s: %v", sub.ID, err), http.StatusInternalServerError)
+			return
+		}
+		attachments = append(attachments, atts...)
+	}
+	var actionItems []prr.ActionItem
+	for _, svc := range services {
+		items, err := a.Store.ListActionItemsByService(ctx, svc.ID)
+		if err != nil {
+			writeAPIError(w, fmt.Sprintf("failed to list action items for %s: %v", svc.ID, err), http.StatusInternalServerError)
+			return
+		}
+		actionItems = append(actionItems, items...)
+	}
+	webhooks, err := a.Store.ListWebhooks(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list webhooks", http.StatusInternalServerError)
+		return
+	}
+	scoringThresholds, err := a.Store.GetScoringThresholds(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to load scoring thresholds", http.StatusInternalServerError)
+		return
+	}
+	expiryPolicy, err := a.Store.GetExpiryPolicy(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to load expiry policy", http.StatusInternalServerError)
+		return
+	}
+	jiraConfig, err := a.Store.GetJiraConfig(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to load jira config", http.StatusInternalServerError)
+		return
+	}
+
+	archive := backupArchive{
+		Version:           backupVersion,
+		Services:          services,
+		Submissions:       submissions,
+		Sections:          sections,
+		Questions:         questions,
+		Templates:         templates,
+		Teams:             teams,
+		Tenants:           tenants,
+		Attachments:       attachments,
+		ActionItems:       actionItems,
+		Webhooks:          webhooks,
+		ScoringThresholds: scoringThresholds,
+		ExpiryPolicy:      expiryPolicy,
+		JiraConfig:        jiraConfig,
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="backup.json"`)
+	writeJSON(w, http.StatusOK, archive)
+}
+
+// handleRestore loads a backupArchive produced by handleBackup, creating
+// or overwriting every record it contains. It doesn't try to reconcile
+// the archive against whatever is already in the Store — a disaster
+// recovery restore is expected to run against an empty one — so it
+// simply replays every Create/Upsert call the archive implies.
+// Attachment metadata is restored, but the attachment's own bytes live
+// in a blob.Store this endpoint doesn't touch, so restoring onto a new
+// environment also requires separately restoring that blob storage.
+func (a *API) handleRestore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	defer r.Body.Close()
+
+	var archive backupArchive
+	if err := jsonvalidate.Decode(r.Body, &archive); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if archive.Version != backupVersion {
+		writeAPIError(w, fmt.Sprintf("unsupported backup version %d", archive.Version), http.StatusBadRequest)
+		return
+	}
+	ctx := r.Context()
+
+	for _, s := range archive.Sections {
+		if err := a.Store.UpsertSection(ctx, s); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore section %s: %v", s.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, q := range archive.Questions {
+		if err := a.Store.UpsertQuestion(ctx, q); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore question %s: %v", q.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, t := range archive.Templates {
+		if err := a.Store.UpsertTemplate(ctx, t); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore template %s: %v", t.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, tm := range archive.Teams {
+		if err := a.Store.UpsertTeam(ctx, tm); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore team %s: %v", tm.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, tn := range archive.Tenants {
+		if err := a.Store.UpsertTenant(ctx, tn); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore tenant %s: %v", tn.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, svc := range archive.Services {
+		if err := a.Store.CreateService(ctx, svc); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore service %s: %v", svc.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, sub := range archive.Submissions {
+		if err := a.Store.CreateSubmission(ctx, sub); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore submission %s: %v", sub.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, att := range archive.Attachments {
+		if err := a.Store.CreateAttachment(ctx, att); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore attachment %s: %v", att.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, item := range archive.ActionItems {
+		if err := a.Store.CreateActionItem(ctx, item); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore action item %s: %v", item.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	for _, hook := range archive.Webhooks {
+		if err := a.Store.CreateWebhook(ctx, hook); err != nil {
+			writeAPIError(w, fmt.Sprintf("restore webhook %s: %v", hook.ID, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := a.Store.SetScoringThresholds(ctx, archive.ScoringThresholds); err != nil {
+		writeAPIError(w, "restore scoring thresholds: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := a.Store.SetExpiryPolicy(ctx, archive.ExpiryPolicy); err != nil {
+		writeAPIError(w, "restore expiry policy: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := a.Store.SetJiraConfig(ctx, archive.JiraConfig); err != nil {
+		writeAPIError(w, "restore jira config: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	a.recordAudit(ctx, "backup", "restore", prr.AuditActionCreate, nil, map[string]int{
+		"services":     len(archive.Services),
+		"submissions":  len(archive.Submissions),
+		"sections":     len(archive.Sections),
+		"questions":    len(archive.Questions),
+		"templates":    len(archive.Templates),
+		"teams":        len(archive.Teams),
+		"tenants":      len(archive.Tenants),
+		"attachments":  len(archive.Attachments),
+		"action_items": len(archive.ActionItems),
+		"webhooks":     len(archive.Webhooks),
+	})
+
+	writeJSON(w, http.StatusOK, map[string]int{
+		"services":     len(archive.Services),
+		"submissions":  len(archive.Submissions),
+		"sections":     len(archive.Sections),
+		"questions":    len(archive.Questions),
+		"templates":    len(archive.Templates),
+		"teams":        len(archive.Teams),
+		"tenants":      len(archive.Tenants),
+		"attachments":  len(archive.Attachments),
+		"action_items": len(archive.ActionItems),
+		"webhooks":     len(archive.Webhooks),
+	})
+}
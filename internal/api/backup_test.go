@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestBackupRestoreRoundTrip(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+	ctx := context.Background()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "payments"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-1", Name: "Security"}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-1", ServiceID: "svc-1", Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/backup", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("backup status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var archive backupArchive
+	if err := json.Unmarshal(rec.Body.Bytes(), &archive); err != nil {
+		t.Fatalf("unmarshal archive: %v", err)
+	}
+	if len(archive.Services) != 1 || len(archive.Sections) != 1 || len(archive.Questions) != 1 || len(archive.Submissions) != 1 {
+		t.Fatalf("archive = %+v", archive)
+	}
+
+	fresh := memory.New()
+	b := New(fresh, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	freshRouter := b.Router()
+
+	body, _ := json.Marshal(archive)
+	rec = httptest.NewRecorder()
+	freshRouter.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/restore", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("restore status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := fresh.GetSubmission(ctx, "sub-1"); err != nil {
+		t.Fatalf("restored submission not found: %v", err)
+	}
+	if _, err := fresh.GetQuestion(ctx, "q1"); err != nil {
+		t.Fatalf("restored question not found: %v", err)
+	}
+}
+
+func TestRestoreRejectsUnsupportedVersion(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	body, _ := json.Marshal(backupArchive{Version: 99})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/restore", bytes.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestBackupRejectsNonPost(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/backup", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want 405", rec.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func newBenchAPI(b *testing.B) *API {
+	b.Helper()
+	return New(memory.New(), prr.SystemClock{}, prr.UUIDGenerator{}, nil)
+}
+
+// BenchmarkCreateSubmission measures submission throughput so that
+// regressions (like the historical N+1 search) are caught before release.
+func BenchmarkCreateSubmission(b *testing.B) {
+	a := newBenchAPI(b)
+	srv := httptest.NewServer(a.Router())
+	defer srv.Close()
+
+	body, _ := json.Marshal(map[string]string{"name": "bench-service"})
+	resp, err := http.Post(srv.URL+"/services", "application/json", bytes.NewReader(body))
+	if err != nil {
+		b.Fatal(err)
+	}
+	var svc struct {
+		ID string `json:"id"`
+	}
+	json.NewDecoder(resp.Body).Decode(&svc)
+	resp.Body.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sub, _ := json.Marshal(map[string]interface{}{
+			"service_id": svc.ID,
+			"answers":    []map[string]string{{"question_id": "q-1", "value": "yes"}},
+		})
+		resp, err := http.Post(srv.URL+"/submissions", "application/json", bytes.NewReader(sub))
+		if err != nil {
+			b.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+}
+
+// BenchmarkListServices measures listing throughput as the number of
+// services grows.
+func BenchmarkListServices(b *testing.B) {
+	a := newBenchAPI(b)
+	srv := httptest.NewServer(a.Router())
+	defer srv.Close()
+
+	for i := 0; i < 500; i++ {
+		body, _ := json.Marshal(map[string]string{"name": fmt.Sprintf("svc-%d", i)})
+		resp, err := http.Post(srv.URL+"/services", "application/json", bytes.NewReader(body))
+		if err != nil {
+			b.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		resp, err := http.Get(srv.URL + "/services")
+		if err != nil {
+			b.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// bulkSubmissionItem is one submission in a POST /prr/bulk request. It
+// carries the historical record's own timestamp and submitting user
+// instead of stamping them with the current time and caller identity
+// the way handleCreateSubmission does, since it exists to backfill
+// reviews that already happened outside this system.
+type bulkSubmissionItem struct {
+	ServiceID  string               `json:"service_id"`
+	TemplateID string               `json:"template_id,omitempty"`
+	Answers    []prr.Answer         `json:"answers"`
+	Status     prr.SubmissionStatus `json:"status,omitempty"`
+	// CreatedAt is required: it's the whole point of a historical
+	// import, and defaulting it to the import time would silently
+	// corrupt the trend/expiry data every later feature depends on.
+	CreatedAt time.Time `json:"created_at"`
+	// UserID is recorded as the audit log actor for this item, in
+	// place of the caller's own bearer-token identity.
+	UserID string `json:"user_id"`
+}
+
+type bulkImportRequest struct {
+	Submissions []bulkSubmissionItem `json:"submissions"`
+}
+
+// bulkSubmissionResult reports the outcome of importing a single item
+// of a POST /prr/bulk request, keeping the response ordered the same
+// as the request so callers can correlate rows back to the spreadsheet
+// they came from.
+type bulkSubmissionResult struct {
+	Index      int             `json:"index"`
+	Submission *prr.Submission `json:"submission,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+type bulkImportResponse struct {
+	Results []bulkSubmissionResult `json:"results"`
+}
+
+// handleBulkImportSubmissions imports a batch of historical PRR
+// submissions, e.g. from a spreadsheet, with explicit timestamps and
+// submitting users. Each item is validated and stored independently,
+// so one bad row doesn't fail the rest of the batch; the response
+// reports a per-item success or failure rather than a single overall
+// status. Final items are graded against the current question bank and
+// scoring thresholds, but imported submissions are not re-notified (no
+// webhooks, email, or Jira tickets fire), since they represent reviews
+// that already happened.
+func (a *API) handleBulkImportSubmissions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req bulkImportRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Submissions) == 0 {
+		writeAPIError(w, "submissions must not be empty", http.StatusBadRequest)
+		return
+	}
+
+	results := make([]bulkSubmissionResult, len(req.Submissions))
+	for i, item := range req.Submissions {
+		sub, err := a.importBulkSubmission(r, item)
+		if err != nil {
+			results[i] = bulkSubmissionResult{Index: i, Error: err.Error()}
+			continue
+		}
+		results[i] = bulkSubmissionResult{Index: i, Submission: &sub}
+	}
+	writeJSON(w, http.StatusOK, bulkImportResponse{Results: results})
+}
+
+// importBulkSubmission validates and stores a single item of a bulk
+// import request.
+func (a *API) importBulkSubmission(r *http.Request, item bulkSubmissionItem) (prr.Submission, error) {
+	ctx := r.Context()
+	if item.ServiceID == "" {
+		return prr.Submission{}, errors.New("service_id is required")
+	}
+	if item.CreatedAt.IsZero() {
+		return prr.Submission{}, errors.New("created_at is required")
+	}
+	status := item.Status
+	if status == "" {
+		status = prr.SubmissionFinal
+	}
+	if status != prr.SubmissionDraft && status != prr.SubmissionFinal {
+		return prr.Submission{}, errors.New(`status must be "draft" or "final"`)
+	}
+
+	if _, err := a.authorizedService(ctx, r, item.ServiceID); err != nil {
+		return prr.Submission{}, fmt.Errorf("service: %w", err)
+	}
+	if item.TemplateID != "" {
+		if _, err := a.Store.GetTemplate(ctx, item.TemplateID); err != nil {
+			return prr.Submission{}, fmt.Errorf("template: %w", err)
+		}
+	}
+	if !a.DisableStrictValidation {
+		allowed, err := a.questionsForTemplateID(ctx, item.TemplateID)
+		if err != nil {
+			return prr.Submission{}, fmt.Errorf("failed to validate answers: %w", err)
+		}
+		if errs := prr.ValidateAnswers(item.Answers, allowed); len(errs) > 0 {
+			return prr.Submission{}, fmt.Errorf("invalid answers: %v", errs)
+		}
+	}
+
+	answers, err := a.stampAnswerVersions(ctx, item.Answers)
+	if err != nil {
+		return prr.Submission{}, fmt.Errorf("failed to stamp question versions: %w", err)
+	}
+
+	sub := prr.Submission{
+		ID:         a.IDGen.NewID(),
+		ServiceID:  item.ServiceID,
+		TemplateID: item.TemplateID,
+		Answers:    answers,
+		Status:     status,
+		CreatedAt:  item.CreatedAt,
+		UpdatedAt:  item.CreatedAt,
+	}
+	if status == prr.SubmissionFinal {
+		sub.Approval = prr.ApprovalPending
+		grade, blocking, err := a.evaluateSubmission(ctx, sub)
+		if err != nil {
+			return prr.Submission{}, fmt.Errorf("failed to grade submission: %w", err)
+		}
+		sub.Grade = grade
+		sub.BlockingIssues = blocking
+	}
+	sub = a.stampContentHash(sub)
+	if err := a.Store.CreateSubmission(ctx, sub); err != nil {
+		return prr.Submission{}, fmt.Errorf("failed to create submission: %w", err)
+	}
+
+	actor := item.UserID
+	if actor == "" {
+		actor = "anonymous"
+	}
+	a.recordAuditAs(ctx, actor, "submission", sub.ID, prr.AuditActionCreate, nil, sub)
+	a.refreshServiceReadiness(ctx, sub.ServiceID)
+
+	return sub, nil
+}
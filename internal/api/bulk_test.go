@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestBulkImportSubmissionsReportsPerItemOutcome(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+
+	body, _ := json.Marshal(bulkImportRequest{
+		Submissions: []bulkSubmissionItem{
+			{
+				ServiceID: svc.ID,
+				Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+				CreatedAt: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+				UserID:    "alice",
+			},
+			{
+				ServiceID: "does-not-exist",
+				CreatedAt: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC),
+				UserID:    "bob",
+			},
+			{
+				ServiceID: svc.ID,
+				UserID:    "carol",
+			},
+		},
+	})
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/bulk", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp bulkImportResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("len(Results) = %d, want 3", len(resp.Results))
+	}
+	if resp.Results[0].Error != "" || resp.Results[0].Submission == nil {
+		t.Fatalf("item 0 = %+v, want success", resp.Results[0])
+	}
+	if resp.Results[0].Submission.CreatedAt.Year() != 2023 {
+		t.Fatalf("item 0 CreatedAt = %v, want explicit timestamp preserved", resp.Results[0].Submission.CreatedAt)
+	}
+	if resp.Results[1].Error == "" {
+		t.Fatalf("item 1 = %+v, want error for missing service", resp.Results[1])
+	}
+	if resp.Results[2].Error == "" {
+		t.Fatalf("item 2 = %+v, want error for missing created_at", resp.Results[2])
+	}
+
+	entries, err := st.ListAuditEntries(ctx, store.AuditFilter{Entity: "submission"})
+	if err != nil {
+		t.Fatalf("list audit entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Actor != "alice" {
+		t.Fatalf("audit entries = %+v, want one entry attributed to alice", entries)
+	}
+}
+
+func TestBulkImportSubmissionsRejectsCrossTenantService(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+
+	body, _ := json.Marshal(bulkImportRequest{
+		Submissions: []bulkSubmissionItem{
+			{
+				ServiceID: svc.ID,
+				Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+				CreatedAt: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC),
+				UserID:    "alice",
+			},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/prr/bulk", bytes.NewReader(body))
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp bulkImportResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Error == "" || resp.Results[0].Submission != nil {
+		t.Fatalf("item 0 = %+v, want error for cross-tenant service", resp.Results[0])
+	}
+}
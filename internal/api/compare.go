@@ -0,0 +1,258 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/report"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// handleCompareSubmissions compares two submissions of the same service,
+// identified by the "from" and "to" query parameters. format=html and
+// format=markdown render the comparison as a document suitable for
+// pasting into a change ticket or emailing to stakeholders; any other
+// value (or the parameter left off) returns the comparison as JSON.
+func (a *API) handleCompareSubmissions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	fromID := r.URL.Query().Get("from")
+	toID := r.URL.Query().Get("to")
+	if fromID == "" || toID == "" {
+		writeAPIError(w, "from and to are required", http.StatusBadRequest)
+		return
+	}
+
+	from, err := a.authorizedSubmission(r.Context(), r, fromID)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	to, err := a.authorizedSubmission(r.Context(), r, toID)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	if from.ServiceID != to.ServiceID {
+		writeAPIError(w, "from and to must be submissions of the same service", http.StatusBadRequest)
+		return
+	}
+	a.respondWithComparison(w, r, from, to)
+}
+
+// handleCompareLatest compares a service's latest submission against the
+// next most recent one, identified by the "service_id" query parameter,
+// without the caller needing to look up submission IDs first. If the
+// service has a pinned baseline submission (set via POST
+// /prr/{id}/baseline) and it isn't itself the latest submission, the
+// latest is compared against the baseline instead of the second most
+// recent submission, so a baseline stays the comparison point across
+// however many submissions follow it.
+func (a *API) handleCompareLatest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	serviceID := r.URL.Query().Get("service_id")
+	if serviceID == "" {
+		writeAPIError(w, "service_id is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := a.authorizedService(r.Context(), r, serviceID); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+
+	all, err := a.Store.ListSubmissionsByService(r.Context(), serviceID)
+	if err != nil {
+		writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+		return
+	}
+	if len(all) == 0 {
+		writeAPIError(w, "service has no submissions to compare", http.StatusBadRequest)
+		return
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+	latest := all[len(all)-1]
+
+	var baseline *prr.Submission
+	for i := range all {
+		if all[i].IsBaseline {
+			baseline = &all[i]
+			break
+		}
+	}
+
+	var from prr.Submission
+	if baseline != nil && baseline.ID != latest.ID {
+		from = *baseline
+	} else if len(all) >= 2 {
+		from = all[len(all)-2]
+	} else {
+		writeAPIError(w, "service needs a second submission or a pinned baseline to compare against", http.StatusBadRequest)
+		return
+	}
+	a.respondWithComparison(w, r, from, latest)
+}
+
+// serviceComparison is the result of comparing the latest submissions
+// of two different services, identifying which side of the Comparison
+// belongs to which service since, unlike handleCompareSubmissions, the
+// two sides aren't submissions of the same service.
+type serviceComparison struct {
+	FromService prr.Service    `json:"from_service"`
+	ToService   prr.Service    `json:"to_service"`
+	Comparison  prr.Comparison `json:"comparison"`
+}
+
+// handleCompareServices benchmarks two different services against each
+// other by diffing the latest submission of each, section-by-section
+// and question-by-question, identified by the "service_id1" and
+// "service_id2" query parameters.
+func (a *API) handleCompareServices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	serviceID1 := r.URL.Query().Get("service_id1")
+	serviceID2 := r.URL.Query().Get("service_id2")
+	if serviceID1 == "" || serviceID2 == "" {
+		writeAPIError(w, "service_id1 and service_id2 are required", http.StatusBadRequest)
+		return
+	}
+
+	svc1, err := a.authorizedService(r.Context(), r, serviceID1)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	svc2, err := a.authorizedService(r.Context(), r, serviceID2)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	from, err := a.latestSubmission(r.Context(), serviceID1)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	to, err := a.latestSubmission(r.Context(), serviceID2)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	fromScoped, fromQuestions, err := a.scopeSubmissionToTemplate(r.Context(), from)
+	if err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+	toScoped, toQuestions, err := a.scopeSubmissionToTemplate(r.Context(), to)
+	if err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+	fromScore := prr.ComputeScore(fromScoped, fromQuestions)
+	toScore := prr.ComputeScore(toScoped, toQuestions)
+	questions := append(append([]prr.Question{}, fromQuestions...), toQuestions...)
+
+	writeJSON(w, http.StatusOK, serviceComparison{
+		FromService: svc1,
+		ToService:   svc2,
+		Comparison:  prr.CompareSubmissions(from, to, fromScore, toScore, questions),
+	})
+}
+
+// latestSubmission returns the most recently created submission for
+// serviceID. It returns store.ErrNotFound if the service has never
+// submitted.
+func (a *API) latestSubmission(ctx context.Context, serviceID string) (prr.Submission, error) {
+	all, err := a.Store.ListSubmissionsByService(ctx, serviceID)
+	if err != nil {
+		return prr.Submission{}, err
+	}
+	if len(all) == 0 {
+		return prr.Submission{}, store.ErrNotFound
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+	return all[len(all)-1], nil
+}
+
+// respondWithComparison scores from and to against their own templates,
+// computes their Comparison, and writes it in the format requested by
+// the "format" query parameter (see handleCompareSubmissions).
+func (a *API) respondWithComparison(w http.ResponseWriter, r *http.Request, from, to prr.Submission) {
+	svc, err := a.authorizedService(r.Context(), r, from.ServiceID)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+
+	fromScoped, fromQuestions, err := a.scopeSubmissionToTemplate(r.Context(), from)
+	if err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+	toScoped, toQuestions, err := a.scopeSubmissionToTemplate(r.Context(), to)
+	if err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+	fromScore := prr.ComputeScore(fromScoped, fromQuestions)
+	toScore := prr.ComputeScore(toScoped, toQuestions)
+
+	questions := append(append([]prr.Question{}, fromQuestions...), toQuestions...)
+	cmp := prr.CompareSubmissions(from, to, fromScore, toScore, questions)
+
+	switch r.URL.Query().Get("format") {
+	case "html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(report.RenderComparisonHTML(svc, cmp)))
+	case "markdown":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Write([]byte(report.RenderComparisonMarkdown(svc, cmp)))
+	default:
+		writeJSON(w, http.StatusOK, cmp)
+	}
+}
+
+// handleSetBaseline marks the submission identified by id as its
+// service's pinned comparison point, clearing the flag from any
+// previous baseline of the same service so at most one stays set.
+func (a *API) handleSetBaseline(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	siblings, err := a.Store.ListSubmissionsByService(r.Context(), sub.ServiceID)
+	if err != nil {
+		writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+		return
+	}
+	for _, sibling := range siblings {
+		if sibling.ID != sub.ID && sibling.IsBaseline {
+			sibling.IsBaseline = false
+			if err := a.Store.UpdateSubmission(r.Context(), sibling); err != nil {
+				writeAPIError(w, "failed to clear previous baseline", http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	sub.IsBaseline = true
+	if err := a.Store.UpdateSubmission(r.Context(), sub); err != nil {
+		writeAPIError(w, "failed to set baseline", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestCompareLatestComparesTwoMostRecentSubmissions(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	older := prr.Submission{ID: "sub-1", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-01-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}}
+	newer := prr.Submission{ID: "sub-2", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-02-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}
+	if err := st.CreateSubmission(ctx, older); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, newer); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare/latest?service_id="+svc.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var cmp prr.Comparison
+	if err := json.Unmarshal(rec.Body.Bytes(), &cmp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if cmp.FromSubmissionID != "sub-1" || cmp.ToSubmissionID != "sub-2" {
+		t.Fatalf("comparison = %s -> %s, want sub-1 -> sub-2", cmp.FromSubmissionID, cmp.ToSubmissionID)
+	}
+}
+
+func TestCompareLatestPrefersPinnedBaselineOverSecondMostRecent(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	baseline := prr.Submission{ID: "sub-1", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-01-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}}
+	middle := prr.Submission{ID: "sub-2", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-02-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}}
+	latest := prr.Submission{ID: "sub-3", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-03-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}
+	for _, sub := range []prr.Submission{baseline, middle, latest} {
+		if err := st.CreateSubmission(ctx, sub); err != nil {
+			t.Fatalf("create submission: %v", err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/sub-1/baseline", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("set baseline status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare/latest?service_id="+svc.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var cmp prr.Comparison
+	if err := json.Unmarshal(rec.Body.Bytes(), &cmp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if cmp.FromSubmissionID != "sub-1" || cmp.ToSubmissionID != "sub-3" {
+		t.Fatalf("comparison = %s -> %s, want pinned baseline sub-1 -> latest sub-3", cmp.FromSubmissionID, cmp.ToSubmissionID)
+	}
+}
+
+func TestSetBaselineClearsPreviousBaseline(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	first := prr.Submission{ID: "sub-1", ServiceID: svc.ID, CreatedAt: time.Unix(0, 0)}
+	second := prr.Submission{ID: "sub-2", ServiceID: svc.ID, CreatedAt: time.Unix(1, 0)}
+	if err := st.CreateSubmission(ctx, first); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, second); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/prr/sub-1/baseline", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/prr/sub-2/baseline", nil))
+
+	got1, err := st.GetSubmission(ctx, "sub-1")
+	if err != nil {
+		t.Fatalf("get submission: %v", err)
+	}
+	got2, err := st.GetSubmission(ctx, "sub-2")
+	if err != nil {
+		t.Fatalf("get submission: %v", err)
+	}
+	if got1.IsBaseline {
+		t.Fatalf("sub-1.IsBaseline = true, want false after sub-2 was pinned")
+	}
+	if !got2.IsBaseline {
+		t.Fatalf("sub-2.IsBaseline = false, want true")
+	}
+}
+
+func TestCompareLatestRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	older := prr.Submission{ID: "sub-1", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-01-01T00:00:00Z")}
+	newer := prr.Submission{ID: "sub-2", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-02-01T00:00:00Z")}
+	if err := st.CreateSubmission(ctx, older); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, newer); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/compare/latest?service_id="+svc.ID, nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSetBaselineRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-1", ServiceID: svc.ID}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/prr/sub-1/baseline", nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCompareLatestRequiresSecondSubmissionOrBaseline(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-1", ServiceID: svc.ID}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare/latest?service_id="+svc.ID, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,268 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func setupCompareFixture(t *testing.T) (a *API, router http.Handler, svc prr.Service, from, to prr.Submission) {
+	t.Helper()
+	st := memory.New()
+	a = New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router = a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-1", Name: "Security"}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is data encrypted at rest?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	createBody, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(createBody)))
+	json.Unmarshal(rec.Body.Bytes(), &from)
+
+	createBody, _ = json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(createBody)))
+	json.Unmarshal(rec.Body.Bytes(), &to)
+
+	return a, router, svc, from, to
+}
+
+func TestCompareSubmissionsJSON(t *testing.T) {
+	_, router, _, from, to := setupCompareFixture(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare?from="+from.ID+"&to="+to.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var cmp prr.Comparison
+	if err := json.Unmarshal(rec.Body.Bytes(), &cmp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(cmp.ChangedAnswers) != 1 || cmp.ChangedAnswers[0].QuestionText != "Is data encrypted at rest?" {
+		t.Fatalf("ChangedAnswers = %+v", cmp.ChangedAnswers)
+	}
+	if cmp.ReadinessDelta <= 0 {
+		t.Fatalf("ReadinessDelta = %v, want positive", cmp.ReadinessDelta)
+	}
+}
+
+func TestCompareSubmissionsHTML(t *testing.T) {
+	_, router, _, from, to := setupCompareFixture(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare?from="+from.ID+"&to="+to.ID+"&format=html", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Fatalf("Content-Type = %q, want text/html", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "Is data encrypted at rest?") {
+		t.Fatalf("body missing question text: %s", rec.Body.String())
+	}
+}
+
+func TestCompareSubmissionsMarkdown(t *testing.T) {
+	_, router, _, from, to := setupCompareFixture(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare?from="+from.ID+"&to="+to.ID+"&format=markdown", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/markdown") {
+		t.Fatalf("Content-Type = %q, want text/markdown", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "| Is data encrypted at rest? | no | yes |") {
+		t.Fatalf("body missing changed-answer row: %s", rec.Body.String())
+	}
+}
+
+func TestCompareSubmissionsRejectsDifferentServices(t *testing.T) {
+	_, router, _, from, _ := setupCompareFixture(t)
+
+	otherBody, _ := json.Marshal(map[string]string{"name": "billing"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(otherBody)))
+	var other prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &other)
+
+	createBody, _ := json.Marshal(createSubmissionRequest{ServiceID: other.ID})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(createBody)))
+	var otherSub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &otherSub)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare?from="+from.ID+"&to="+otherSub.ID, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestCompareServicesDiffsLatestSubmissions(t *testing.T) {
+	a, router, svc, _, to := setupCompareFixture(t)
+
+	// setupCompareFixture runs on a FixedClock, so from and to share a
+	// CreatedAt and aren't orderable by timestamp alone. Give svc an
+	// unambiguous latest submission directly through the store so this
+	// test isn't at the mercy of a CreatedAt tie-break.
+	latest := to
+	latest.ID = "sub-latest"
+	latest.CreatedAt = latest.CreatedAt.Add(time.Hour)
+	latest.UpdatedAt = latest.CreatedAt
+	if err := a.Store.CreateSubmission(context.Background(), latest); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	otherBody, _ := json.Marshal(map[string]string{"name": "billing"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(otherBody)))
+	var other prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &other)
+
+	createBody, _ := json.Marshal(createSubmissionRequest{ServiceID: other.ID, Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(createBody)))
+	var otherSub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &otherSub)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare/services?service_id1="+svc.ID+"&service_id2="+other.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp serviceComparison
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.FromService.ID != svc.ID || resp.ToService.ID != other.ID {
+		t.Fatalf("unexpected services: %+v", resp)
+	}
+	if resp.Comparison.FromSubmissionID != latest.ID || resp.Comparison.ToSubmissionID != otherSub.ID {
+		t.Fatalf("unexpected submissions compared: %+v", resp.Comparison)
+	}
+}
+
+func TestCompareSubmissionsRejectsCrossTenantCaller(t *testing.T) {
+	_, router, _, from, to := setupCompareFixture(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/compare?from="+from.ID+"&to="+to.ID, nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCompareSubmissionsCrossTenantDoesNotLeakRegressions(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", IsEssential: true, Text: "Is data encrypted at rest?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	from := prr.Submission{ID: "sub-1", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-01-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}
+	to := prr.Submission{ID: "sub-2", ServiceID: svc.ID, CreatedAt: mustParseTime(t, "2024-02-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}}
+	if err := st.CreateSubmission(ctx, from); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, to); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	sameTenant := httptest.NewRequest(http.MethodGet, "/prr/compare?from="+from.ID+"&to="+to.ID, nil)
+	sameTenant.Header.Set(tenantHeader, "tenant-a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, sameTenant)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("same-tenant status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var cmp prr.Comparison
+	if err := json.Unmarshal(rec.Body.Bytes(), &cmp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(cmp.Regressions) != 1 {
+		t.Fatalf("Regressions = %+v, want 1 (test fixture didn't exercise the leak path)", cmp.Regressions)
+	}
+
+	crossTenant := httptest.NewRequest(http.MethodGet, "/prr/compare?from="+from.ID+"&to="+to.ID, nil)
+	crossTenant.Header.Set(tenantHeader, "tenant-b")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, crossTenant)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("cross-tenant status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "encrypted at rest") {
+		t.Fatalf("cross-tenant response leaked regression detail: %s", rec.Body.String())
+	}
+}
+
+func TestCompareServicesRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc1 := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	svc2 := prr.Service{ID: "svc-2", Name: "billing", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc1); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.CreateService(ctx, svc2); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-1", ServiceID: svc1.ID}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-2", ServiceID: svc2.ID}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/compare/services?service_id1="+svc1.ID+"&service_id2="+svc2.ID, nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestCompareServicesRequiresBothIDs(t *testing.T) {
+	_, router, svc, _, _ := setupCompareFixture(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/compare/services?service_id1="+svc.ID, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
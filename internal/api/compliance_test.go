@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestListServicesAnnotatesComplianceStatus(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2026-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]interface{}{"name": "payments", "review_interval_days": 30})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("list status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var listed []prr.ServiceCompliance
+	if err := json.Unmarshal(rec.Body.Bytes(), &listed); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(listed) != 1 {
+		t.Fatalf("len(listed) = %d, want 1", len(listed))
+	}
+	if listed[0].ComplianceStatus != prr.ComplianceOverdue {
+		t.Fatalf("ComplianceStatus = %q, want %q (no submission ever made)", listed[0].ComplianceStatus, prr.ComplianceOverdue)
+	}
+}
+
+func TestListOverdueServicesReturnsOnlyOverdue(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2026-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]interface{}{"name": "current-service", "review_interval_days": 30})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var current prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &current)
+
+	subBody, _ := json.Marshal(createSubmissionRequest{ServiceID: current.ID, Status: prr.SubmissionFinal})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	createBody, _ = json.Marshal(map[string]interface{}{"name": "overdue-service", "review_interval_days": 30})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var overdue prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &overdue)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services/overdue", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("overdue status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var results []prr.ServiceCompliance
+	if err := json.Unmarshal(rec.Body.Bytes(), &results); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != overdue.ID {
+		t.Fatalf("results = %+v, want only %s", results, overdue.ID)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/Eambo/vxcvxvc/internal/confluence"
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/report"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+type publishConfluenceRequest struct {
+	ServiceID    string `json:"service_id"`
+	SubmissionID string `json:"submission_id"`
+	PageID       string `json:"page_id"`
+	Title        string `json:"title"`
+	NextVersion  int    `json:"next_version"`
+}
+
+// handlePublishConfluence renders a submission and publishes it to a
+// Confluence page. The Confluence instance is configured via the
+// CONFLUENCE_BASE_URL and CONFLUENCE_TOKEN environment variables.
+func (a *API) handlePublishConfluence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req publishConfluenceRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ServiceID == "" || req.SubmissionID == "" || req.PageID == "" {
+		writeAPIError(w, "service_id, submission_id and page_id are required", http.StatusBadRequest)
+		return
+	}
+
+	baseURL := os.Getenv("CONFLUENCE_BASE_URL")
+	token := os.Getenv("CONFLUENCE_TOKEN")
+	if baseURL == "" || token == "" {
+		writeAPIError(w, "confluence publishing is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	svc, err := a.authorizedService(r.Context(), r, req.ServiceID)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	sub, err := a.authorizedSubmission(r.Context(), r, req.SubmissionID)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	htmlBody := report.RenderSubmissionHTML(svc, sub)
+
+	client := confluence.NewClient(baseURL, token)
+	if err := client.PublishPage(r.Context(), req.PageID, req.Title, htmlBody, req.NextVersion); err != nil {
+		writeAPIError(w, "failed to publish to confluence", http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "published"})
+}
+
+func writeStoreError(w http.ResponseWriter, err error, what string) {
+	if errors.Is(err, store.ErrNotFound) {
+		writeAPIError(w, what+" not found", http.StatusNotFound)
+		return
+	}
+	writeAPIError(w, "failed to look up "+what, http.StatusInternalServerError)
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandlePublishConfluenceRequiresConfiguration(t *testing.T) {
+	t.Setenv("CONFLUENCE_BASE_URL", "")
+	t.Setenv("CONFLUENCE_TOKEN", "")
+
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "payments"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-1", ServiceID: "svc-1"}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	body, _ := json.Marshal(publishConfluenceRequest{ServiceID: "svc-1", SubmissionID: "sub-1", PageID: "123"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/publish/confluence", bytes.NewReader(body)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlePublishConfluenceRequiresFields(t *testing.T) {
+	t.Setenv("CONFLUENCE_BASE_URL", "https://example.atlassian.net/wiki")
+	t.Setenv("CONFLUENCE_TOKEN", "secret")
+
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	body, _ := json.Marshal(publishConfluenceRequest{ServiceID: "svc-1"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/publish/confluence", bytes.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlePublishConfluenceRejectsCrossTenantCaller(t *testing.T) {
+	t.Setenv("CONFLUENCE_BASE_URL", "https://example.atlassian.net/wiki")
+	t.Setenv("CONFLUENCE_TOKEN", "secret")
+
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-1", ServiceID: "svc-1"}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	body, _ := json.Marshal(publishConfluenceRequest{ServiceID: "svc-1", SubmissionID: "sub-1", PageID: "123"})
+	req := httptest.NewRequest(http.MethodPost, "/admin/publish/confluence", bytes.NewReader(body))
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlePublishConfluenceServiceNotFound(t *testing.T) {
+	t.Setenv("CONFLUENCE_BASE_URL", "https://example.atlassian.net/wiki")
+	t.Setenv("CONFLUENCE_TOKEN", "secret")
+
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	body, _ := json.Marshal(publishConfluenceRequest{ServiceID: "missing", SubmissionID: "sub-1", PageID: "123"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/publish/confluence", bytes.NewReader(body)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// defaultCORSMethods and defaultCORSHeaders are used when
+// CORSAllowedMethods/CORSAllowedHeaders are left unset but
+// CORSAllowedOrigins is configured.
+var (
+	defaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization"}
+)
+
+// corsOriginAllowed reports whether origin may receive CORS headers,
+// per CORSAllowedOrigins. "*" allows any origin.
+func (a *API) corsOriginAllowed(origin string) bool {
+	for _, allowed := range a.CORSAllowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// withCORS sets CORS response headers for allowed origins and answers
+// every OPTIONS request as a preflight check, across all routes,
+// rather than letting it fall through to a handler that would reject
+// OPTIONS with 405. A request from a non-allowed (or missing) Origin
+// gets no CORS headers, which makes the browser enforce same-origin as
+// usual. An empty CORSAllowedOrigins (the default) disables CORS
+// entirely, since a same-origin deployment doesn't need it.
+func (a *API) withCORS(next http.Handler) http.Handler {
+	if len(a.CORSAllowedOrigins) == 0 {
+		return next
+	}
+	methods := a.CORSAllowedMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	headers := a.CORSAllowedHeaders
+	if len(headers) == 0 {
+		headers = defaultCORSHeaders
+	}
+	allowedMethods := strings.Join(methods, ", ")
+	allowedHeaders := strings.Join(headers, ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && a.corsOriginAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+		}
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
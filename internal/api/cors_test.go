@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestCORSDisabledByDefault(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	req := httptest.NewRequest(http.MethodGet, "/services", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Fatal("expected no CORS headers when CORSAllowedOrigins is unset")
+	}
+}
+
+func TestCORSAllowsConfiguredOrigin(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.CORSAllowedOrigins = []string{"https://example.com"}
+	router := a.Router()
+
+	req := httptest.NewRequest(http.MethodGet, "/services", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want https://example.com", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/services", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want empty for a non-allowed origin", got)
+	}
+}
+
+func TestCORSPreflightOptions(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.CORSAllowedOrigins = []string{"*"}
+	router := a.Router()
+
+	req := httptest.NewRequest(http.MethodOptions, "/admin/questions/import", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("preflight status = %d, want 204", rec.Code)
+	}
+	if rec.Header().Get("Access-Control-Allow-Methods") == "" {
+		t.Fatal("expected Access-Control-Allow-Methods on a preflight response")
+	}
+}
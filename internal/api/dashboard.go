@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// sectionGrade is a section's org-wide average score, used to surface
+// the weakest areas across every service's latest submission.
+type sectionGrade struct {
+	SectionID      string  `json:"section_id"`
+	AveragePercent float64 `json:"average_percent"`
+	Submissions    int     `json:"submissions"`
+}
+
+// dashboardSummary aggregates org-wide readiness across every service's
+// latest submission, so a leadership dashboard can render it from a
+// single request instead of issuing one query per service.
+type dashboardSummary struct {
+	ServicesWithPRR    int               `json:"services_with_prr"`
+	ServicesWithoutPRR int               `json:"services_without_prr"`
+	GradeDistribution  map[prr.Grade]int `json:"grade_distribution"`
+	WorstSections      []sectionGrade    `json:"worst_sections"`
+	ExpiredServices    []staleService    `json:"expired_services"`
+}
+
+// handleDashboardSummary returns the org-wide readiness summary backing
+// a leadership dashboard.
+func (a *API) handleDashboardSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	summary, err := a.dashboardSummary(r.Context(), resolveTenant(r))
+	if err != nil {
+		writeAPIError(w, "failed to compute dashboard summary", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// dashboardSummary computes the tenant's readiness summary from each of
+// its services' latest submission, looked up from the materialized
+// service_readiness summary (see refreshServiceReadiness) instead of
+// re-listing and sorting every submission per service.
+func (a *API) dashboardSummary(ctx context.Context, tenant string) (dashboardSummary, error) {
+	services, err := a.visibleServices(ctx, tenant)
+	if err != nil {
+		return dashboardSummary{}, err
+	}
+	thresholds, err := a.Store.GetScoringThresholds(ctx)
+	if err != nil {
+		return dashboardSummary{}, err
+	}
+
+	summary := dashboardSummary{
+		GradeDistribution: make(map[prr.Grade]int),
+	}
+	sectionPoints := make(map[string]int)
+	sectionMax := make(map[string]int)
+	sectionSubs := make(map[string]int)
+	var sectionOrder []string
+
+	for _, svc := range services {
+		if svc.Archived {
+			continue
+		}
+		readiness, err := a.getServiceReadiness(ctx, svc.ID)
+		if err == store.ErrNotFound {
+			summary.ServicesWithoutPRR++
+			continue
+		}
+		if err != nil {
+			return dashboardSummary{}, err
+		}
+		summary.ServicesWithPRR++
+
+		latest, err := a.Store.GetSubmission(ctx, readiness.SubmissionID)
+		if err != nil {
+			return dashboardSummary{}, err
+		}
+
+		scoped, questions, err := a.scopeSubmissionToTemplate(ctx, latest)
+		if err != nil {
+			return dashboardSummary{}, err
+		}
+		score := prr.ComputeScore(scoped, questions)
+		grade := prr.ComputeGrade(score.Percent, thresholds)
+		summary.GradeDistribution[grade]++
+
+		for _, sec := range score.SectionScores {
+			if _, ok := sectionPoints[sec.SectionID]; !ok {
+				sectionOrder = append(sectionOrder, sec.SectionID)
+			}
+			sectionPoints[sec.SectionID] += sec.Points
+			sectionMax[sec.SectionID] += sec.MaxPoints
+			sectionSubs[sec.SectionID]++
+		}
+	}
+
+	for _, id := range sectionOrder {
+		avg := 0.0
+		if sectionMax[id] > 0 {
+			avg = 100 * float64(sectionPoints[id]) / float64(sectionMax[id])
+		}
+		summary.WorstSections = append(summary.WorstSections, sectionGrade{
+			SectionID:      id,
+			AveragePercent: avg,
+			Submissions:    sectionSubs[id],
+		})
+	}
+	sort.Slice(summary.WorstSections, func(i, j int) bool {
+		return summary.WorstSections[i].AveragePercent < summary.WorstSections[j].AveragePercent
+	})
+
+	expired, err := a.staleServices(ctx, a.Clock.Now(), tenant)
+	if err != nil {
+		return dashboardSummary{}, err
+	}
+	summary.ExpiredServices = expired
+
+	return summary, nil
+}
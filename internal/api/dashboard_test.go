@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleDashboardSummary(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	a := New(st, prr.SystemClock{}, idGen, nil)
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	withPRR := prr.Service{ID: idGen.NewID(), Name: "with-prr"}
+	withoutPRR := prr.Service{ID: idGen.NewID(), Name: "without-prr"}
+	archived := prr.Service{ID: idGen.NewID(), Name: "archived", Archived: true}
+	for _, svc := range []prr.Service{withPRR, withoutPRR, archived} {
+		if err := st.CreateService(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sub := prr.Submission{
+		ID:        idGen.NewID(),
+		ServiceID: withPRR.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/summary", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var summary dashboardSummary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.ServicesWithPRR != 1 {
+		t.Errorf("ServicesWithPRR = %d, want 1", summary.ServicesWithPRR)
+	}
+	if summary.ServicesWithoutPRR != 1 {
+		t.Errorf("ServicesWithoutPRR = %d, want 1 (archived service excluded)", summary.ServicesWithoutPRR)
+	}
+	if summary.GradeDistribution[prr.GradeGreen] != 1 {
+		t.Errorf("GradeDistribution[green] = %d, want 1", summary.GradeDistribution[prr.GradeGreen])
+	}
+	if len(summary.WorstSections) != 1 || summary.WorstSections[0].SectionID != "sec1" {
+		t.Fatalf("WorstSections = %+v, want sec1", summary.WorstSections)
+	}
+	if summary.WorstSections[0].AveragePercent != 100 {
+		t.Errorf("AveragePercent = %v, want 100", summary.WorstSections[0].AveragePercent)
+	}
+}
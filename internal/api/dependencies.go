@@ -0,0 +1,103 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// dependencyConflict is one reason a section delete or question move
+// was refused, so the caller sees every blocker at once instead of
+// discovering them one at a time across repeated requests.
+type dependencyConflict struct {
+	Type   string `json:"type"`
+	Detail string `json:"detail"`
+}
+
+// writeDependencyConflicts writes conflicts as a 409 Conflict.
+func writeDependencyConflicts(w http.ResponseWriter, conflicts []dependencyConflict) {
+	writeJSON(w, http.StatusConflict, map[string]interface{}{"conflicts": conflicts})
+}
+
+// questionDependencyConflicts reports every reason removing questionIDs
+// from the question bank (by deleting their section, or moving one of
+// them elsewhere) would corrupt something else that depends on them:
+// another question's conditional display names one of them as its
+// ParentQuestionID, a template references one of them (or, when
+// sectionID is set, the section itself), or a draft submission has
+// already answered one of them. sectionID is empty when checking a
+// single moved question rather than a whole section.
+func (a *API) questionDependencyConflicts(ctx context.Context, questionIDs map[string]bool, sectionID string) ([]dependencyConflict, error) {
+	var conflicts []dependencyConflict
+
+	questions, err := a.Store.ListQuestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range questions {
+		if q.ParentQuestionID != "" && questionIDs[q.ParentQuestionID] && !questionIDs[q.ID] {
+			conflicts = append(conflicts, dependencyConflict{
+				Type:   "conditional_question_parent",
+				Detail: fmt.Sprintf("question %s is a conditional follow-up to question %s", q.ID, q.ParentQuestionID),
+			})
+		}
+	}
+
+	templates, err := a.Store.ListTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, tmpl := range templates {
+		if sectionID != "" {
+			for _, id := range tmpl.SectionIDs {
+				if id != sectionID {
+					continue
+				}
+				conflicts = append(conflicts, dependencyConflict{
+					Type:   "template_reference",
+					Detail: fmt.Sprintf("template %s (%s) references this section", tmpl.ID, tmpl.Name),
+				})
+				break
+			}
+		}
+		for _, id := range tmpl.QuestionIDs {
+			if !questionIDs[id] {
+				continue
+			}
+			conflicts = append(conflicts, dependencyConflict{
+				Type:   "template_reference",
+				Detail: fmt.Sprintf("template %s (%s) references question %s", tmpl.ID, tmpl.Name, id),
+			})
+		}
+	}
+
+	services, err := a.Store.ListServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, svc := range services {
+		subs, err := a.Store.ListSubmissionsByService(ctx, svc.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range subs {
+			if sub.Status != prr.SubmissionDraft {
+				continue
+			}
+			for _, ans := range sub.Answers {
+				if !questionIDs[ans.QuestionID] {
+					continue
+				}
+				conflicts = append(conflicts, dependencyConflict{
+					Type:   "open_draft_submission",
+					Detail: fmt.Sprintf("draft submission %s for service %s has answered question %s", sub.ID, svc.ID, ans.QuestionID),
+				})
+				break
+			}
+		}
+	}
+
+	return conflicts, nil
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestDeleteSectionRefusesWhenTemplateReferencesIt(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec1", Name: "Security"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertTemplate(ctx, prr.Template{ID: "tmpl1", Name: "Minimal", SectionIDs: []string{"sec1"}, QuestionIDs: []string{"q1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/sections?id=sec1&force=true", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp map[string][]dependencyConflict
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp["conflicts"]) == 0 || resp["conflicts"][0].Type != "template_reference" {
+		t.Fatalf("conflicts = %+v, want a template_reference conflict", resp["conflicts"])
+	}
+
+	if _, err := st.GetSection(ctx, "sec1"); err != nil {
+		t.Fatalf("section was deleted despite conflict: %v", err)
+	}
+}
+
+func TestDeleteSectionRefusesWhenQuestionIsConditionalParent(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec1", Name: "Security"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q2", SectionID: "sec2", ParentQuestionID: "q1", ShowWhenAnswer: "yes"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/sections?id=sec1&force=true", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp map[string][]dependencyConflict
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp["conflicts"]) == 0 || resp["conflicts"][0].Type != "conditional_question_parent" {
+		t.Fatalf("conflicts = %+v, want a conditional_question_parent conflict", resp["conflicts"])
+	}
+
+	if _, err := st.GetQuestion(ctx, "q1"); err != nil {
+		t.Fatalf("question was deleted despite conflict: %v", err)
+	}
+}
+
+func TestDeleteSectionRefusesWithOpenDraftSubmission(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec1", Name: "Security"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc1", Name: "svc"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{
+		ID:        "sub1",
+		ServiceID: "svc1",
+		Status:    prr.SubmissionDraft,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/sections?id=sec1&force=true", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp map[string][]dependencyConflict
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp["conflicts"]) == 0 || resp["conflicts"][0].Type != "open_draft_submission" {
+		t.Fatalf("conflicts = %+v, want an open_draft_submission conflict", resp["conflicts"])
+	}
+}
+
+func TestMoveQuestionRefusesWhenTemplateReferencesIt(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec1", Name: "Security"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec2", Name: "Reliability"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertTemplate(ctx, prr.Template{ID: "tmpl1", Name: "Minimal", QuestionIDs: []string{"q1"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(moveQuestionRequest{SectionID: "sec2"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/questions/q1/move", bytes.NewReader(body)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409, body=%s", rec.Code, rec.Body.String())
+	}
+
+	q, err := st.GetQuestion(ctx, "q1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.SectionID != "sec1" {
+		t.Fatalf("SectionID = %q, want unchanged sec1", q.SectionID)
+	}
+}
+
+func TestMoveQuestionSucceedsWithoutConflicts(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec1", Name: "Security"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec2", Name: "Reliability"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(moveQuestionRequest{SectionID: "sec2"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/questions/q1/move", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	q, err := st.GetQuestion(ctx, "q1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.SectionID != "sec2" {
+		t.Fatalf("SectionID = %q, want sec2", q.SectionID)
+	}
+}
+
+func TestMoveQuestionUnknownSection(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(moveQuestionRequest{SectionID: "nope"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/questions/q1/move", bytes.NewReader(body)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// handleDevSeed populates the store with randomized but realistic
+// services and submissions, so frontend developers can work against a
+// populated dashboard immediately. It is only mounted when DevMode is
+// enabled and must never be reachable in production.
+func (a *API) handleDevSeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	services := queryInt(r, "services", 10)
+	submissions := queryInt(r, "submissions", 3)
+
+	createdServices := 0
+	createdSubmissions := 0
+	for i := 0; i < services; i++ {
+		svc := prr.Service{
+			ID:        a.IDGen.NewID(),
+			Name:      fmt.Sprintf("seed-service-%d", i),
+			CreatedAt: a.Clock.Now(),
+		}
+		if err := a.Store.CreateService(r.Context(), svc); err != nil {
+			writeAPIError(w, "failed to seed service", http.StatusInternalServerError)
+			return
+		}
+		createdServices++
+
+		for j := 0; j < submissions; j++ {
+			sub := prr.Submission{
+				ID:        a.IDGen.NewID(),
+				ServiceID: svc.ID,
+				Answers: []prr.Answer{
+					{QuestionID: "q-oncall", Value: []string{"yes", "no"}[rand.Intn(2)]},
+					{QuestionID: "q-runbook", Value: []string{"yes", "no"}[rand.Intn(2)]},
+				},
+				CreatedAt: a.Clock.Now(),
+			}
+			if err := a.Store.CreateSubmission(r.Context(), sub); err != nil {
+				writeAPIError(w, "failed to seed submission", http.StatusInternalServerError)
+				return
+			}
+			createdSubmissions++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{
+		"services":    createdServices,
+		"submissions": createdSubmissions,
+	})
+}
+
+func queryInt(r *http.Request, name string, def int) int {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return def
+	}
+	return n
+}
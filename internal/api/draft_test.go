@@ -0,0 +1,74 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestDraftSaveAndResume(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1"}); err != nil {
+		t.Fatalf("seed question: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q2"}); err != nil {
+		t.Fatalf("seed question: %v", err)
+	}
+
+	createBody, _ := json.Marshal(map[string]string{"name": "svc"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	draftBody, _ := json.Marshal(map[string]interface{}{
+		"service_id": svc.ID,
+		"status":     "draft",
+		"answers":    []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(draftBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create draft status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+	if sub.Status != prr.SubmissionDraft {
+		t.Fatalf("status = %q, want draft", sub.Status)
+	}
+
+	updateBody, _ := json.Marshal(map[string]interface{}{
+		"answers": []prr.Answer{{QuestionID: "q1", Value: "yes"}, {QuestionID: "q2", Value: "no"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/submissions/"+sub.ID, bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update draft status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/finalize", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("finalize status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var final prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &final)
+	if final.Status != prr.SubmissionFinal || len(final.Answers) != 2 {
+		t.Fatalf("final = %+v", final)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/finalize", nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("re-finalize status = %d, want 409", rec.Code)
+	}
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"text/template"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// EmailTemplate is a pair of text/template strings rendered against an
+// emailTemplateData value to produce one lifecycle email's subject and
+// body.
+type EmailTemplate struct {
+	Subject string
+	Body    string
+}
+
+// EmailTemplates customizes the subject and body of each lifecycle
+// email the API sends. Any field left at its zero value falls back to
+// the matching defaultEmailTemplates entry.
+type EmailTemplates struct {
+	// SubmissionReceived is emailed to the service owner when a new PRR
+	// submission is created.
+	SubmissionReceived EmailTemplate
+	// Approved is emailed to the submitter when a reviewer approves
+	// their submission.
+	Approved EmailTemplate
+	// Rejected is emailed to the submitter when a reviewer rejects
+	// their submission.
+	Rejected EmailTemplate
+	// ExpiryApproaching is emailed to the service owner when the
+	// service's PRR compliance status becomes due soon.
+	ExpiryApproaching EmailTemplate
+}
+
+// defaultEmailTemplates are used for any EmailTemplates field left at
+// its zero value.
+var defaultEmailTemplates = EmailTemplates{
+	SubmissionReceived: EmailTemplate{
+		Subject: "PRR submitted for {{.Service.Name}}",
+		Body:    "A PRR submission ({{.Submission.ID}}) was received for {{.Service.Name}}.\n\nGrade: {{.Submission.Grade}}\n",
+	},
+	Approved: EmailTemplate{
+		Subject: "PRR approved for {{.Service.Name}}",
+		Body:    "Your PRR submission ({{.Submission.ID}}) for {{.Service.Name}} was approved.\n",
+	},
+	Rejected: EmailTemplate{
+		Subject: "PRR rejected for {{.Service.Name}}",
+		Body:    "Your PRR submission ({{.Submission.ID}}) for {{.Service.Name}} was rejected.\n",
+	},
+	ExpiryApproaching: EmailTemplate{
+		Subject: "PRR for {{.Service.Name}} is due soon",
+		Body:    "{{.Service.Name}}'s PRR review interval is ending soon. Please schedule a re-run.\n",
+	},
+}
+
+// emailTemplateData is the value each EmailTemplate is executed
+// against.
+type emailTemplateData struct {
+	Service    prr.Service
+	Submission prr.Submission
+}
+
+// resolveEmailTemplate fills any zero-value field of configured from
+// fallback.
+func resolveEmailTemplate(configured, fallback EmailTemplate) EmailTemplate {
+	if configured.Subject == "" {
+		configured.Subject = fallback.Subject
+	}
+	if configured.Body == "" {
+		configured.Body = fallback.Body
+	}
+	return configured
+}
+
+// renderEmail executes tmpl's subject and body templates against data.
+func renderEmail(tmpl EmailTemplate, data emailTemplateData) (subject, body string, err error) {
+	subjectTmpl, err := template.New("subject").Parse(tmpl.Subject)
+	if err != nil {
+		return "", "", err
+	}
+	bodyTmpl, err := template.New("body").Parse(tmpl.Body)
+	if err != nil {
+		return "", "", err
+	}
+	var subjectBuf, bodyBuf bytes.Buffer
+	if err := subjectTmpl.Execute(&subjectBuf, data); err != nil {
+		return "", "", err
+	}
+	if err := bodyTmpl.Execute(&bodyBuf, data); err != nil {
+		return "", "", err
+	}
+	return subjectBuf.String(), bodyBuf.String(), nil
+}
+
+// sendLifecycleEmail best-effort emails to using configured (falling
+// back to fallback for any unset field) rendered against data. Delivery
+// happens in its own goroutine, detached from the request context, same
+// as dispatchWebhookEvent: callers fire this from request handlers that
+// must not block the response on a slow or unreachable mail server. It
+// is a no-op when email is not configured or to is empty.
+func (a *API) sendLifecycleEmail(to string, configured, fallback EmailTemplate, data emailTemplateData) {
+	if a.Email == nil || to == "" {
+		return
+	}
+	subject, body, err := renderEmail(resolveEmailTemplate(configured, fallback), data)
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("failed to render email template: %v", err)
+		}
+		return
+	}
+	go func() {
+		if err := a.Email.Send(context.Background(), to, subject, body); err != nil && a.Logger != nil {
+			a.Logger.Printf("failed to send email to %s: %v", to, err)
+		}
+	}()
+}
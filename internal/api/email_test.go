@@ -0,0 +1,146 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/notify"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+// fakeEmailSends records every message an EmailClient tries to send,
+// always succeeding, so tests can assert on recipients/subjects without
+// a real SMTP server.
+type fakeEmailSends struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (f *fakeEmailSends) sendFunc() func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+	return func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.msgs = append(f.msgs, to[0]+"|"+string(msg))
+		return nil
+	}
+}
+
+func (f *fakeEmailSends) waitForCount(t *testing.T, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		f.mu.Lock()
+		got := len(f.msgs)
+		f.mu.Unlock()
+		if got >= n {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.msgs...)
+}
+
+func TestCreateSubmissionEmailsServiceOwner(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	fake := &fakeEmailSends{}
+	client := notify.NewEmailClient("smtp.example.com", "587", "", "", "prr@example.com")
+	client.SendFunc = fake.sendFunc()
+	a.Email = client
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]interface{}{"name": "payments", "owner_email": "owner@example.com"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionFinal})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	msgs := fake.waitForCount(t, 1)
+	if len(msgs) != 1 {
+		t.Fatalf("got %d emails, want 1", len(msgs))
+	}
+	if !bytes.Contains([]byte(msgs[0]), []byte("owner@example.com")) {
+		t.Fatalf("email not sent to owner: %s", msgs[0])
+	}
+}
+
+func TestRecordApprovalEmailsSubmitter(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	fake := &fakeEmailSends{}
+	client := notify.NewEmailClient("smtp.example.com", "587", "", "", "prr@example.com")
+	client.SendFunc = fake.sendFunc()
+	a.Email = client
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID, Status: prr.SubmissionFinal, SubmitterEmail: "submitter@example.com"}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/reject", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reject status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	msgs := fake.waitForCount(t, 1)
+	if len(msgs) != 1 {
+		t.Fatalf("got %d emails, want 1", len(msgs))
+	}
+	if !bytes.Contains([]byte(msgs[0]), []byte("submitter@example.com")) {
+		t.Fatalf("email not sent to submitter: %s", msgs[0])
+	}
+	if !bytes.Contains([]byte(msgs[0]), []byte("rejected")) {
+		t.Fatalf("expected rejection email, got: %s", msgs[0])
+	}
+}
+
+func TestSendLifecycleEmailNoopWithoutEmailClient(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	// a.Email is nil; this must not panic and must not attempt delivery.
+	a.sendLifecycleEmail("someone@example.com", EmailTemplate{}, defaultEmailTemplates.Approved, emailTemplateData{})
+}
+
+func TestSendLifecycleEmailHonorsCustomTemplate(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	fake := &fakeEmailSends{}
+	client := notify.NewEmailClient("smtp.example.com", "587", "", "", "prr@example.com")
+	client.SendFunc = fake.sendFunc()
+	a.Email = client
+
+	a.sendLifecycleEmail("to@example.com", EmailTemplate{Subject: "custom subject", Body: "custom body"}, defaultEmailTemplates.Approved, emailTemplateData{})
+
+	msgs := fake.waitForCount(t, 1)
+	if len(msgs) != 1 {
+		t.Fatalf("got %d emails, want 1", len(msgs))
+	}
+	if !bytes.Contains([]byte(msgs[0]), []byte("custom subject")) {
+		t.Fatalf("expected custom subject to be used, got: %s", msgs[0])
+	}
+}
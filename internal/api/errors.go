@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// apiError is the standard JSON error envelope every handler in this
+// package returns on failure, instead of the plain-text body
+// http.Error would write, so a frontend can branch on Code and
+// correlate a failure with server logs via RequestID rather than
+// parsing Message.
+type apiError struct {
+	Code      string      `json:"code"`
+	Message   string      `json:"message"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// errorCodeForStatus maps an HTTP status to a stable, machine-readable
+// error code, so clients can branch on Code without parsing Message.
+func errorCodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusMethodNotAllowed:
+		return "method_not_allowed"
+	case http.StatusServiceUnavailable:
+		return "unavailable"
+	case http.StatusBadGateway:
+		return "bad_gateway"
+	case http.StatusNotImplemented:
+		return "not_implemented"
+	default:
+		return "internal"
+	}
+}
+
+// writeAPIError writes status with a standardized JSON error envelope.
+// It's a drop-in replacement for http.Error(w, message, status) used
+// throughout this package, so every handler's failures share the same
+// shape.
+func writeAPIError(w http.ResponseWriter, message string, status int) {
+	requestID := w.Header().Get("X-Request-ID")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(apiError{
+		Code:      errorCodeForStatus(status),
+		Message:   message,
+		RequestID: requestID,
+	})
+}
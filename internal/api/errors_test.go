@@ -0,0 +1,39 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestErrorsUseStandardEnvelope(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services/missing-service-id/submissions", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var got apiError
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("response body is not a JSON error envelope: %v (body=%s)", err, rec.Body.String())
+	}
+	if got.Code != "not_found" {
+		t.Fatalf("Code = %q, want not_found", got.Code)
+	}
+	if got.Message == "" {
+		t.Fatal("Message is empty")
+	}
+	if got.RequestID == "" {
+		t.Fatal("RequestID is empty, want the X-Request-ID set by withRequestLogging")
+	}
+	if rec.Header().Get("X-Request-ID") != got.RequestID {
+		t.Fatalf("envelope RequestID %q does not match X-Request-ID header %q", got.RequestID, rec.Header().Get("X-Request-ID"))
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// writeJSONWithETag marshals v to JSON and serves it with an ETag
+// computed from its content, returning 304 Not Modified instead of the
+// body when the client's If-None-Match header already matches. It's
+// used for listings like questions and sections that are fetched on
+// every form load but rarely change.
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeAPIError(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	w.Header().Set("ETag", etag)
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestQuestionsAndSectionsServeETagAnd304(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-1", Name: "General"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Ready?"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"/admin/questions", "/admin/sections", "/prr/form"} {
+		rec := httptest.NewRecorder()
+		a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, body=%s", path, rec.Code, rec.Body.String())
+		}
+		etag := rec.Header().Get("ETag")
+		if etag == "" {
+			t.Fatalf("%s: missing ETag header", path)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("If-None-Match", etag)
+		rec = httptest.NewRecorder()
+		a.Router().ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotModified {
+			t.Fatalf("%s: status = %d, want 304", path, rec.Code)
+		}
+		if rec.Body.Len() != 0 {
+			t.Fatalf("%s: 304 response had a body: %s", path, rec.Body.String())
+		}
+	}
+}
+
+func TestQuestionsETagChangesWhenContentChanges(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/questions", nil))
+	before := rec.Header().Get("ETag")
+
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Ready?"}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec = httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/questions", nil))
+	after := rec.Header().Get("ETag")
+
+	if before == after {
+		t.Fatalf("ETag did not change after content changed: %q", before)
+	}
+}
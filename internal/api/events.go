@@ -0,0 +1,116 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+)
+
+// eventHubBufferSize bounds how many undelivered events a single GET
+// /events subscriber can fall behind by before publish starts dropping
+// events for it, so one slow or stalled dashboard tab can't block
+// delivery to every other subscriber or to the request that triggered
+// the event.
+const eventHubBufferSize = 16
+
+// eventHub fans out SSE events to every currently-connected subscriber
+// of GET /events that's scoped to the same tenant as the event. An API
+// builds one in New and reuses it, the same way it reuses a single
+// webhook.Deliverer.
+type eventHub struct {
+	mu          sync.Mutex
+	subscribers map[chan []byte]string
+}
+
+// newEventHub returns an eventHub with no subscribers.
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[chan []byte]string)}
+}
+
+// subscribe registers a new subscriber scoped to tenant (the empty
+// string on a deployment that isn't multi-tenant, matching
+// resolveTenant), returning the channel it receives published event
+// payloads on and a function the caller must invoke (typically via
+// defer) to unregister it once it stops reading.
+func (h *eventHub) subscribe(tenant string) (chan []byte, func()) {
+	ch := make(chan []byte, eventHubBufferSize)
+	h.mu.Lock()
+	h.subscribers[ch] = tenant
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subscribers, ch)
+		h.mu.Unlock()
+		close(ch)
+	}
+}
+
+// publish fans payload out to every current subscriber scoped to
+// tenant, the same isolation handleListServices applies to reads. A
+// subscriber whose buffer is already full has this event silently
+// dropped rather than blocking every other subscriber, or the caller,
+// on it.
+func (h *eventHub) publish(tenant string, payload []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch, subTenant := range h.subscribers {
+		if subTenant != tenant {
+			continue
+		}
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
+
+// handleEvents streams submission, approval, and readiness lifecycle
+// events to the caller as Server-Sent Events, so a dashboard can update
+// live instead of polling /prr/history or /services. Every event
+// published here is also dispatched to registered webhooks (see
+// dispatchWebhookEvent), so the two delivery mechanisms never drift
+// apart. The stream stays open until the client disconnects.
+func (a *API) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	restricted := false
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		restricted = principal.Role == auth.RoleRestricted
+	}
+
+	ch, unsubscribe := a.Events.subscribe(resolveTenant(r))
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case payload, ok := <-ch:
+			if !ok {
+				return
+			}
+			if restricted {
+				payload = redactJSON(payload)
+			}
+			fmt.Fprintf(w, "data: %s\n\n", payload)
+			flusher.Flush()
+		}
+	}
+}
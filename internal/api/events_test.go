@@ -0,0 +1,170 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleEventsStreamsSubmissionCreated(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	streamRec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(streamRec, req)
+		close(done)
+	}()
+
+	// Give the subscriber a moment to register before publishing, so
+	// the event isn't dropped for lack of a listener.
+	time.Sleep(20 * time.Millisecond)
+
+	body, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "q-auth", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handleEvents did not return after context cancellation")
+	}
+
+	if streamRec.Code != http.StatusOK {
+		t.Fatalf("status = %d", streamRec.Code)
+	}
+	if ct := streamRec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", ct)
+	}
+	if !strings.Contains(streamRec.Body.String(), `"event":"submission.created"`) {
+		t.Fatalf("stream body = %q, want it to contain a submission.created event", streamRec.Body.String())
+	}
+}
+
+func TestHandleEventsDoesNotLeakAcrossTenants(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	svcReq := httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody))
+	svcReq.Header.Set(tenantHeader, "tenant-a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, svcReq)
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	streamRec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx)
+	req.Header.Set(tenantHeader, "tenant-b")
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(streamRec, req)
+		close(done)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	body, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "q-auth", Value: "yes"}},
+	})
+	subReq := httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body))
+	subReq.Header.Set(tenantHeader, "tenant-a")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, subReq)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handleEvents did not return after context cancellation")
+	}
+
+	if strings.Contains(streamRec.Body.String(), `"event":"submission.created"`) {
+		t.Fatalf("tenant-b subscriber received tenant-a's event: %q", streamRec.Body.String())
+	}
+}
+
+// TestHandleEventsConcurrentWithSubmissionCreatesIsRaceFree pins a
+// regression where a live GET /events stream (running in its own
+// goroutine, same as production) and a concurrent POST /submissions
+// both mint IDs via a.IDGen.NewID at the same time. Run with -race.
+func TestHandleEventsConcurrentWithSubmissionCreatesIsRaceFree(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/events", nil).WithContext(ctx))
+		close(done)
+	}()
+
+	body, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "q-auth", Value: "yes"}},
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+		}()
+	}
+	wg.Wait()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("handleEvents did not return after context cancellation")
+	}
+}
@@ -0,0 +1,275 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// handleExpiryConfig gets or sets the policy controlling how often a
+// service must re-run its PRR before it's considered stale.
+func (a *API) handleExpiryConfig(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		policy, err := a.Store.GetExpiryPolicy(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to load expiry config", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, policy)
+	case http.MethodPut:
+		var policy prr.ExpiryPolicy
+		if err := jsonvalidate.Decode(r.Body, &policy); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if policy.IntervalDays <= 0 {
+			writeAPIError(w, "interval_days must be positive", http.StatusBadRequest)
+			return
+		}
+		if err := a.Store.SetExpiryPolicy(r.Context(), policy); err != nil {
+			writeAPIError(w, "failed to save expiry config", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, policy)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// staleService is a service whose latest PRR submission is older than
+// the configured expiry policy, or one that has never submitted at all.
+type staleService struct {
+	Service           prr.Service `json:"service"`
+	LatestSubmittedAt *string     `json:"latest_submitted_at,omitempty"`
+}
+
+// handleListStaleServices returns every service whose latest submission
+// predates the configured expiry policy (or that has no submission at
+// all), so teams know which PRRs are due for a re-run.
+func (a *API) handleListStaleServices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	stale, err := a.staleServices(r.Context(), a.Clock.Now(), resolveTenant(r))
+	if err != nil {
+		writeAPIError(w, fmt.Sprintf("failed to compute stale services: %v", err), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, stale)
+}
+
+// staleServices computes, as of now, the services scoped to tenant
+// whose latest submission is older than the configured expiry policy,
+// or that have never submitted at all.
+func (a *API) staleServices(ctx context.Context, now time.Time, tenant string) ([]staleService, error) {
+	services, err := a.visibleServices(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	return a.staleServicesAmong(ctx, now, services)
+}
+
+// staleServicesAmong computes, as of now, which of services have a
+// latest submission older than the configured expiry policy, or have
+// never submitted at all. Split out from staleServices so the expiry
+// scheduler (see notifyStaleServices), which notifies about every
+// tenant's stale services rather than a single caller's, can reuse the
+// computation without going through tenant-scoped listing.
+func (a *API) staleServicesAmong(ctx context.Context, now time.Time, services []prr.Service) ([]staleService, error) {
+	policy, err := a.Store.GetExpiryPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []staleService
+	for _, svc := range services {
+		submissions, err := a.Store.ListSubmissionsByService(ctx, svc.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(submissions) == 0 {
+			out = append(out, staleService{Service: svc})
+			continue
+		}
+		sort.Slice(submissions, func(i, j int) bool {
+			return submissions[i].CreatedAt.After(submissions[j].CreatedAt)
+		})
+		latest := submissions[0]
+		if now.Sub(latest.CreatedAt).Hours() > float64(policy.IntervalDays)*24 {
+			ts := latest.CreatedAt.Format("2006-01-02T15:04:05Z07:00")
+			out = append(out, staleService{Service: svc, LatestSubmittedAt: &ts})
+		}
+	}
+	return out, nil
+}
+
+// handleListOverdueServices returns every service whose computed
+// ComplianceStatus is overdue, for the ops team to chase.
+func (a *API) handleListOverdueServices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	services, err := a.visibleServices(r.Context(), resolveTenant(r))
+	if err != nil {
+		writeAPIError(w, "failed to list services", http.StatusInternalServerError)
+		return
+	}
+	visible := make([]prr.Service, 0, len(services))
+	for _, svc := range services {
+		if svc.Archived {
+			continue
+		}
+		visible = append(visible, svc)
+	}
+	compliance, err := a.serviceComplianceForAll(r.Context(), visible, a.Clock.Now())
+	if err != nil {
+		writeAPIError(w, fmt.Sprintf("failed to compute compliance status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	overdue := make([]prr.ServiceCompliance, 0, len(compliance))
+	for _, c := range compliance {
+		if c.ComplianceStatus == prr.ComplianceOverdue {
+			overdue = append(overdue, c)
+		}
+	}
+	writeJSON(w, http.StatusOK, overdue)
+}
+
+// effectiveReviewIntervalDays returns how many days svc's latest
+// submission stays current: its own ReviewIntervalDays override if set,
+// otherwise the deployment-wide policy's default.
+func effectiveReviewIntervalDays(svc prr.Service, policy prr.ExpiryPolicy) int {
+	if svc.ReviewIntervalDays > 0 {
+		return svc.ReviewIntervalDays
+	}
+	return policy.IntervalDays
+}
+
+// serviceCompliance computes svc's ComplianceStatus as of now, using
+// its own ReviewIntervalDays override if set, otherwise policy's
+// default. It reads svc's latest submission timestamp from the
+// materialized service_readiness summary (see refreshServiceReadiness)
+// instead of re-listing and sorting every submission.
+func (a *API) serviceCompliance(ctx context.Context, svc prr.Service, policy prr.ExpiryPolicy, now time.Time) (prr.ServiceCompliance, error) {
+	interval := effectiveReviewIntervalDays(svc, policy)
+	readiness, err := a.getServiceReadiness(ctx, svc.ID)
+	if err == store.ErrNotFound {
+		return prr.ServiceCompliance{
+			Service:          svc,
+			ComplianceStatus: prr.ComputeComplianceStatus(interval, time.Time{}, false, now),
+		}, nil
+	}
+	if err != nil {
+		return prr.ServiceCompliance{}, err
+	}
+	ts := readiness.SubmittedAt.Format("2006-01-02T15:04:05Z07:00")
+	return prr.ServiceCompliance{
+		Service:           svc,
+		ComplianceStatus:  prr.ComputeComplianceStatus(interval, readiness.SubmittedAt, true, now),
+		LatestSubmittedAt: &ts,
+	}, nil
+}
+
+// serviceComplianceForAll computes ServiceCompliance for every service
+// in services, as of now.
+func (a *API) serviceComplianceForAll(ctx context.Context, services []prr.Service, now time.Time) ([]prr.ServiceCompliance, error) {
+	policy, err := a.Store.GetExpiryPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]prr.ServiceCompliance, 0, len(services))
+	for _, svc := range services {
+		compliance, err := a.serviceCompliance(ctx, svc, policy, now)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, compliance)
+	}
+	return out, nil
+}
+
+// RunExpiryScheduler periodically checks for services whose PRR has
+// expired and best-effort notifies configured chat integrations, until
+// ctx is cancelled. Callers run it in its own goroutine; see
+// cmd/prrserver/main.go.
+func (a *API) RunExpiryScheduler(ctx context.Context, checkInterval time.Duration) {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.notifyStaleServices(ctx)
+			a.notifyApproachingExpiry(ctx)
+		}
+	}
+}
+
+// notifyStaleServices best-effort notifies configured chat integrations
+// about every currently-stale service. Failures are logged but never
+// stop the scheduler.
+func (a *API) notifyStaleServices(ctx context.Context) {
+	if a.Teams == nil {
+		return
+	}
+	services, err := a.Store.ListServices(ctx)
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("expiry scheduler: failed to list services: %v", err)
+		}
+		return
+	}
+	stale, err := a.staleServicesAmong(ctx, a.Clock.Now(), services)
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("expiry scheduler: failed to compute stale services: %v", err)
+		}
+		return
+	}
+	for _, s := range stale {
+		text := fmt.Sprintf("**%s**'s PRR has expired and needs to be re-run.", s.Service.Name)
+		if err := a.Teams.Send(ctx, "PRR expired", text); err != nil && a.Logger != nil {
+			a.Logger.Printf("failed to send expiry notification for %s: %v", s.Service.ID, err)
+		}
+	}
+}
+
+// notifyApproachingExpiry best-effort emails the owning team of every
+// service whose ComplianceStatus has become due soon. Failures are
+// logged but never stop the scheduler.
+func (a *API) notifyApproachingExpiry(ctx context.Context) {
+	if a.Email == nil {
+		return
+	}
+	services, err := a.Store.ListServices(ctx)
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("expiry scheduler: failed to list services: %v", err)
+		}
+		return
+	}
+	compliance, err := a.serviceComplianceForAll(ctx, services, a.Clock.Now())
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("expiry scheduler: failed to compute compliance status: %v", err)
+		}
+		return
+	}
+	for _, c := range compliance {
+		if c.ComplianceStatus != prr.ComplianceDueSoon {
+			continue
+		}
+		a.sendLifecycleEmail(c.Service.OwnerEmail, a.EmailTemplates.ExpiryApproaching, defaultEmailTemplates.ExpiryApproaching, emailTemplateData{Service: c.Service})
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleListStaleServices(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := New(st, prr.FixedClock{At: now}, idGen, nil)
+
+	if err := st.SetExpiryPolicy(context.Background(), prr.ExpiryPolicy{IntervalDays: 30}); err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := prr.Service{ID: idGen.NewID(), Name: "fresh"}
+	stale := prr.Service{ID: idGen.NewID(), Name: "stale"}
+	never := prr.Service{ID: idGen.NewID(), Name: "never-submitted"}
+	for _, svc := range []prr.Service{fresh, stale, never} {
+		if err := st.CreateService(context.Background(), svc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.CreateSubmission(context.Background(), prr.Submission{
+		ID: idGen.NewID(), ServiceID: fresh.ID, CreatedAt: now.AddDate(0, 0, -5),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(context.Background(), prr.Submission{
+		ID: idGen.NewID(), ServiceID: stale.ID, CreatedAt: now.AddDate(0, 0, -60),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/stale", nil)
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var got []staleService
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len(got) = %d, want 2: %+v", len(got), got)
+	}
+	names := map[string]bool{}
+	for _, s := range got {
+		names[s.Service.Name] = true
+	}
+	if !names["stale"] || !names["never-submitted"] {
+		t.Fatalf("got = %+v, want stale and never-submitted", got)
+	}
+}
+
+func TestHandleExpiryConfigGetAndSet(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/expiry", nil))
+	var got prr.ExpiryPolicy
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != prr.DefaultExpiryPolicy {
+		t.Fatalf("got = %+v, want default %+v", got, prr.DefaultExpiryPolicy)
+	}
+
+	body := `{"interval_days": 90}`
+	rec = httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/expiry", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/report"
+)
+
+// handleExportSubmission renders the submission identified by id as a
+// downloadable document. Only format=pdf is supported today; other
+// formats (CSV, Markdown) are left for later requests.
+func (a *API) handleExportSubmission(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if id == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "pdf"
+	}
+	if format != "pdf" {
+		writeAPIError(w, "unsupported format: "+format, http.StatusBadRequest)
+		return
+	}
+
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	svc, err := a.authorizedService(r.Context(), r, sub.ServiceID)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	scoped, questions, err := a.scopeSubmissionToTemplate(r.Context(), sub)
+	if err != nil {
+		writeAPIError(w, "failed to resolve template", http.StatusInternalServerError)
+		return
+	}
+	thresholds, err := a.Store.GetScoringThresholds(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to load scoring config", http.StatusInternalServerError)
+		return
+	}
+
+	score := prr.ComputeScore(scoped, questions)
+	score.Grade = prr.ComputeGrade(score.Percent, thresholds)
+	pdf := report.RenderSubmissionPDF(svc, sub, score)
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+sub.ID+".pdf\"")
+	w.WriteHeader(http.StatusOK)
+	w.Write(pdf)
+}
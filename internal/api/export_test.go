@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleExportSubmissionReturnsPDF(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/export?id="+sub.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/pdf" {
+		t.Fatalf("Content-Type = %q, want application/pdf", ct)
+	}
+}
+
+func TestHandleExportSubmissionRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/export?id="+sub.ID, nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleExportSubmissionRejectsUnsupportedFormat(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	sub := prr.Submission{ID: "sub-1", ServiceID: "svc-1"}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "payments"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/export?id="+sub.ID+"&format=csv", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandleExportSubmissionNotFound(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/export?id=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestHandleExportSubmissionRequiresID(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/export", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// formQuestion is the subset of a Question the review form needs to
+// render it, in the order it should appear within its section.
+type formQuestion struct {
+	ID               string           `json:"id"`
+	Text             string           `json:"text"`
+	Type             prr.QuestionType `json:"type,omitempty"`
+	Options          []string         `json:"options,omitempty"`
+	Blurb            string           `json:"blurb,omitempty"`
+	SupportingLink   string           `json:"supporting_link,omitempty"`
+	IsEssential      bool             `json:"is_essential,omitempty"`
+	ParentQuestionID string           `json:"parent_question_id,omitempty"`
+	ShowWhenAnswer   string           `json:"show_when_answer,omitempty"`
+}
+
+// formSection is a Section along with its ordered, active questions.
+type formSection struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Questions []formQuestion `json:"questions"`
+}
+
+// handleReviewForm assembles the sections and active questions a client
+// needs to render the review form in one response, instead of fetching
+// /admin/sections and /admin/questions separately and joining them
+// client-side. If template_id is set, both sections and questions are
+// narrowed to that template's selection. The response is served with an
+// ETag and honors If-None-Match, since it's fetched on every form load
+// but rarely changes.
+func (a *API) handleReviewForm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	templateID := r.URL.Query().Get("template_id")
+	locale := resolveLocale(r)
+
+	sections, err := a.Store.ListSections(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to list sections", http.StatusInternalServerError)
+		return
+	}
+	sortSections(sections)
+
+	questions, err := a.questionsForTemplateID(r.Context(), templateID)
+	if err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+	sortQuestions(questions)
+
+	if templateID != "" {
+		tmpl, err := a.Store.GetTemplate(r.Context(), templateID)
+		if err != nil {
+			writeStoreError(w, err, "template")
+			return
+		}
+		if len(tmpl.SectionIDs) > 0 {
+			allowed := make(map[string]bool, len(tmpl.SectionIDs))
+			for _, id := range tmpl.SectionIDs {
+				allowed[id] = true
+			}
+			narrowed := make([]prr.Section, 0, len(sections))
+			for _, sec := range sections {
+				if allowed[sec.ID] {
+					narrowed = append(narrowed, sec)
+				}
+			}
+			sections = narrowed
+		}
+	}
+
+	bySection := make(map[string][]formQuestion, len(sections))
+	for _, q := range questions {
+		if q.Archived {
+			continue
+		}
+		text, blurb := localizedQuestionText(q, locale)
+		bySection[q.SectionID] = append(bySection[q.SectionID], formQuestion{
+			ID:               q.ID,
+			Text:             text,
+			Type:             q.Type,
+			Options:          q.Options,
+			Blurb:            blurb,
+			SupportingLink:   q.SupportingLink,
+			IsEssential:      q.IsEssential,
+			ParentQuestionID: q.ParentQuestionID,
+			ShowWhenAnswer:   q.ShowWhenAnswer,
+		})
+	}
+
+	form := make([]formSection, 0, len(sections))
+	for _, sec := range sections {
+		form = append(form, formSection{ID: sec.ID, Name: localizedSectionName(sec, locale), Questions: bySection[sec.ID]})
+	}
+
+	writeJSONWithETag(w, r, form)
+}
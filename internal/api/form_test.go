@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestReviewFormGroupsActiveQuestionsBySection(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-1", Name: "Security", Order: 0}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-2", Name: "Reliability", Order: 1}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?", Blurb: "See the auth guide.", SupportingLink: "https://wiki.example.com/auth", IsEssential: true}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q2", SectionID: "sec-1", Text: "Retired question", Archived: true}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/form", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var form []formSection
+	if err := json.Unmarshal(rec.Body.Bytes(), &form); err != nil {
+		t.Fatalf("unmarshal form: %v", err)
+	}
+	if len(form) != 2 {
+		t.Fatalf("len(form) = %d, want 2 sections", len(form))
+	}
+	if form[0].ID != "sec-1" || len(form[0].Questions) != 1 {
+		t.Fatalf("sec-1 = %+v, want exactly q1 (q2 is archived)", form[0])
+	}
+	q1 := form[0].Questions[0]
+	if q1.ID != "q1" || !q1.IsEssential || q1.Blurb != "See the auth guide." || q1.SupportingLink != "https://wiki.example.com/auth" {
+		t.Fatalf("q1 = %+v, want full metadata carried through", q1)
+	}
+	if form[1].ID != "sec-2" || len(form[1].Questions) != 0 {
+		t.Fatalf("sec-2 = %+v, want no questions", form[1])
+	}
+}
+
+func TestReviewFormNarrowsToTemplate(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-1", Name: "Security"}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-2", Name: "Reliability"}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q2", SectionID: "sec-2", Text: "Is there an on-call rotation?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	if err := st.UpsertTemplate(ctx, prr.Template{ID: "tmpl-1", Name: "Minimal", SectionIDs: []string{"sec-1"}, QuestionIDs: []string{"q1"}}); err != nil {
+		t.Fatalf("upsert template: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/form?template_id=tmpl-1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var form []formSection
+	json.Unmarshal(rec.Body.Bytes(), &form)
+	if len(form) != 1 || form[0].ID != "sec-1" {
+		t.Fatalf("form = %+v, want only sec-1", form)
+	}
+}
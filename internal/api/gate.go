@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type gateResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+// preReleaseGateResponse reports whether a service is ready to release,
+// for a deployment pipeline to gate on.
+type preReleaseGateResponse struct {
+	ServiceID string    `json:"service_id"`
+	Pass      bool      `json:"pass"`
+	Grade     prr.Grade `json:"grade,omitempty"`
+	// Reasons lists every check that failed. Empty when Pass is true.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// handlePreReleaseGate reports whether service_id is ready to release,
+// based on its latest PRR submission: it must exist, be no older than
+// the configured expiry policy, carry no essential "no" answers, and
+// score at least Amber under the configured grade thresholds. It's
+// meant to be polled from a deployment pipeline rather than a human, so
+// unlike handleEvaluateGate it needs no policy-as-code backend
+// configured and never 5xxs for a service that simply isn't ready.
+func (a *API) handlePreReleaseGate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	serviceID := r.URL.Query().Get("service_id")
+	if serviceID == "" {
+		writeAPIError(w, "service_id is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := a.authorizedService(r.Context(), r, serviceID); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+
+	submissions, err := a.Store.ListSubmissionsByService(r.Context(), serviceID)
+	if err != nil {
+		writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+		return
+	}
+	resp := preReleaseGateResponse{ServiceID: serviceID}
+	if len(submissions) == 0 {
+		resp.Reasons = append(resp.Reasons, "no PRR submission found for this service")
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+	sort.Slice(submissions, func(i, j int) bool { return submissions[i].CreatedAt.After(submissions[j].CreatedAt) })
+	latest := submissions[0]
+
+	expiry, err := a.Store.GetExpiryPolicy(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to load expiry config", http.StatusInternalServerError)
+		return
+	}
+	if age := a.Clock.Now().Sub(latest.CreatedAt); age.Hours() > float64(expiry.IntervalDays)*24 {
+		resp.Reasons = append(resp.Reasons, "latest PRR submission is stale")
+	}
+
+	grade, blocking, err := a.evaluateSubmission(r.Context(), latest)
+	if err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+	resp.Grade = grade
+	for _, issue := range blocking {
+		resp.Reasons = append(resp.Reasons, "blocking issue: "+issue)
+	}
+	if grade == prr.GradeRed {
+		resp.Reasons = append(resp.Reasons, "grade is below the minimum required for release")
+	}
+
+	resp.Pass = len(resp.Reasons) == 0
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleEvaluateGate evaluates a submission against the configured
+// policy-as-code release gate and reports whether it passes.
+func (a *API) handleEvaluateGate(w http.ResponseWriter, r *http.Request, submissionID string) {
+	if a.Gate == nil {
+		writeAPIError(w, "policy gate is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	sub, err := a.authorizedSubmission(r.Context(), r, submissionID)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	allowed, err := a.Gate.Evaluate(r.Context(), sub)
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("policy gate evaluation failed: %v", err)
+		}
+		writeAPIError(w, "failed to evaluate policy gate", http.StatusBadGateway)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, gateResponse{Allowed: allowed})
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestPreReleaseGatePassesForFreshHighGradeSubmission(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := New(st, prr.FixedClock{At: now}, idGen, nil)
+	ctx := context.Background()
+
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?", IsEssential: true}); err != nil {
+		t.Fatal(err)
+	}
+	svc := prr.Service{ID: idGen.NewID(), Name: "checkout"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{
+		ID: idGen.NewID(), ServiceID: svc.ID, CreatedAt: now.AddDate(0, 0, -1),
+		Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gate?service_id="+svc.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp preReleaseGateResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Pass {
+		t.Fatalf("Pass = false, reasons=%v", resp.Reasons)
+	}
+	if resp.Grade != prr.GradeGreen {
+		t.Fatalf("Grade = %q, want green", resp.Grade)
+	}
+}
+
+func TestPreReleaseGateFailsForMissingSubmissionStaleGradeAndBlockingIssue(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := New(st, prr.FixedClock{At: now}, idGen, nil)
+	ctx := context.Background()
+
+	if err := st.SetExpiryPolicy(ctx, prr.ExpiryPolicy{IntervalDays: 30}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?", IsEssential: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	noSubmissions := prr.Service{ID: idGen.NewID(), Name: "no-submissions"}
+	staleAndFailing := prr.Service{ID: idGen.NewID(), Name: "stale-and-failing"}
+	for _, svc := range []prr.Service{noSubmissions, staleAndFailing} {
+		if err := st.CreateService(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{
+		ID: idGen.NewID(), ServiceID: staleAndFailing.ID, CreatedAt: now.AddDate(0, 0, -60),
+		Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gate?service_id="+noSubmissions.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var noneResp preReleaseGateResponse
+	json.Unmarshal(rec.Body.Bytes(), &noneResp)
+	if noneResp.Pass || len(noneResp.Reasons) == 0 {
+		t.Fatalf("no-submissions gate result = %+v, want a failing reason", noneResp)
+	}
+
+	rec = httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gate?service_id="+staleAndFailing.ID, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp preReleaseGateResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Pass {
+		t.Fatalf("expected gate to fail, got %+v", resp)
+	}
+	if resp.Grade != prr.GradeRed {
+		t.Fatalf("Grade = %q, want red", resp.Grade)
+	}
+	if len(resp.Reasons) < 2 {
+		t.Fatalf("Reasons = %v, want at least a staleness and a blocking-issue/grade reason", resp.Reasons)
+	}
+}
+
+func TestPreReleaseGateRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	svc := prr.Service{ID: "svc-1", Name: "checkout", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/gate?service_id="+svc.ID, nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestPreReleaseGateUnknownServiceReturnsNotFound(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gate?service_id=missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
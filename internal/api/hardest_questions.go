@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+)
+
+// hardestQuestion is one question's org-wide "No" rate, as of each
+// service's latest submission, broken down by the section it belongs to
+// and the service tiers that answered it.
+type hardestQuestion struct {
+	QuestionID string         `json:"question_id"`
+	SectionID  string         `json:"section_id"`
+	NoRate     float64        `json:"no_rate"`
+	Answered   int            `json:"answered"`
+	ByTier     map[string]int `json:"by_tier_no_count,omitempty"`
+}
+
+type hardestQuestionsResponse struct {
+	Questions []hardestQuestion `json:"questions"`
+}
+
+// handleHardestQuestions ranks questions by how often the latest
+// submission across every service answered them "no", so the platform
+// team can see which readiness criteria the org struggles with most and
+// prioritize tooling or guidance that would raise it.
+func (a *API) handleHardestQuestions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx := r.Context()
+	questions, err := a.Store.ListQuestions(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+		return
+	}
+	sections := make(map[string]string, len(questions))
+	for _, q := range questions {
+		sections[q.ID] = q.SectionID
+	}
+
+	services, err := a.visibleServices(ctx, resolveTenant(r))
+	if err != nil {
+		writeAPIError(w, "failed to list services", http.StatusInternalServerError)
+		return
+	}
+
+	counts := make(map[string]*answerTally, len(questions))
+	byTier := make(map[string]map[string]int, len(questions))
+	for _, q := range questions {
+		counts[q.ID] = &answerTally{}
+		byTier[q.ID] = make(map[string]int)
+	}
+
+	for _, svc := range services {
+		sub, err := a.latestSubmission(ctx, svc.ID)
+		if err != nil {
+			continue
+		}
+		tier := string(svc.Tier)
+		if tier == "" {
+			tier = "unrated"
+		}
+		for _, ans := range sub.Answers {
+			t, ok := counts[ans.QuestionID]
+			if !ok {
+				continue
+			}
+			t.add(ans.Value)
+			if ans.Value == "no" {
+				byTier[ans.QuestionID][tier]++
+			}
+		}
+	}
+
+	resp := hardestQuestionsResponse{}
+	for id, t := range counts {
+		answered := t.Yes + t.No + t.NA + t.Other
+		if answered == 0 {
+			continue
+		}
+		resp.Questions = append(resp.Questions, hardestQuestion{
+			QuestionID: id,
+			SectionID:  sections[id],
+			NoRate:     float64(t.No) / float64(answered),
+			Answered:   answered,
+			ByTier:     byTier[id],
+		})
+	}
+
+	sort.Slice(resp.Questions, func(i, j int) bool {
+		if resp.Questions[i].NoRate != resp.Questions[j].NoRate {
+			return resp.Questions[i].NoRate > resp.Questions[j].NoRate
+		}
+		return resp.Questions[i].QuestionID < resp.Questions[j].QuestionID
+	})
+
+	writeJSON(w, http.StatusOK, resp)
+}
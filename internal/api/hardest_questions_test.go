@@ -0,0 +1,127 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleHardestQuestions(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	a := New(st, prr.SystemClock{}, idGen, nil)
+
+	ctx := context.Background()
+	for _, q := range []prr.Question{
+		{ID: "q1", SectionID: "sec1"},
+		{ID: "q2", SectionID: "sec1"},
+	} {
+		if err := st.UpsertQuestion(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc1 := prr.Service{ID: idGen.NewID(), Name: "svc1", Tier: prr.TierCritical}
+	svc2 := prr.Service{ID: idGen.NewID(), Name: "svc2", Tier: prr.TierLow}
+	for _, svc := range []prr.Service{svc1, svc2} {
+		if err := st.CreateService(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// svc1's latest submission answers q1 "no"; an earlier, superseded
+	// submission answering q1 "yes" must not count.
+	if err := st.CreateSubmission(ctx, prr.Submission{
+		ID:        idGen.NewID(),
+		ServiceID: svc1.ID,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{
+		ID:        idGen.NewID(),
+		ServiceID: svc1.ID,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "no"}, {QuestionID: "q2", Value: "yes"}},
+		CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{
+		ID:        idGen.NewID(),
+		ServiceID: svc2.ID,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "no"}, {QuestionID: "q2", Value: "no"}},
+		CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/analytics/questions/hardest", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp hardestQuestionsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Questions) != 2 {
+		t.Fatalf("Questions = %+v, want 2 entries", resp.Questions)
+	}
+	// q1 was answered "no" by both services, q2 by only one: q1 should
+	// rank hardest.
+	if resp.Questions[0].QuestionID != "q1" || resp.Questions[0].NoRate != 1 {
+		t.Errorf("Questions[0] = %+v, want q1 with no_rate 1", resp.Questions[0])
+	}
+	if resp.Questions[0].ByTier["critical"] != 1 || resp.Questions[0].ByTier["low"] != 1 {
+		t.Errorf("Questions[0].ByTier = %+v, want critical:1 low:1", resp.Questions[0].ByTier)
+	}
+	if resp.Questions[1].QuestionID != "q2" || resp.Questions[1].NoRate != 0.5 {
+		t.Errorf("Questions[1] = %+v, want q2 with no_rate 0.5", resp.Questions[1])
+	}
+}
+
+func TestHandleHardestQuestionsIsScopedToResolvedTenant(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	a := New(st, prr.SystemClock{}, idGen, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-a", Name: "svc-a", TenantID: "tenant-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-b", Name: "svc-b", TenantID: "tenant-b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-a", ServiceID: "svc-a", Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-b", ServiceID: "svc-b", Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/analytics/questions/hardest", nil)
+	req.Header.Set(tenantHeader, "tenant-a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp hardestQuestionsResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp.Questions) != 1 || resp.Questions[0].Answered != 1 || resp.Questions[0].NoRate != 1 {
+		t.Fatalf("hardest questions as tenant-a = %+v, want only svc-a's no answer counted", resp.Questions)
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import "net/http"
+
+// handleHealthz reports process liveness: if the handler can run at
+// all, the process is alive. It never depends on downstream services,
+// so Kubernetes won't kill a pod that's merely waiting on a slow
+// Elasticsearch.
+func (a *API) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReadyz reports whether the service is ready to take traffic:
+// it pings Elasticsearch when one is configured, so load balancers stop
+// sending requests to an instance that can't actually serve them.
+func (a *API) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if a.ES != nil {
+		if err := a.ES.Ping(r.Context()); err != nil {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{
+				"status": "not ready",
+				"reason": err.Error(),
+			})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
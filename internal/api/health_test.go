@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+// fakePinger is a test double for es.Pinger, avoiding the need for a
+// real Elasticsearch cluster to exercise handleReadyz.
+type fakePinger struct {
+	err error
+}
+
+func (f fakePinger) Ping(ctx context.Context) error { return f.err }
+
+func TestHealthzAndReadyz(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("healthz status = %d, want 200", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("readyz status = %d, want 200", rec.Code)
+	}
+}
+
+func TestReadyzReportsUnreachableElasticsearch(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.ES = fakePinger{err: errors.New("connection refused")}
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz status = %d, want 503", rec.Code)
+	}
+}
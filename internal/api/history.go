@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+type submissionHistoryResponse struct {
+	Submissions []prr.Submission `json:"submissions"`
+	Limit       int              `json:"limit"`
+	Offset      int              `json:"offset"`
+	Total       int              `json:"total"`
+}
+
+// handleListSubmissionHistory returns a page of a service's PRR
+// submission history, most recent first.
+func (a *API) handleListSubmissionHistory(w http.ResponseWriter, r *http.Request, serviceID string) {
+	if _, err := a.authorizedService(r.Context(), r, serviceID); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+
+	limit := queryInt(r, "limit", defaultHistoryLimit)
+	if limit <= 0 || limit > maxHistoryLimit {
+		limit = defaultHistoryLimit
+	}
+	offset := queryInt(r, "offset", 0)
+
+	all, err := a.Store.ListSubmissionsByService(r.Context(), serviceID)
+	if err != nil {
+		writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].CreatedAt.After(all[j].CreatedAt)
+	})
+
+	page := []prr.Submission{}
+	if offset < len(all) {
+		end := offset + limit
+		if end > len(all) {
+			end = len(all)
+		}
+		page = all[offset:end]
+	}
+
+	writeJSON(w, http.StatusOK, submissionHistoryResponse{
+		Submissions: page,
+		Limit:       limit,
+		Offset:      offset,
+		Total:       len(all),
+	})
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/csv"
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// handleExportSubmissionHistory streams a service's full submission
+// history as CSV, one row per answer, for compliance teams who want a
+// spreadsheet rather than the paginated JSON from
+// handleListSubmissionHistory. The in-memory store returns a service's
+// whole history in one call, so there's no need for the scroll/search
+// cursor a real Elasticsearch-backed history would require to stream
+// large result sets without an unbounded query.
+func (a *API) handleExportSubmissionHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	serviceID := r.URL.Query().Get("service_id")
+	if serviceID == "" {
+		writeAPIError(w, "service_id is required", http.StatusBadRequest)
+		return
+	}
+	if format := r.URL.Query().Get("format"); format != "" && format != "csv" {
+		writeAPIError(w, "unsupported format: "+format, http.StatusBadRequest)
+		return
+	}
+
+	if _, err := a.authorizedService(r.Context(), r, serviceID); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	submissions, err := a.Store.ListSubmissionsByService(r.Context(), serviceID)
+	if err != nil {
+		writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].CreatedAt.Before(submissions[j].CreatedAt)
+	})
+	questions, err := a.Store.ListQuestions(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+		return
+	}
+	questionByID := make(map[string]prr.Question, len(questions))
+	for _, q := range questions {
+		questionByID[q.ID] = q
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+serviceID+"-history.csv\"")
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"submission_id", "created_at", "status", "section_id", "question_id", "question_text", "response"})
+	for _, sub := range submissions {
+		for _, ans := range sub.Answers {
+			q := questionByID[ans.QuestionID]
+			cw.Write([]string{
+				sub.ID,
+				sub.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+				string(sub.Status),
+				q.SectionID,
+				ans.QuestionID,
+				q.Text,
+				ans.Value,
+			})
+		}
+	}
+	cw.Flush()
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestExportSubmissionHistoryCSV(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.UpsertQuestion(httptest.NewRequest(http.MethodGet, "/", nil).Context(), prr.Question{ID: "q1"}); err != nil {
+		t.Fatalf("seed question: %v", err)
+	}
+
+	createBody, _ := json.Marshal(map[string]string{"name": "svc"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(map[string]interface{}{
+		"service_id": svc.ID,
+		"answers":    []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/history/export?service_id="+svc.ID+"&format=csv", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("export status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "submission_id,created_at,status,section_id,question_id,question_text,response") {
+		t.Fatalf("missing header: %s", body)
+	}
+	if !strings.Contains(body, ",q1,,yes") {
+		t.Fatalf("missing answer row: %s", body)
+	}
+}
+
+func TestExportSubmissionHistoryRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	svc := prr.Service{ID: "svc-1", Name: "svc", TenantID: "tenant-a"}
+	if err := st.CreateService(httptest.NewRequest(http.MethodGet, "/", nil).Context(), svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/prr/history/export?service_id="+svc.ID, nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
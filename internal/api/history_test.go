@@ -0,0 +1,73 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleListSubmissionHistoryPaginates(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	a := New(st, prr.SystemClock{}, idGen, nil)
+
+	ctx := context.Background()
+	svc := prr.Service{ID: idGen.NewID(), Name: "svc"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		sub := prr.Submission{
+			ID:        idGen.NewID(),
+			ServiceID: svc.ID,
+			CreatedAt: time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC),
+		}
+		if err := st.CreateSubmission(ctx, sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/submissions?limit=2&offset=1", nil)
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp submissionHistoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 5 || len(resp.Submissions) != 2 {
+		t.Fatalf("resp = %+v, want total=5 len=2", resp)
+	}
+	// Most recent first: day 5 is newest, offset 1 skips it, page starts at day 4.
+	if resp.Submissions[0].CreatedAt.Day() != 4 {
+		t.Fatalf("Submissions[0].CreatedAt.Day() = %d, want 4", resp.Submissions[0].CreatedAt.Day())
+	}
+}
+
+func TestHandleListSubmissionHistoryRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	svc := prr.Service{ID: "svc-1", Name: "svc", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/submissions", nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
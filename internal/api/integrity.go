@@ -0,0 +1,65 @@
+package api
+
+import (
+	"crypto/hmac"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// stampContentHash computes sub's ContentHash (and, when a.SigningKey
+// is configured, its Signature), overwriting whatever was there before.
+// It must be called after every change to a submission's answers,
+// template or timestamps, since those are exactly what ContentHash
+// covers.
+func (a *API) stampContentHash(sub prr.Submission) prr.Submission {
+	sub.ContentHash = prr.ContentHash(sub)
+	sub.Signature = ""
+	if a.SigningKey != "" {
+		sub.Signature = prr.SignSubmission(sub, a.SigningKey)
+	}
+	return sub
+}
+
+// verifyResult reports whether a submission's stored hash (and
+// signature, if one was recorded) still matches its content.
+type verifyResult struct {
+	SubmissionID     string `json:"submission_id"`
+	Verified         bool   `json:"verified"`
+	ExpectedHash     string `json:"expected_hash"`
+	StoredHash       string `json:"stored_hash"`
+	SignatureValid   bool   `json:"signature_valid,omitempty"`
+	SignatureChecked bool   `json:"signature_checked"`
+}
+
+// handleVerifySubmission recomputes the submission identified by id's
+// content hash (and signature, if a.SigningKey is configured) and
+// compares it against what was stamped at submission time, proving the
+// stored answers haven't been altered since.
+func (a *API) handleVerifySubmission(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	expectedHash := prr.ContentHash(sub)
+	result := verifyResult{
+		SubmissionID: sub.ID,
+		ExpectedHash: expectedHash,
+		StoredHash:   sub.ContentHash,
+		Verified:     sub.ContentHash != "" && sub.ContentHash == expectedHash,
+	}
+	if a.SigningKey != "" {
+		result.SignatureChecked = true
+		expectedSig := prr.SignSubmission(sub, a.SigningKey)
+		result.SignatureValid = sub.Signature != "" && hmac.Equal([]byte(sub.Signature), []byte(expectedSig))
+		result.Verified = result.Verified && result.SignatureValid
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
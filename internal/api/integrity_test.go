@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleVerifySubmissionDetectsTampering(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "payments"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: "svc-1", Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var sub prr.Submission
+	if err := json.Unmarshal(rec.Body.Bytes(), &sub); err != nil {
+		t.Fatalf("unmarshal submission: %v", err)
+	}
+	if sub.ContentHash == "" {
+		t.Fatalf("submission has no content hash")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/verify", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("verify status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var result verifyResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unmarshal verify result: %v", err)
+	}
+	if !result.Verified {
+		t.Fatalf("expected verified, got %+v", result)
+	}
+
+	tampered := sub
+	tampered.Answers[0].Value = "no"
+	if err := st.UpdateSubmission(ctx, tampered); err != nil {
+		t.Fatalf("tamper submission: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/verify", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("verify status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unmarshal verify result: %v", err)
+	}
+	if result.Verified {
+		t.Fatalf("expected tampered submission to fail verification, got %+v", result)
+	}
+}
+
+func TestHandleVerifySubmissionChecksSignatureWhenConfigured(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.SigningKey = "top-secret"
+	router := a.Router()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "payments"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: "svc-1", Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+	var sub prr.Submission
+	if err := json.Unmarshal(rec.Body.Bytes(), &sub); err != nil {
+		t.Fatalf("unmarshal submission: %v", err)
+	}
+	if sub.Signature == "" {
+		t.Fatalf("submission has no signature")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/verify", nil))
+	var result verifyResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("unmarshal verify result: %v", err)
+	}
+	if !result.SignatureChecked || !result.SignatureValid || !result.Verified {
+		t.Fatalf("expected a valid signed verification, got %+v", result)
+	}
+}
+
+func TestHandleVerifySubmissionRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/"+sub.ID+"/verify", nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleVerifySubmissionNotFound(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/missing/verify", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
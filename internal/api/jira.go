@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jira"
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// defaultJiraIssueType is used when a configured prr.JiraConfig leaves
+// IssueType empty.
+const defaultJiraIssueType = "Task"
+
+// handleJiraConfig gets or sets the Jira integration that failing
+// essential questions are auto-filed against.
+func (a *API) handleJiraConfig(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		config, err := a.Store.GetJiraConfig(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to load jira config", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, config)
+	case http.MethodPut:
+		var config prr.JiraConfig
+		if err := jsonvalidate.Decode(r.Body, &config); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if config.BaseURL != "" && config.ProjectKey == "" {
+			writeAPIError(w, "project_key is required when base_url is set", http.StatusBadRequest)
+			return
+		}
+		if err := a.Store.SetJiraConfig(r.Context(), config); err != nil {
+			writeAPIError(w, "failed to save jira config", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, config)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// syncJiraTickets auto-files (or updates) one Jira issue per question in
+// sub.BlockingIssues, tracking the issue key on the submission so a
+// later re-score updates the same ticket rather than opening a
+// duplicate. It is a no-op if Jira is not configured via
+// /admin/integrations/jira. Failures are logged but never fail the
+// submission request itself.
+func (a *API) syncJiraTickets(ctx context.Context, svc prr.Service, sub prr.Submission) prr.Submission {
+	config, err := a.Store.GetJiraConfig(ctx)
+	if err != nil || config.BaseURL == "" || len(sub.BlockingIssues) == 0 {
+		return sub
+	}
+	issueType := config.IssueType
+	if issueType == "" {
+		issueType = defaultJiraIssueType
+	}
+	client := jira.NewClient(config.BaseURL, config.Email, config.APIToken)
+
+	keys := make(map[string]string, len(sub.BlockingIssues))
+	for k, v := range sub.JiraIssueKeys {
+		keys[k] = v
+	}
+	description := fmt.Sprintf("PRR submission %s for %s failed essential question %%s.", sub.ID, svc.Name)
+	for _, questionID := range sub.BlockingIssues {
+		desc := fmt.Sprintf(description, questionID)
+		if existing, ok := keys[questionID]; ok {
+			if err := client.UpdateIssue(ctx, existing, desc); err != nil && a.Logger != nil {
+				a.Logger.Printf("failed to update jira issue %s: %v", existing, err)
+			}
+			continue
+		}
+		summary := fmt.Sprintf("PRR: %s failed essential question %s", svc.Name, questionID)
+		key, err := client.CreateIssue(ctx, config.ProjectKey, issueType, summary, desc)
+		if err != nil {
+			if a.Logger != nil {
+				a.Logger.Printf("failed to create jira issue for %s/%s: %v", sub.ID, questionID, err)
+			}
+			continue
+		}
+		keys[questionID] = key
+	}
+	sub.JiraIssueKeys = keys
+	return sub
+}
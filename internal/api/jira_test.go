@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestJiraConfigGetAndPut(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/integrations/jira", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("get status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var config prr.JiraConfig
+	if err := json.Unmarshal(rec.Body.Bytes(), &config); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if config.BaseURL != "" {
+		t.Fatalf("expected empty default config, got %+v", config)
+	}
+
+	putBody, _ := json.Marshal(prr.JiraConfig{BaseURL: "https://example.atlassian.net", ProjectKey: "PRR"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/integrations/jira", bytes.NewReader(putBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("put status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	saved, err := st.GetJiraConfig(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.ProjectKey != "PRR" {
+		t.Fatalf("ProjectKey = %q, want PRR", saved.ProjectKey)
+	}
+}
+
+func TestJiraConfigRejectsBaseURLWithoutProjectKey(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	putBody, _ := json.Marshal(prr.JiraConfig{BaseURL: "https://example.atlassian.net"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/integrations/jira", bytes.NewReader(putBody)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestCreateSubmissionFilesJiraTicketForBlockingQuestion(t *testing.T) {
+	var mu sync.Mutex
+	created := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		created++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"key":"PRR-1"}`))
+	}))
+	defer srv.Close()
+
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	if err := st.SetJiraConfig(ctx, prr.JiraConfig{BaseURL: srv.URL, ProjectKey: "PRR"}); err != nil {
+		t.Fatal(err)
+	}
+	router := a.Router()
+
+	question := prr.Question{ID: "has-backups", Text: "Does the service have backups?", IsEssential: true}
+	if err := st.UpsertQuestion(ctx, question); err != nil {
+		t.Fatal(err)
+	}
+
+	createBody, _ := json.Marshal(map[string]interface{}{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "has-backups", Value: "no"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+
+	if sub.JiraIssueKeys["has-backups"] != "PRR-1" {
+		t.Fatalf("JiraIssueKeys = %+v, want has-backups -> PRR-1", sub.JiraIssueKeys)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if created != 1 {
+		t.Fatalf("created = %d, want 1", created)
+	}
+}
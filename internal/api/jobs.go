@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jobqueue"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// jobStatusResponse reports the outcome of a job queued by
+// handleCreateSubmissionAsync.
+type jobStatusResponse struct {
+	ID         string          `json:"id"`
+	Status     jobqueue.Status `json:"status"`
+	Submission *prr.Submission `json:"submission,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// handleJobStatus reports the status of the background job identified
+// by id, including its resulting submission once done.
+func (a *API) handleJobStatus(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if a.Jobs == nil {
+		writeAPIError(w, "asynchronous submission processing is not enabled on this deployment", http.StatusNotImplemented)
+		return
+	}
+	job, ok := a.Jobs.Get(id)
+	if !ok {
+		writeAPIError(w, "job not found", http.StatusNotFound)
+		return
+	}
+	resp := jobStatusResponse{ID: job.ID, Status: job.Status, Error: job.Error}
+	if job.Status == jobqueue.StatusDone {
+		if sub, ok := job.Result.(prr.Submission); ok {
+			resp.Submission = &sub
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/jobqueue"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestCreateSubmissionAsyncReportsJobStatus(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	a.Jobs = jobqueue.New(1)
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionDraft})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions?async=true", bytes.NewReader(body)))
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("create status = %d, want 202, body=%s", rec.Code, rec.Body.String())
+	}
+	var accepted struct {
+		JobID     string `json:"job_id"`
+		StatusURL string `json:"status_url"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &accepted); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if accepted.JobID == "" || accepted.StatusURL == "" {
+		t.Fatalf("accepted response missing job_id/status_url: %+v", accepted)
+	}
+
+	var status jobStatusResponse
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, accepted.StatusURL, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("job status = %d, body=%s", rec.Code, rec.Body.String())
+		}
+		json.Unmarshal(rec.Body.Bytes(), &status)
+		if status.Status == jobqueue.StatusDone || status.Status == jobqueue.StatusFailed {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if status.Status != jobqueue.StatusDone {
+		t.Fatalf("job status = %s, want done (error=%s)", status.Status, status.Error)
+	}
+	if status.Submission == nil || status.Submission.ServiceID != svc.ID {
+		t.Fatalf("job result submission = %+v, want one for service %s", status.Submission, svc.ID)
+	}
+
+	subs, err := st.ListSubmissionsByService(httptest.NewRequest(http.MethodGet, "/", nil).Context(), svc.ID)
+	if err != nil {
+		t.Fatalf("ListSubmissionsByService: %v", err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("len(subs) = %d, want 1", len(subs))
+	}
+}
+
+func TestCreateSubmissionAsyncDisabledWithoutJobQueue(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionDraft})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions?async=true", bytes.NewReader(body)))
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("status = %d, want 501, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestJobStatusUnknownIDReturnsNotFound(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.Jobs = jobqueue.New(1)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/jobs/missing", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
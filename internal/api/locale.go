@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// resolveLocale picks the locale a request wants its question/section
+// text localized into. The "lang" query parameter takes precedence
+// (easy to set from a bookmark or a test), falling back to the first
+// tag of the Accept-Language header; an unset or unparsable header
+// resolves to "", which means "use the base, untranslated text".
+func resolveLocale(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return lang
+	}
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return ""
+	}
+	tag, _, _ := strings.Cut(header, ",")
+	tag, _, _ = strings.Cut(tag, ";")
+	return strings.TrimSpace(tag)
+}
+
+// localizedQuestionText returns q's Text and Blurb translated into
+// locale, falling back to the base Text/Blurb when locale is empty, has
+// no translation, or its translation leaves Text empty.
+func localizedQuestionText(q prr.Question, locale string) (text, blurb string) {
+	if locale != "" {
+		if t, ok := q.Translations[locale]; ok && t.Text != "" {
+			return t.Text, t.Blurb
+		}
+	}
+	return q.Text, q.Blurb
+}
+
+// localizedSectionName returns sec's Name translated into locale,
+// falling back to the base Name when locale is empty, has no
+// translation, or its translation leaves Name empty.
+func localizedSectionName(sec prr.Section, locale string) string {
+	if locale != "" {
+		if t, ok := sec.Translations[locale]; ok && t.Name != "" {
+			return t.Name
+		}
+	}
+	return sec.Name
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/blob"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestApprovalLocksSubmissionAgainstAmendmentsAndAttachments(t *testing.T) {
+	st := memory.New()
+	disk, err := blob.NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.Blob = disk
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	createBody, _ := json.Marshal(map[string]string{"name": "svc"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(map[string]interface{}{
+		"service_id": svc.ID,
+		"status":     prr.SubmissionFinal,
+		"answers":    []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+	if sub.Locked {
+		t.Fatalf("new submission is already locked")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/approve", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("approve status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var approved prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &approved)
+	if !approved.Locked {
+		t.Fatalf("approved submission is not locked")
+	}
+
+	amendBody, _ := json.Marshal(amendAnswersRequest{
+		Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}},
+		Reason:  "correcting a mistake",
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/prr/"+sub.ID+"/answers", bytes.NewReader(amendBody)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("amend status = %d, want 409, body=%s", rec.Code, rec.Body.String())
+	}
+
+	uploadBody, contentType := newMultipartUpload(t, "file", "evidence.txt", "text/plain", []byte("hello"))
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/prr/"+sub.ID+"/attachments", uploadBody)
+	req.Header.Set("Content-Type", contentType)
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("upload status = %d, want 409, body=%s", rec.Code, rec.Body.String())
+	}
+
+	// Unlocking clears Locked and records the justification, after
+	// which an amendment succeeds again.
+	unlockBody, _ := json.Marshal(unlockSubmissionRequest{Reason: "reopened for a compliance correction"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/unlock", bytes.NewReader(unlockBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unlock status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var unlocked prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &unlocked)
+	if unlocked.Locked {
+		t.Fatalf("submission still locked after unlock")
+	}
+	if unlocked.LastUnlock == nil || unlocked.LastUnlock.Reason != "reopened for a compliance correction" {
+		t.Fatalf("LastUnlock = %+v, want recorded reason", unlocked.LastUnlock)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/prr/"+sub.ID+"/answers", bytes.NewReader(amendBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("amend after unlock status = %d, want 201, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestUnlockSubmissionRequiresLockedAndReason(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "svc"}); err != nil {
+		t.Fatal(err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: "svc-1"}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/sub-1/unlock", bytes.NewReader([]byte(`{}`))))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400 when reason is missing, body=%s", rec.Code, rec.Body.String())
+	}
+
+	body, _ := json.Marshal(unlockSubmissionRequest{Reason: "just checking"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/sub-1/unlock", bytes.NewReader(body)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409 when not locked, body=%s", rec.Code, rec.Body.String())
+	}
+}
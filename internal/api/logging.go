@@ -0,0 +1,46 @@
+package api
+
+import "net/http"
+
+// loggingResponseWriter records the status code written so it can be
+// logged after the handler returns, since http.ResponseWriter doesn't
+// expose it directly.
+type loggingResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *loggingResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// withRequestLogging wraps next with structured JSON request logging: a
+// generated X-Request-ID header is set on every response, and when
+// SLogger is configured a log line is emitted alongside it with
+// method, path, status and latency, so logs from a single request can
+// be correlated across the stack. With no SLogger configured (e.g. in
+// tests) only the header is set.
+func (a *API) withRequestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := a.IDGen.NewID()
+		w.Header().Set("X-Request-ID", requestID)
+
+		if a.SLogger == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		lrw := &loggingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		start := a.Clock.Now()
+		next.ServeHTTP(lrw, r)
+
+		a.SLogger.Info("request",
+			"request_id", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", lrw.status,
+			"latency_ms", a.Clock.Now().Sub(start).Milliseconds(),
+		)
+	})
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+type partialSubmissionRequest struct {
+	ServiceID string       `json:"service_id"`
+	Answers   []prr.Answer `json:"answers"`
+	Reason    string       `json:"reason,omitempty"`
+}
+
+// handleSubmitPartial lets a team re-answer just the section(s) it
+// remediated instead of re-running a whole PRR: it layers Answers over
+// ServiceID's latest submission (see latestSubmission) to produce a new
+// derived submission flagged IsPartial, the same way
+// handleAmendSubmissionAnswers derives an amendment, and links back to
+// it via AmendsSubmissionID. Sections the request doesn't touch keep
+// their answers (and therefore their scores) from the latest
+// submission unchanged, so re-scoring the result only moves the score
+// of the sections actually resubmitted.
+func (a *API) handleSubmitPartial(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req partialSubmissionRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ServiceID == "" {
+		writeAPIError(w, "service_id is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.Answers) == 0 {
+		writeAPIError(w, "answers is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := a.authorizedService(r.Context(), r, req.ServiceID); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	original, err := a.latestSubmission(r.Context(), req.ServiceID)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+
+	if !a.DisableStrictValidation {
+		allowed, err := a.questionsForTemplateID(r.Context(), original.TemplateID)
+		if err != nil {
+			writeAPIError(w, "failed to validate answers", http.StatusInternalServerError)
+			return
+		}
+		if errs := prr.ValidateAnswers(req.Answers, allowed); len(errs) > 0 {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": errs})
+			return
+		}
+	}
+
+	corrections, err := a.stampAnswerVersions(r.Context(), req.Answers)
+	if err != nil {
+		writeAPIError(w, "failed to stamp question versions", http.StatusInternalServerError)
+		return
+	}
+	answers, changed := mergeAnswers(original.Answers, corrections)
+
+	submittedBy := "anonymous"
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		submittedBy = principal.Subject
+	}
+
+	now := a.Clock.Now()
+	partial := original
+	partial.ID = a.IDGen.NewID()
+	partial.Answers = answers
+	partial.Approval = prr.ApprovalPending
+	partial.Approvals = nil
+	partial.CreatedAt = now
+	partial.UpdatedAt = now
+	partial.IdempotencyKey = ""
+	partial.IsBaseline = false
+	partial.IsPartial = true
+	partial.AmendsSubmissionID = original.ID
+	partial.Amendment = &prr.Amendment{By: submittedBy, Reason: req.Reason, At: now, Changed: changed}
+
+	grade, blocking, err := a.evaluateSubmission(r.Context(), partial)
+	if err != nil {
+		writeAPIError(w, "failed to grade partial submission", http.StatusInternalServerError)
+		return
+	}
+	partial.Grade = grade
+	partial.BlockingIssues = blocking
+	partial = a.stampContentHash(partial)
+
+	if err := a.Store.CreateSubmission(r.Context(), partial); err != nil {
+		if errors.Is(err, store.ErrLocked) {
+			writeAPIError(w, "submission is locked and cannot be partially resubmitted", http.StatusConflict)
+			return
+		}
+		writeAPIError(w, "failed to create partial submission", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "submission", partial.ID, prr.AuditActionCreate, original, partial)
+	a.refreshServiceReadiness(r.Context(), partial.ServiceID)
+
+	if svc, err := a.Store.GetService(r.Context(), partial.ServiceID); err == nil {
+		a.dispatchWebhookEvent(r.Context(), prr.WebhookEventSubmissionAmended, svc, partial)
+		if partial.Grade == prr.GradeRed {
+			a.dispatchWebhookEvent(r.Context(), prr.WebhookEventReadinessDegraded, svc, partial)
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, partial)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestSubmitPartialMergesOverLatestSubmission(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers: []prr.Answer{
+			{QuestionID: "q-auth", Value: "no"},
+			{QuestionID: "q-oncall", Value: "yes"},
+		},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, want 201, body=%s", rec.Code, rec.Body.String())
+	}
+	var original prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &original)
+
+	partialBody, _ := json.Marshal(partialSubmissionRequest{
+		ServiceID: svc.ID,
+		Answers:   []prr.Answer{{QuestionID: "q-auth", Value: "yes"}},
+		Reason:    "remediated MFA enforcement",
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/partial", bytes.NewReader(partialBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("submit partial status = %d, want 201, body=%s", rec.Code, rec.Body.String())
+	}
+	var partial prr.Submission
+	if err := json.Unmarshal(rec.Body.Bytes(), &partial); err != nil {
+		t.Fatalf("unmarshal partial: %v", err)
+	}
+
+	if !partial.IsPartial {
+		t.Fatalf("partial.IsPartial = false, want true")
+	}
+	if partial.AmendsSubmissionID != original.ID {
+		t.Fatalf("AmendsSubmissionID = %q, want %q", partial.AmendsSubmissionID, original.ID)
+	}
+	if partial.ID == original.ID {
+		t.Fatalf("partial submission should be a new record, not the original")
+	}
+
+	byQuestion := make(map[string]string, len(partial.Answers))
+	for _, ans := range partial.Answers {
+		byQuestion[ans.QuestionID] = ans.Value
+	}
+	if byQuestion["q-auth"] != "yes" {
+		t.Fatalf("q-auth = %q, want yes (resubmitted)", byQuestion["q-auth"])
+	}
+	if byQuestion["q-oncall"] != "yes" {
+		t.Fatalf("q-oncall = %q, want yes (carried over unchanged)", byQuestion["q-oncall"])
+	}
+
+	refetched, err := st.GetSubmission(context.Background(), original.ID)
+	if err != nil {
+		t.Fatalf("get original: %v", err)
+	}
+	for _, ans := range refetched.Answers {
+		if ans.QuestionID == "q-auth" && ans.Value != "no" {
+			t.Fatalf("original submission was mutated: q-auth = %q", ans.Value)
+		}
+	}
+}
+
+func TestSubmitPartialRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID, Answers: []prr.Answer{{QuestionID: "q-auth", Value: "no"}}}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	body, _ := json.Marshal(partialSubmissionRequest{
+		ServiceID: svc.ID,
+		Answers:   []prr.Answer{{QuestionID: "q-auth", Value: "yes"}},
+		Reason:    "remediated MFA enforcement",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/prr/partial", bytes.NewReader(body))
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSubmitPartialRequiresExistingSubmission(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	body, _ := json.Marshal(partialSubmissionRequest{
+		ServiceID: "no-such-service",
+		Answers:   []prr.Answer{{QuestionID: "q-auth", Value: "yes"}},
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/partial", bytes.NewReader(body)))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
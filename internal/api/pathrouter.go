@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type pathParamsKey struct{}
+
+// pathParam returns the value matched for a "{name}" segment in the
+// pathRouter pattern that served r, or "" if none matched (e.g. the
+// request fell through to the legacy query-parameter mux instead).
+func pathParam(r *http.Request, name string) string {
+	params, _ := r.Context().Value(pathParamsKey{}).(map[string]string)
+	return params[name]
+}
+
+// pathRoute is one registered method+pattern pair. A pattern segment
+// wrapped in braces, like "{id}", matches any single path segment and
+// is captured under that name.
+type pathRoute struct {
+	method   string
+	segments []string
+	handler  http.HandlerFunc
+}
+
+// pathRouter is a minimal path-parameter router for the RESTful routes
+// (e.g. "/services/{id}") this API is migrating to, alongside the
+// older query-parameter routes it's replacing. It intentionally
+// supports only exact-length patterns with single-segment wildcards:
+// enough for this API's URL shapes without pulling in a routing
+// library.
+type pathRouter struct {
+	routes []pathRoute
+}
+
+func newPathRouter() *pathRouter {
+	return &pathRouter{}
+}
+
+// Handle registers handler for method and pattern, e.g.
+// pr.Handle(http.MethodDelete, "/services/{id}", h).
+func (pr *pathRouter) Handle(method, pattern string, handler http.HandlerFunc) {
+	pr.routes = append(pr.routes, pathRoute{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+// match finds the route for r, returning its handler and the
+// extracted path parameters, or ok=false if none of the registered
+// routes apply.
+func (pr *pathRouter) match(r *http.Request) (handler http.HandlerFunc, params map[string]string, ok bool) {
+	requestSegments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	for _, route := range pr.routes {
+		if route.method != r.Method || len(route.segments) != len(requestSegments) {
+			continue
+		}
+		candidate := make(map[string]string)
+		matched := true
+		for i, seg := range route.segments {
+			if name, isParam := strings.CutPrefix(seg, "{"); isParam {
+				candidate[strings.TrimSuffix(name, "}")] = requestSegments[i]
+				continue
+			}
+			if seg != requestSegments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return route.handler, candidate, true
+		}
+	}
+	return nil, nil, false
+}
+
+// Wrap returns a handler that serves a matching route directly, or
+// falls through to next for any request this router doesn't
+// recognize — in particular, every deprecated query-parameter route
+// this router has a path-based replacement for. Route patterns are
+// chosen to never collide with an existing literal route on next (see
+// the comment where each is registered in Router).
+func (pr *pathRouter) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler, params, ok := pr.match(r)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		handler(w, r.WithContext(context.WithValue(r.Context(), pathParamsKey{}, params)))
+	})
+}
+
+// deprecated marks next as a deprecated route per RFC 8594, for a
+// query-parameter route kept working as an alias of a newer
+// path-parameter one.
+func deprecated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		next(w, r)
+	}
+}
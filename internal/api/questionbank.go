@@ -0,0 +1,279 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/seed"
+	"github.com/Eambo/vxcvxvc/internal/sheets"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// handleImportQuestionsFromSheet accepts a CSV export of a Google Sheet
+// question bank in the request body and upserts its sections/questions.
+func (a *API) handleImportQuestionsFromSheet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	defer r.Body.Close()
+
+	summary, err := sheets.Import(r.Context(), a.Store, r.Body)
+	if err != nil {
+		writeAPIError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// handleSeedQuestionBank loads the embedded curated default question
+// bank, so a fresh deployment has a usable PRR form without waiting on
+// an import or a question bank git checkout. It's idempotent: upserting
+// by ID means calling it again just rewrites the same sections and
+// questions rather than duplicating them.
+func (a *API) handleSeedQuestionBank(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	summary, err := seed.Load(r.Context(), a.Store)
+	if err != nil {
+		writeAPIError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"sections": summary.Sections, "questions": summary.Questions})
+}
+
+// questionBankDump is the portable JSON shape used for bulk question bank
+// import/export, so an export from one environment can be fed straight
+// back into handleImportQuestions on another.
+type questionBankDump struct {
+	Sections  []prr.Section    `json:"sections"`
+	Questions []questionImport `json:"questions"`
+}
+
+// questionImport is a Question that may reference its section by name
+// instead of ID, for hand-authored import payloads.
+type questionImport struct {
+	ID               string                 `json:"id"`
+	SectionID        string                 `json:"section_id,omitempty"`
+	SectionName      string                 `json:"section_name,omitempty"`
+	Text             string                 `json:"text"`
+	Type             prr.QuestionType       `json:"type,omitempty"`
+	Options          []string               `json:"options,omitempty"`
+	Order            int                    `json:"order,omitempty"`
+	ParentQuestionID string                 `json:"parent_question_id,omitempty"`
+	ShowWhenAnswer   string                 `json:"show_when_answer,omitempty"`
+	Blurb            string                 `json:"blurb,omitempty"`
+	SupportingLink   string                 `json:"supporting_link,omitempty"`
+	Vocabulary       []prr.VocabularyOption `json:"vocabulary,omitempty"`
+}
+
+// handleImportQuestions bulk-upserts sections and questions from a JSON
+// dump, resolving section references by ID when present or by name
+// otherwise, creating new sections as needed.
+func (a *API) handleImportQuestions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	defer r.Body.Close()
+
+	var dump questionBankDump
+	if err := jsonvalidate.Decode(r.Body, &dump); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	byName := make(map[string]string, len(dump.Sections))
+	for _, s := range dump.Sections {
+		if s.ID == "" {
+			writeAPIError(w, "section is missing an id", http.StatusBadRequest)
+			return
+		}
+		if err := a.Store.UpsertSection(r.Context(), s); err != nil {
+			writeAPIError(w, fmt.Sprintf("upsert section %s: %v", s.ID, err), http.StatusInternalServerError)
+			return
+		}
+		byName[s.Name] = s.ID
+	}
+
+	imported := 0
+	for _, qi := range dump.Questions {
+		if qi.ID == "" {
+			writeAPIError(w, "question is missing an id", http.StatusBadRequest)
+			return
+		}
+		if !prr.ValidQuestionType(qi.Type) {
+			writeAPIError(w, fmt.Sprintf("question %s has invalid type %q", qi.ID, qi.Type), http.StatusBadRequest)
+			return
+		}
+		if !prr.ValidVocabulary(qi.Vocabulary) {
+			writeAPIError(w, fmt.Sprintf("question %s has an invalid vocabulary", qi.ID), http.StatusBadRequest)
+			return
+		}
+		sectionID := qi.SectionID
+		if sectionID == "" {
+			sectionID = byName[qi.SectionName]
+		}
+		if sectionID == "" {
+			sectionID = a.IDGen.NewID()
+			section := prr.Section{ID: sectionID, Name: qi.SectionName}
+			if err := a.Store.UpsertSection(r.Context(), section); err != nil {
+				writeAPIError(w, fmt.Sprintf("upsert section for %s: %v", qi.ID, err), http.StatusInternalServerError)
+				return
+			}
+			byName[qi.SectionName] = sectionID
+		}
+
+		q := prr.Question{
+			ID:               qi.ID,
+			SectionID:        sectionID,
+			Text:             qi.Text,
+			Type:             qi.Type,
+			Options:          qi.Options,
+			Order:            qi.Order,
+			ParentQuestionID: qi.ParentQuestionID,
+			ShowWhenAnswer:   qi.ShowWhenAnswer,
+			Blurb:            qi.Blurb,
+			SupportingLink:   qi.SupportingLink,
+			Vocabulary:       qi.Vocabulary,
+		}
+		before, err := a.Store.GetQuestion(r.Context(), q.ID)
+		action := prr.AuditActionUpdate
+		if err == store.ErrNotFound {
+			action = prr.AuditActionCreate
+		}
+		if err := a.Store.UpsertQuestion(r.Context(), q); err != nil {
+			writeAPIError(w, fmt.Sprintf("upsert question %s: %v", qi.ID, err), http.StatusInternalServerError)
+			return
+		}
+		if action == prr.AuditActionCreate {
+			a.recordAudit(r.Context(), "question", q.ID, action, nil, q)
+		} else {
+			a.recordAudit(r.Context(), "question", q.ID, action, before, q)
+		}
+		imported++
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"sections": len(dump.Sections), "questions": imported})
+}
+
+// handleExportQuestions produces a portable JSON dump of the whole
+// question bank, suitable for re-import into another environment via
+// handleImportQuestions.
+func (a *API) handleExportQuestions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sections, err := a.Store.ListSections(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to list sections", http.StatusInternalServerError)
+		return
+	}
+	sortSections(sections)
+	questions, err := a.Store.ListQuestions(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+		return
+	}
+	sortQuestions(questions)
+
+	dump := questionBankDump{Sections: sections}
+	for _, q := range questions {
+		dump.Questions = append(dump.Questions, questionImport{
+			ID:               q.ID,
+			SectionID:        q.SectionID,
+			Text:             q.Text,
+			Type:             q.Type,
+			Options:          q.Options,
+			Order:            q.Order,
+			ParentQuestionID: q.ParentQuestionID,
+			ShowWhenAnswer:   q.ShowWhenAnswer,
+			Blurb:            q.Blurb,
+			SupportingLink:   q.SupportingLink,
+			Vocabulary:       q.Vocabulary,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, dump)
+}
+
+type reorderQuestionsRequest struct {
+	SectionID   string   `json:"section_id"`
+	QuestionIDs []string `json:"question_ids"`
+}
+
+// handleReorderQuestions assigns each question in QuestionIDs a sequential
+// Order matching its position in the list, so duplicate order values
+// within a section can't arise. The list must name exactly the
+// questions currently in SectionID, with no repeats, so reordering can't
+// silently orphan a question or leave a gap.
+func (a *API) handleReorderQuestions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req reorderQuestionsRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.SectionID == "" {
+		writeAPIError(w, "section_id is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.QuestionIDs) == 0 {
+		writeAPIError(w, "question_ids is required", http.StatusBadRequest)
+		return
+	}
+
+	seen := make(map[string]bool, len(req.QuestionIDs))
+	for _, id := range req.QuestionIDs {
+		if seen[id] {
+			writeAPIError(w, fmt.Sprintf("question_ids contains %s more than once", id), http.StatusBadRequest)
+			return
+		}
+		seen[id] = true
+	}
+
+	all, err := a.Store.ListQuestions(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+		return
+	}
+	inSection := make(map[string]prr.Question)
+	for _, q := range all {
+		if q.SectionID == req.SectionID {
+			inSection[q.ID] = q
+		}
+	}
+	if len(inSection) != len(req.QuestionIDs) {
+		writeAPIError(w, "question_ids must name exactly the questions in section_id", http.StatusBadRequest)
+		return
+	}
+	for _, id := range req.QuestionIDs {
+		if _, ok := inSection[id]; !ok {
+			writeAPIError(w, fmt.Sprintf("question %s is not in section %s", id, req.SectionID), http.StatusBadRequest)
+			return
+		}
+	}
+
+	for order, id := range req.QuestionIDs {
+		q := inSection[id]
+		q.Order = order
+		if err := a.Store.UpsertQuestion(r.Context(), q); err != nil {
+			writeAPIError(w, fmt.Sprintf("failed to reorder question %s: %v", id, err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{"reordered": len(req.QuestionIDs)})
+}
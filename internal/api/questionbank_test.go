@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestImportExportQuestions(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	importBody, _ := json.Marshal(map[string]interface{}{
+		"sections": []prr.Section{{ID: "sec-1", Name: "Security"}},
+		"questions": []map[string]string{
+			{"id": "q1", "section_id": "sec-1", "text": "Is auth enforced?"},
+			{"id": "q2", "section_name": "Reliability", "text": "Is there an on-call rotation?"},
+		},
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/import", bytes.NewReader(importBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/questions/export", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("export status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var dump questionBankDump
+	if err := json.Unmarshal(rec.Body.Bytes(), &dump); err != nil {
+		t.Fatalf("unmarshal export: %v", err)
+	}
+	if len(dump.Sections) != 2 || len(dump.Questions) != 2 {
+		t.Fatalf("export = %+v", dump)
+	}
+}
+
+func TestImportQuestionsWithVocabulary(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	importBody, _ := json.Marshal(questionBankDump{
+		Sections: []prr.Section{{ID: "sec-1", Name: "Security"}},
+		Questions: []questionImport{{
+			ID: "q1", SectionID: "sec-1", Text: "How well is auth enforced?",
+			Vocabulary: []prr.VocabularyOption{
+				{Value: "full", Label: "Fully enforced", Points: 1, CountsTowardTotal: true},
+				{Value: "exempt", Label: "Not applicable", CountsTowardTotal: false},
+			},
+		}},
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/import", bytes.NewReader(importBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	q, err := st.GetQuestion(context.Background(), "q1")
+	if err != nil {
+		t.Fatalf("get question: %v", err)
+	}
+	if len(q.Vocabulary) != 2 || q.Vocabulary[0].Value != "full" {
+		t.Fatalf("question vocabulary = %+v", q.Vocabulary)
+	}
+}
+
+func TestImportQuestionsRejectsInvalidVocabulary(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	importBody, _ := json.Marshal(questionBankDump{
+		Sections: []prr.Section{{ID: "sec-1", Name: "Security"}},
+		Questions: []questionImport{{
+			ID: "q1", SectionID: "sec-1", Text: "How well is auth enforced?",
+			Vocabulary: []prr.VocabularyOption{{Value: "full", Points: 5}},
+		}},
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/import", bytes.NewReader(importBody)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestReorderQuestions(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	importBody, _ := json.Marshal(map[string]interface{}{
+		"sections": []prr.Section{{ID: "sec-1", Name: "Security"}},
+		"questions": []map[string]string{
+			{"id": "q1", "section_id": "sec-1", "text": "first?"},
+			{"id": "q2", "section_id": "sec-1", "text": "second?"},
+		},
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/import", bytes.NewReader(importBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	reorderBody, _ := json.Marshal(reorderQuestionsRequest{SectionID: "sec-1", QuestionIDs: []string{"q2", "q1"}})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/reorder", bytes.NewReader(reorderBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("reorder status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/questions/export", nil))
+	var dump questionBankDump
+	if err := json.Unmarshal(rec.Body.Bytes(), &dump); err != nil {
+		t.Fatal(err)
+	}
+	if len(dump.Questions) != 2 || dump.Questions[0].ID != "q2" || dump.Questions[1].ID != "q1" {
+		t.Fatalf("Questions = %+v, want q2 before q1 after reorder", dump.Questions)
+	}
+}
+
+func TestReorderQuestionsRejectsMismatchedSet(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	importBody, _ := json.Marshal(map[string]interface{}{
+		"sections": []prr.Section{{ID: "sec-1", Name: "Security"}},
+		"questions": []map[string]string{
+			{"id": "q1", "section_id": "sec-1", "text": "first?"},
+			{"id": "q2", "section_id": "sec-1", "text": "second?"},
+		},
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/import", bytes.NewReader(importBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("import status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	reorderBody, _ := json.Marshal(reorderQuestionsRequest{SectionID: "sec-1", QuestionIDs: []string{"q1"}})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/reorder", bytes.NewReader(reorderBody)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// handleQuestions lists questions, hiding archived ones unless
+// include_inactive=true is set, or soft-deletes one identified by
+// ?id=... on DELETE. The list is served with an ETag and honors
+// If-None-Match, since it's fetched on every form load but rarely
+// changes.
+func (a *API) handleQuestions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		questions, err := a.Store.ListQuestions(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Query().Get("include_inactive") != "true" {
+			visible := make([]prr.Question, 0, len(questions))
+			for _, q := range questions {
+				if !q.Archived {
+					visible = append(visible, q)
+				}
+			}
+			questions = visible
+		}
+		sortQuestions(questions)
+		writeJSONWithETag(w, r, questions)
+	case http.MethodDelete:
+		a.handleDeleteQuestion(w, r, r.URL.Query().Get("id"))
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleDeleteQuestion archives the question identified by id. This is a
+// soft delete: the question is hidden from default listings and
+// rejected on new submissions, but its wording and historical answers
+// are retained rather than deleted.
+func (a *API) handleDeleteQuestion(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	before, err := a.Store.GetQuestion(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "question")
+		return
+	}
+	q := before
+	q.Archived = true
+	if err := a.Store.UpsertQuestion(r.Context(), q); err != nil {
+		writeAPIError(w, "failed to archive question", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "question", q.ID, prr.AuditActionDelete, before, q)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRestoreQuestion un-archives the question identified by id, making
+// it visible again in default listings and answerable on new submissions.
+func (a *API) handleRestoreQuestion(w http.ResponseWriter, r *http.Request, id string) {
+	before, err := a.Store.GetQuestion(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "question")
+		return
+	}
+	q := before
+	q.Archived = false
+	if err := a.Store.UpsertQuestion(r.Context(), q); err != nil {
+		writeAPIError(w, "failed to restore question", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "question", q.ID, prr.AuditActionUpdate, before, q)
+	writeJSON(w, http.StatusOK, q)
+}
+
+type moveQuestionRequest struct {
+	SectionID string `json:"section_id"`
+}
+
+// handleMoveQuestion reassigns the question identified by id to another
+// section. It's refused with a 409 listing every blocker (see
+// questionDependencyConflicts) if moving it would corrupt a conditional
+// follow-up question that depends on it as a parent, a template that
+// references it, or a draft submission that has already answered it,
+// rather than silently leaving those relationships dangling.
+func (a *API) handleMoveQuestion(w http.ResponseWriter, r *http.Request, id string) {
+	var req moveQuestionRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.SectionID == "" {
+		writeAPIError(w, "section_id is required", http.StatusBadRequest)
+		return
+	}
+
+	before, err := a.Store.GetQuestion(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "question")
+		return
+	}
+	if before.SectionID == req.SectionID {
+		writeJSON(w, http.StatusOK, before)
+		return
+	}
+	if _, err := a.Store.GetSection(r.Context(), req.SectionID); err != nil {
+		writeStoreError(w, err, "section")
+		return
+	}
+
+	conflicts, err := a.questionDependencyConflicts(r.Context(), map[string]bool{id: true}, "")
+	if err != nil {
+		writeAPIError(w, "failed to check question dependencies", http.StatusInternalServerError)
+		return
+	}
+	if len(conflicts) > 0 {
+		writeDependencyConflicts(w, conflicts)
+		return
+	}
+
+	q := before
+	q.SectionID = req.SectionID
+	if err := a.Store.UpsertQuestion(r.Context(), q); err != nil {
+		writeAPIError(w, "failed to move question", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "question", q.ID, prr.AuditActionUpdate, before, q)
+	writeJSON(w, http.StatusOK, q)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestDeleteQuestionArchivesAndHidesFromListing(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	importBody, _ := json.Marshal(map[string]interface{}{
+		"sections":  []prr.Section{{ID: "sec-1", Name: "Security"}},
+		"questions": []map[string]string{{"id": "q1", "section_id": "sec-1", "text": "Is auth enforced?"}},
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/admin/questions/import", bytes.NewReader(importBody)))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/questions?id=q1", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/questions", nil))
+	var listed []prr.Question
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	for _, q := range listed {
+		if q.ID == "q1" {
+			t.Fatalf("archived question still visible in default listing")
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/questions?include_inactive=true", nil))
+	listed = nil
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	found := false
+	for _, q := range listed {
+		if q.ID == "q1" && q.Archived {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("archived question not returned with include_inactive=true")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/questions/q1/restore", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("restore status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var restored prr.Question
+	json.Unmarshal(rec.Body.Bytes(), &restored)
+	if restored.Archived {
+		t.Fatalf("restored question still marked archived")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/questions", nil))
+	listed = nil
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	found = false
+	for _, q := range listed {
+		if q.ID == "q1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("restored question missing from default listing")
+	}
+}
+
+func TestSubmissionRejectsAnswerToArchivedQuestion(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	importBody, _ := json.Marshal(map[string]interface{}{
+		"sections":  []prr.Section{{ID: "sec-1", Name: "Security"}},
+		"questions": []map[string]string{{"id": "q1", "section_id": "sec-1", "text": "Is auth enforced?"}},
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/admin/questions/import", bytes.NewReader(importBody)))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/admin/questions?id=q1", nil))
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionDraft,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("submission status = %d, want 422, body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenBucket is a classic token-bucket rate limiter: it holds up to
+// burst tokens, refilling at rate tokens per second, and denies a
+// request when it has none left.
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	rate     float64
+	burst    float64
+	lastSeen time.Time
+}
+
+func (b *tokenBucket) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*b.rate)
+	b.lastSeen = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// bucketSweepInterval bounds how often rateLimiter.allow scans buckets
+// for eviction, so a high request rate doesn't turn the sweep itself
+// into an O(n) cost per request.
+const bucketSweepInterval = time.Minute
+
+// bucketStaleFactor is how many bucket lifetimes (the time to refill an
+// empty bucket to full) of inactivity a bucket tolerates before it's
+// evicted as stale.
+const bucketStaleFactor = 10
+
+// rateLimiter hands out a token bucket per client key, so one noisy
+// client can't exhaust the budget of another. Clients are frequently
+// identified by source IP or a rotating token, so buckets that go quiet
+// are swept out periodically to keep the map from growing without
+// bound for the lifetime of the process.
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	rate      float64
+	burst     float64
+	lastSweep time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{buckets: make(map[string]*tokenBucket), rate: rate, burst: float64(burst)}
+}
+
+func (l *rateLimiter) allow(key string, now time.Time) bool {
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, rate: l.rate, burst: l.burst, lastSeen: now}
+		l.buckets[key] = b
+	}
+	l.sweepStaleBucketsLocked(now)
+	l.mu.Unlock()
+	return b.allow(now)
+}
+
+// sweepStaleBucketsLocked evicts buckets that haven't been used in
+// bucketStaleFactor lifetimes, at most once per bucketSweepInterval.
+// Callers must hold l.mu.
+func (l *rateLimiter) sweepStaleBucketsLocked(now time.Time) {
+	if now.Sub(l.lastSweep) < bucketSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	maxIdle := time.Duration(l.burst/l.rate*float64(time.Second)) * bucketStaleFactor
+	for key, b := range l.buckets {
+		b.mu.Lock()
+		idle := now.Sub(b.lastSeen)
+		b.mu.Unlock()
+		if idle > maxIdle {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// retryAfterSeconds is the value to advertise in a 429's Retry-After
+// header: how long a client must wait for the bucket to produce
+// another token.
+func (l *rateLimiter) retryAfterSeconds() int {
+	return int(math.Ceil(1 / l.rate))
+}
+
+// clientKey identifies the caller a rate limit bucket should be keyed
+// by: the bearer token if one was presented, since that identifies the
+// client precisely even behind a shared NAT/proxy, falling back to the
+// request's source IP for unauthenticated requests.
+func clientKey(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && token != "" {
+		return token
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// withRateLimit rejects requests beyond limiter's per-client rate with
+// 429 and a Retry-After header. A nil limiter (the default, when
+// RateLimitPerSecond is unset) disables rate limiting entirely.
+func (a *API) withRateLimit(limiter *rateLimiter, next http.Handler) http.Handler {
+	if limiter == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.allow(clientKey(r), a.Clock.Now()) {
+			w.Header().Set("Retry-After", strconv.Itoa(limiter.retryAfterSeconds()))
+			writeAPIError(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withMaxRequestBodySize rejects POST/PUT requests whose declared
+// Content-Length exceeds MaxRequestBodySizeBytes with 413, and caps
+// the body reader for requests without a Content-Length (e.g.
+// chunked transfer) at the same limit as a backstop. Zero disables
+// the limit.
+func (a *API) withMaxRequestBodySize(next http.Handler) http.Handler {
+	if a.MaxRequestBodySizeBytes <= 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			if r.ContentLength > a.MaxRequestBodySizeBytes {
+				writeAPIError(w, fmt.Sprintf("request body exceeds %d bytes", a.MaxRequestBodySizeBytes), http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, a.MaxRequestBodySizeBytes)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
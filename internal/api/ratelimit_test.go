@@ -0,0 +1,102 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestRateLimitExceeded(t *testing.T) {
+	a := New(memory.New(), prr.FixedClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.RateLimitPerSecond = 1
+	a.RateLimitBurst = 1
+	router := a.Router()
+
+	req := func() *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/services", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		return r
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req())
+	if rec.Code != http.StatusOK {
+		t.Fatalf("first request status = %d, want 200", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req())
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("second request status = %d, want 429", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatal("expected a Retry-After header on a 429 response")
+	}
+}
+
+func TestRateLimitKeyedPerClient(t *testing.T) {
+	a := New(memory.New(), prr.FixedClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.RateLimitPerSecond = 1
+	a.RateLimitBurst = 1
+	router := a.Router()
+
+	for _, ip := range []string{"10.0.0.1:1", "10.0.0.2:1"} {
+		r := httptest.NewRequest(http.MethodGet, "/services", nil)
+		r.RemoteAddr = ip
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, r)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request from %s status = %d, want 200", ip, rec.Code)
+		}
+	}
+}
+
+func TestRateLimiterSweepsStaleBuckets(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	start := time.Unix(0, 0)
+	l.allow("stale-client", start)
+	if len(l.buckets) != 1 {
+		t.Fatalf("len(buckets) = %d, want 1", len(l.buckets))
+	}
+
+	// Advance past both the sweep interval and the bucket's stale
+	// threshold, and let a different client's request trigger the sweep.
+	later := start.Add(bucketSweepInterval * (bucketStaleFactor + 1))
+	l.allow("active-client", later)
+
+	l.mu.Lock()
+	_, staleStillPresent := l.buckets["stale-client"]
+	_, activePresent := l.buckets["active-client"]
+	n := len(l.buckets)
+	l.mu.Unlock()
+
+	if staleStillPresent {
+		t.Fatal("expected stale-client's bucket to be evicted")
+	}
+	if !activePresent {
+		t.Fatal("expected active-client's bucket to remain")
+	}
+	if n != 1 {
+		t.Fatalf("len(buckets) = %d, want 1", n)
+	}
+}
+
+func TestMaxRequestBodySizeRejectsLargeBody(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.MaxRequestBodySizeBytes = 10
+	router := a.Router()
+
+	body := bytes.Repeat([]byte("x"), 100)
+	req := httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", rec.Code)
+	}
+}
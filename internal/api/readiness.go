@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// refreshServiceReadiness best-effort recomputes and stores the
+// service_readiness summary for serviceID from its current latest
+// submission. It's called after any write that can change what
+// "latest" means for a service (a new submission, a finalized draft, an
+// approval or rejection) so dashboard and search reads can look the
+// summary up directly instead of re-listing and re-scoring every
+// submission to find the newest one. A failure is logged but never
+// fails the request that triggered it, consistent with this API's other
+// best-effort recording (e.g. recordAudit, dispatchWebhookEvent). A
+// service with no submissions has no summary to maintain and is left
+// alone.
+func (a *API) refreshServiceReadiness(ctx context.Context, serviceID string) {
+	readiness, err := a.computeServiceReadiness(ctx, serviceID)
+	if err != nil {
+		if err != store.ErrNotFound && a.Logger != nil {
+			a.Logger.Printf("failed to refresh service readiness for %s: %v", serviceID, err)
+		}
+		return
+	}
+	if err := a.Store.UpsertServiceReadiness(ctx, readiness); err != nil && a.Logger != nil {
+		a.Logger.Printf("failed to store service readiness for %s: %v", serviceID, err)
+	}
+}
+
+// computeServiceReadiness scores serviceID's current latest submission
+// into a prr.ServiceReadiness, without storing it. It returns
+// store.ErrNotFound if the service has never submitted.
+func (a *API) computeServiceReadiness(ctx context.Context, serviceID string) (prr.ServiceReadiness, error) {
+	latest, err := a.latestSubmission(ctx, serviceID)
+	if err != nil {
+		return prr.ServiceReadiness{}, err
+	}
+
+	scoped, questions, err := a.scopeSubmissionToTemplate(ctx, latest)
+	if err != nil {
+		return prr.ServiceReadiness{}, err
+	}
+	score := prr.ComputeScore(scoped, questions)
+
+	return prr.ServiceReadiness{
+		ServiceID:      serviceID,
+		SubmissionID:   latest.ID,
+		Percent:        score.Percent,
+		Grade:          latest.Grade,
+		Approval:       latest.Approval,
+		BlockingIssues: latest.BlockingIssues,
+		SubmittedAt:    latest.CreatedAt,
+		UpdatedAt:      a.Clock.Now(),
+	}, nil
+}
+
+// getServiceReadiness returns the materialized service_readiness summary
+// for serviceID, computing and backfilling it on the fly (see
+// computeServiceReadiness) if nothing has been recorded yet - e.g. for a
+// submission written directly to the store rather than through this
+// API, or for a deployment upgraded before any submission triggered
+// refreshServiceReadiness. It returns store.ErrNotFound if the service
+// has never submitted.
+func (a *API) getServiceReadiness(ctx context.Context, serviceID string) (prr.ServiceReadiness, error) {
+	readiness, err := a.Store.GetServiceReadiness(ctx, serviceID)
+	if err == nil {
+		return readiness, nil
+	}
+	if err != store.ErrNotFound {
+		return prr.ServiceReadiness{}, err
+	}
+
+	readiness, err = a.computeServiceReadiness(ctx, serviceID)
+	if err != nil {
+		return prr.ServiceReadiness{}, err
+	}
+	if err := a.Store.UpsertServiceReadiness(ctx, readiness); err != nil && a.Logger != nil {
+		a.Logger.Printf("failed to backfill service readiness for %s: %v", serviceID, err)
+	}
+	return readiness, nil
+}
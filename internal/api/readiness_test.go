@@ -0,0 +1,122 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestSubmissionCreationRefreshesServiceReadiness(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1", Weight: 1}); err != nil {
+		t.Fatal(err)
+	}
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+
+	readiness, err := st.GetServiceReadiness(ctx, svc.ID)
+	if err != nil {
+		t.Fatalf("GetServiceReadiness: %v", err)
+	}
+	if readiness.SubmissionID != sub.ID {
+		t.Errorf("SubmissionID = %q, want %q", readiness.SubmissionID, sub.ID)
+	}
+	if readiness.Grade != sub.Grade {
+		t.Errorf("Grade = %q, want %q", readiness.Grade, sub.Grade)
+	}
+	if readiness.Percent != 100 {
+		t.Errorf("Percent = %v, want 100", readiness.Percent)
+	}
+	if readiness.Approval != prr.ApprovalPending {
+		t.Errorf("Approval = %q, want pending", readiness.Approval)
+	}
+
+	// Approving the submission should refresh the summary's Approval
+	// without changing which submission is "latest".
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/approve", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("approve status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	readiness, err = st.GetServiceReadiness(ctx, svc.ID)
+	if err != nil {
+		t.Fatalf("GetServiceReadiness after approval: %v", err)
+	}
+	if readiness.Approval != prr.ApprovalApproved {
+		t.Errorf("Approval = %q, want approved", readiness.Approval)
+	}
+	if readiness.SubmissionID != sub.ID {
+		t.Errorf("SubmissionID after approval = %q, want %q", readiness.SubmissionID, sub.ID)
+	}
+}
+
+func TestGetServiceReadinessBackfillsWhenMissing(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1", Weight: 1}); err != nil {
+		t.Fatal(err)
+	}
+	svc := prr.Service{ID: "svc-1", Name: "payments"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	sub := prr.Submission{
+		ID:        "sub-1",
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionFinal,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+		Grade:     prr.GradeGreen,
+	}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := st.GetServiceReadiness(ctx, svc.ID); err != store.ErrNotFound {
+		t.Fatalf("expected no readiness summary yet, got %v", err)
+	}
+
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	readiness, err := a.getServiceReadiness(ctx, svc.ID)
+	if err != nil {
+		t.Fatalf("getServiceReadiness: %v", err)
+	}
+	if readiness.SubmissionID != sub.ID {
+		t.Errorf("SubmissionID = %q, want %q", readiness.SubmissionID, sub.ID)
+	}
+
+	stored, err := st.GetServiceReadiness(ctx, svc.ID)
+	if err != nil {
+		t.Fatalf("readiness was not backfilled into the store: %v", err)
+	}
+	if stored.SubmissionID != sub.ID {
+		t.Errorf("backfilled SubmissionID = %q, want %q", stored.SubmissionID, sub.ID)
+	}
+}
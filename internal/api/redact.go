@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+)
+
+// restrictedFields are JSON object keys stripped from every response
+// served to a auth.RoleRestricted principal, wherever they appear in
+// the response body. They hold free-text detail (an answer's
+// explanatory comment, its supporting evidence links) rather than the
+// scores and statuses a restricted viewer is meant to see.
+var restrictedFields = map[string]bool{
+	"comment":        true,
+	"evidence_links": true,
+}
+
+// redactingResponseWriter buffers a handler's response instead of
+// streaming it, so withFieldRedaction can inspect and rewrite the body
+// once the handler is done with it.
+type redactingResponseWriter struct {
+	http.ResponseWriter
+	status      int
+	buf         bytes.Buffer
+	wroteHeader bool
+}
+
+func (w *redactingResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+}
+
+func (w *redactingResponseWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+// withFieldRedaction wraps next so that JSON responses served to a
+// restricted principal (see auth.RoleRestricted) have restrictedFields
+// stripped before they reach the client. Enforcing this here, on the
+// way out, means every handler gets it for free instead of each one
+// having to remember to filter what it returns. Requests from any
+// other (or no) principal pass through unbuffered.
+// GET /events is exempt: it's a long-lived stream rather than a single
+// JSON response, so it can't be buffered and rewritten the way every
+// other handler's response is here. handleEvents redacts each published
+// event for a restricted principal itself instead.
+func (a *API) withFieldRedaction(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.FromContext(r.Context())
+		if r.URL.Path == "/events" || !ok || principal.Role != auth.RoleRestricted {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rw := &redactingResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rw, r)
+
+		body := rw.buf.Bytes()
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+			body = redactJSON(body)
+		}
+		w.WriteHeader(rw.status)
+		w.Write(body)
+	})
+}
+
+// redactJSON removes restrictedFields from body at any depth. Malformed
+// or non-object/array JSON (e.g. a bare string or number response) is
+// returned unchanged.
+func redactJSON(body []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	redactValue(v)
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+func redactValue(v interface{}) {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if restrictedFields[k] {
+				delete(val, k)
+				continue
+			}
+			redactValue(child)
+		}
+	case []interface{}:
+		for _, item := range val {
+			redactValue(item)
+		}
+	}
+}
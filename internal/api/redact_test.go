@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestFieldRedactionStripsCommentsAndEvidenceForRestrictedRole(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	a.Tokens = auth.TokenStore{
+		"restricted-tok": {Subject: "viewer", Role: auth.RoleRestricted},
+		"admin-tok":      {Subject: "root", Role: auth.RoleAdmin},
+	}
+	router := a.Router()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "payments"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{
+		ID:        "sub-1",
+		ServiceID: "svc-1",
+		Status:    prr.SubmissionFinal,
+		Approval:  prr.ApprovalPending,
+		Grade:     prr.GradeGreen,
+		Answers: []prr.Answer{{
+			QuestionID:    "q1",
+			Value:         "no",
+			Comment:       "we don't have MFA enforced yet",
+			EvidenceLinks: []string{"https://runbooks.example.com/auth"},
+		}},
+	}); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	get := func(token string) prr.Submission {
+		req := httptest.NewRequest(http.MethodGet, "/submissions/sub-1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+		}
+		var sub prr.Submission
+		if err := json.Unmarshal(rec.Body.Bytes(), &sub); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		return sub
+	}
+
+	admin := get("admin-tok")
+	if admin.Answers[0].Comment == "" || len(admin.Answers[0].EvidenceLinks) == 0 {
+		t.Fatalf("admin view unexpectedly redacted: %+v", admin.Answers[0])
+	}
+	if admin.Grade != prr.GradeGreen {
+		t.Fatalf("admin view missing grade: %+v", admin)
+	}
+
+	restricted := get("restricted-tok")
+	if restricted.Answers[0].Comment != "" {
+		t.Fatalf("comment not redacted for restricted role: %+v", restricted.Answers[0])
+	}
+	if len(restricted.Answers[0].EvidenceLinks) != 0 {
+		t.Fatalf("evidence links not redacted for restricted role: %+v", restricted.Answers[0])
+	}
+	if restricted.Grade != prr.GradeGreen {
+		t.Fatalf("score/grade unexpectedly redacted: %+v", restricted)
+	}
+}
@@ -0,0 +1,382 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/openapi"
+	"github.com/Eambo/vxcvxvc/internal/webui"
+)
+
+// Router builds the HTTP mux for the PRR API.
+func (a *API) Router() http.Handler {
+	top := http.NewServeMux()
+	if ui, err := webui.Handler("/ui/"); err == nil {
+		top.Handle("/ui/", ui)
+	} else if a.Logger != nil {
+		a.Logger.Printf("failed to mount admin UI: %v", err)
+	}
+	top.HandleFunc("/openapi.json", openapi.SpecHandler())
+	top.Handle("/docs/", http.StripPrefix("/docs/", openapi.DocsHandler()))
+	top.HandleFunc("/healthz", a.handleHealthz)
+	top.HandleFunc("/readyz", a.handleReadyz)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/services", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			a.handleCreateService(w, r)
+		case http.MethodGet:
+			a.handleListServices(w, r)
+		case http.MethodPut:
+			a.handleUpdateService(w, r)
+		case http.MethodDelete:
+			deprecated(func(w http.ResponseWriter, r *http.Request) {
+				a.handleDeleteService(w, r, r.URL.Query().Get("id"))
+			})(w, r)
+		default:
+			writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/services/overdue", a.handleListOverdueServices)
+	mux.HandleFunc("/services/", func(w http.ResponseWriter, r *http.Request) {
+		rest := strings.TrimPrefix(r.URL.Path, "/services/")
+		if id, ok := strings.CutSuffix(rest, "/submissions"); ok {
+			if r.Method != http.MethodGet {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			a.handleListSubmissionHistory(w, r, id)
+			return
+		}
+		if id, ok := strings.CutSuffix(rest, "/restore"); ok {
+			if r.Method != http.MethodPost {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			a.handleRestoreService(w, r, id)
+			return
+		}
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("/submissions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		a.handleCreateSubmission(w, r)
+	})
+	mux.HandleFunc("/submissions/", func(w http.ResponseWriter, r *http.Request) {
+		rest := strings.TrimPrefix(r.URL.Path, "/submissions/")
+		if id, ok := strings.CutSuffix(rest, "/gate"); ok {
+			if r.Method != http.MethodGet {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			a.handleEvaluateGate(w, r, id)
+			return
+		}
+		if id, ok := strings.CutSuffix(rest, "/score/explain"); ok {
+			if r.Method != http.MethodGet {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			a.handleExplainScore(w, r, id)
+			return
+		}
+		if id, ok := strings.CutSuffix(rest, "/finalize"); ok {
+			if r.Method != http.MethodPost {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			a.handleFinalizeSubmission(w, r, id)
+			return
+		}
+		if id, ok := strings.CutSuffix(rest, "/approve"); ok {
+			if r.Method != http.MethodPost {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			approve := a.handleApproveSubmission
+			if len(a.Tokens) > 0 {
+				auth.RequireRole(auth.RoleReviewer, func(w http.ResponseWriter, r *http.Request) {
+					approve(w, r, id)
+				})(w, r)
+				return
+			}
+			approve(w, r, id)
+			return
+		}
+		if id, ok := strings.CutSuffix(rest, "/reject"); ok {
+			if r.Method != http.MethodPost {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			reject := a.handleRejectSubmission
+			if len(a.Tokens) > 0 {
+				auth.RequireRole(auth.RoleReviewer, func(w http.ResponseWriter, r *http.Request) {
+					reject(w, r, id)
+				})(w, r)
+				return
+			}
+			reject(w, r, id)
+			return
+		}
+		if id, ok := strings.CutSuffix(rest, "/unlock"); ok {
+			if r.Method != http.MethodPost {
+				writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			unlock := a.handleUnlockSubmission
+			if len(a.Tokens) > 0 {
+				auth.RequireRole(auth.RoleAdmin, func(w http.ResponseWriter, r *http.Request) {
+					unlock(w, r, id)
+				})(w, r)
+				return
+			}
+			unlock(w, r, id)
+			return
+		}
+
+		if rest == "" {
+			writeAPIError(w, "missing submission id", http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			a.handleGetSubmission(w, r, rest)
+		case http.MethodPut:
+			a.handleUpdateSubmission(w, r, rest)
+		default:
+			writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	importSheet := a.handleImportQuestionsFromSheet
+	publishConfluence := a.handlePublishConfluence
+	importQuestions := a.handleImportQuestions
+	seedQuestionBank := a.handleSeedQuestionBank
+	exportQuestions := a.handleExportQuestions
+	reorderQuestions := a.handleReorderQuestions
+	bulkImportSubmissions := a.handleBulkImportSubmissions
+	backup := a.handleBackup
+	restore := a.handleRestore
+	scoringConfig := a.handleScoringConfig
+	expiryConfig := a.handleExpiryConfig
+	jiraConfig := a.handleJiraConfig
+	auditLog := a.handleAuditLog
+	sections := a.handleSections
+	templates := a.handleTemplates
+	webhooks := a.handleWebhooks
+	teams := a.handleTeams
+	tenants := a.handleTenants
+	questions := a.handleQuestions
+	deleteSection := func(w http.ResponseWriter, r *http.Request) {
+		a.handleDeleteSection(w, r, pathParam(r, "id"))
+	}
+	deleteTemplate := func(w http.ResponseWriter, r *http.Request) {
+		a.handleDeleteTemplate(w, r, pathParam(r, "id"))
+	}
+	deleteWebhook := func(w http.ResponseWriter, r *http.Request) {
+		a.handleDeleteWebhook(w, r, pathParam(r, "id"))
+	}
+	deleteTeam := func(w http.ResponseWriter, r *http.Request) {
+		a.handleDeleteTeam(w, r, pathParam(r, "id"))
+	}
+	deleteTenant := func(w http.ResponseWriter, r *http.Request) {
+		a.handleDeleteTenant(w, r, pathParam(r, "id"))
+	}
+	teamReadiness := func(w http.ResponseWriter, r *http.Request) {
+		a.handleTeamReadiness(w, r, pathParam(r, "id"))
+	}
+	restoreQuestion := func(w http.ResponseWriter, r *http.Request) {
+		a.handleRestoreQuestion(w, r, pathParam(r, "id"))
+	}
+	moveQuestion := func(w http.ResponseWriter, r *http.Request) {
+		a.handleMoveQuestion(w, r, pathParam(r, "id"))
+	}
+	questionTranslation := func(w http.ResponseWriter, r *http.Request) {
+		a.handleQuestionTranslation(w, r, pathParam(r, "id"), pathParam(r, "locale"))
+	}
+	sectionTranslation := func(w http.ResponseWriter, r *http.Request) {
+		a.handleSectionTranslation(w, r, pathParam(r, "id"), pathParam(r, "locale"))
+	}
+	if len(a.Tokens) > 0 {
+		importSheet = auth.RequireRole(auth.RoleAdmin, importSheet)
+		publishConfluence = auth.RequireRole(auth.RoleAdmin, publishConfluence)
+		importQuestions = auth.RequireRole(auth.RoleAdmin, importQuestions)
+		seedQuestionBank = auth.RequireRole(auth.RoleAdmin, seedQuestionBank)
+		exportQuestions = auth.RequireRole(auth.RoleAdmin, exportQuestions)
+		reorderQuestions = auth.RequireRole(auth.RoleAdmin, reorderQuestions)
+		bulkImportSubmissions = auth.RequireRole(auth.RoleAdmin, bulkImportSubmissions)
+		backup = auth.RequireRole(auth.RoleAdmin, backup)
+		restore = auth.RequireRole(auth.RoleAdmin, restore)
+		scoringConfig = auth.RequireRole(auth.RoleAdmin, scoringConfig)
+		expiryConfig = auth.RequireRole(auth.RoleAdmin, expiryConfig)
+		jiraConfig = auth.RequireRole(auth.RoleAdmin, jiraConfig)
+		auditLog = auth.RequireRole(auth.RoleAdmin, auditLog)
+		sections = auth.RequireRole(auth.RoleAdmin, sections)
+		templates = auth.RequireRole(auth.RoleAdmin, templates)
+		webhooks = auth.RequireRole(auth.RoleAdmin, webhooks)
+		questions = auth.RequireRole(auth.RoleAdmin, questions)
+		teams = auth.RequireRole(auth.RoleAdmin, teams)
+		tenants = auth.RequireRole(auth.RoleAdmin, tenants)
+		deleteSection = auth.RequireRole(auth.RoleAdmin, deleteSection)
+		deleteTemplate = auth.RequireRole(auth.RoleAdmin, deleteTemplate)
+		deleteWebhook = auth.RequireRole(auth.RoleAdmin, deleteWebhook)
+		deleteTeam = auth.RequireRole(auth.RoleAdmin, deleteTeam)
+		deleteTenant = auth.RequireRole(auth.RoleAdmin, deleteTenant)
+		restoreQuestion = auth.RequireRole(auth.RoleAdmin, restoreQuestion)
+		moveQuestion = auth.RequireRole(auth.RoleAdmin, moveQuestion)
+		questionTranslation = auth.RequireRole(auth.RoleAdmin, questionTranslation)
+		sectionTranslation = auth.RequireRole(auth.RoleAdmin, sectionTranslation)
+	}
+	mux.HandleFunc("/admin/questions", questions)
+	mux.HandleFunc("/admin/questions/import/sheet", importSheet)
+	mux.HandleFunc("/admin/questions/import", importQuestions)
+	mux.HandleFunc("/admin/seed", seedQuestionBank)
+	mux.HandleFunc("/admin/questions/export", exportQuestions)
+	mux.HandleFunc("/admin/questions/reorder", reorderQuestions)
+	mux.HandleFunc("/admin/publish/confluence", publishConfluence)
+	mux.HandleFunc("/admin/backup", backup)
+	mux.HandleFunc("/admin/restore", restore)
+	mux.HandleFunc("/admin/scoring", scoringConfig)
+	mux.HandleFunc("/admin/expiry", expiryConfig)
+	mux.HandleFunc("/admin/integrations/jira", jiraConfig)
+	mux.HandleFunc("/admin/audit", auditLog)
+	mux.HandleFunc("/admin/sections", sections)
+	mux.HandleFunc("/admin/templates", templates)
+	mux.HandleFunc("/admin/webhooks", webhooks)
+	mux.HandleFunc("/admin/teams", teams)
+	mux.HandleFunc("/admin/tenants", tenants)
+	mux.HandleFunc("/prr/export", deprecated(func(w http.ResponseWriter, r *http.Request) {
+		a.handleExportSubmission(w, r, r.URL.Query().Get("id"))
+	}))
+	mux.HandleFunc("/prr/validate", a.handleValidateSubmission)
+	mux.HandleFunc("/prr/form", a.handleReviewForm)
+	mux.HandleFunc("/prr/compare", a.handleCompareSubmissions)
+	mux.HandleFunc("/prr/compare/latest", a.handleCompareLatest)
+	mux.HandleFunc("/prr/compare/services", a.handleCompareServices)
+	mux.HandleFunc("/prr/bulk", bulkImportSubmissions)
+	mux.HandleFunc("/prr/partial", a.handleSubmitPartial)
+	mux.HandleFunc("/prr/history/export", a.handleExportSubmissionHistory)
+	mux.HandleFunc("/prr/trend", a.handleTrend)
+	mux.HandleFunc("/prr/stale", a.handleListStaleServices)
+	mux.HandleFunc("/gate", a.handlePreReleaseGate)
+	mux.HandleFunc("/dashboard/summary", a.handleDashboardSummary)
+	mux.HandleFunc("/analytics/sections", a.handleSectionAnalytics)
+	mux.HandleFunc("/analytics/questions/hardest", a.handleHardestQuestions)
+	mux.HandleFunc("/events", a.handleEvents)
+	if a.DevMode {
+		mux.HandleFunc("/dev/seed", a.handleDevSeed)
+	}
+
+	// paths holds the RESTful path-parameter routes this API is
+	// migrating to. Each has an older query-parameter equivalent above,
+	// registered directly on mux and marked deprecated, kept working as
+	// an alias for one release.
+	paths := newPathRouter()
+	paths.Handle(http.MethodDelete, "/services/{id}", func(w http.ResponseWriter, r *http.Request) {
+		a.handleDeleteService(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodDelete, "/admin/sections/{id}", deleteSection)
+	paths.Handle(http.MethodDelete, "/admin/templates/{id}", deleteTemplate)
+	paths.Handle(http.MethodDelete, "/admin/webhooks/{id}", deleteWebhook)
+	paths.Handle(http.MethodDelete, "/admin/teams/{id}", deleteTeam)
+	paths.Handle(http.MethodDelete, "/admin/tenants/{id}", deleteTenant)
+	paths.Handle(http.MethodGet, "/teams/{id}/readiness", teamReadiness)
+	paths.Handle(http.MethodPost, "/admin/questions/{id}/restore", restoreQuestion)
+	paths.Handle(http.MethodPut, "/admin/questions/{id}/move", moveQuestion)
+	paths.Handle(http.MethodPut, "/admin/questions/{id}/translations/{locale}", questionTranslation)
+	paths.Handle(http.MethodDelete, "/admin/questions/{id}/translations/{locale}", questionTranslation)
+	paths.Handle(http.MethodPut, "/admin/sections/{id}/translations/{locale}", sectionTranslation)
+	paths.Handle(http.MethodDelete, "/admin/sections/{id}/translations/{locale}", sectionTranslation)
+	paths.Handle(http.MethodPost, "/prr/{id}/baseline", func(w http.ResponseWriter, r *http.Request) {
+		a.handleSetBaseline(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodGet, "/prr/{id}/verify", func(w http.ResponseWriter, r *http.Request) {
+		a.handleVerifySubmission(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodPost, "/prr/{id}/attachments", func(w http.ResponseWriter, r *http.Request) {
+		a.handleUploadAttachment(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodGet, "/prr/{id}/attachments", func(w http.ResponseWriter, r *http.Request) {
+		a.handleListAttachments(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodGet, "/prr/attachments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		a.handleDownloadAttachment(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodDelete, "/prr/attachments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		a.handleDeleteAttachment(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodGet, "/prr/jobs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		a.handleJobStatus(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodPost, "/prr/{id}/actions", func(w http.ResponseWriter, r *http.Request) {
+		create := a.handleCreateActionItem
+		id := pathParam(r, "id")
+		if len(a.Tokens) > 0 {
+			auth.RequireRole(auth.RoleReviewer, func(w http.ResponseWriter, r *http.Request) {
+				create(w, r, id)
+			})(w, r)
+			return
+		}
+		create(w, r, id)
+	})
+	paths.Handle(http.MethodGet, "/prr/{id}/actions", func(w http.ResponseWriter, r *http.Request) {
+		a.handleListActionItemsBySubmission(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodGet, "/services/{id}/actions", func(w http.ResponseWriter, r *http.Request) {
+		a.handleListActionItemsByService(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodGet, "/services/{id}/scores/timeseries", func(w http.ResponseWriter, r *http.Request) {
+		a.handleScoreTimeseries(w, r, pathParam(r, "id"))
+	})
+	paths.Handle(http.MethodPatch, "/prr/{id}/answers", func(w http.ResponseWriter, r *http.Request) {
+		amend := a.handleAmendSubmissionAnswers
+		id := pathParam(r, "id")
+		if len(a.Tokens) > 0 {
+			auth.RequireRole(auth.RoleReviewer, func(w http.ResponseWriter, r *http.Request) {
+				amend(w, r, id)
+			})(w, r)
+			return
+		}
+		amend(w, r, id)
+	})
+
+	var apiHandler http.Handler = paths.Wrap(mux)
+	apiHandler = a.withFieldRedaction(apiHandler)
+	if len(a.Tokens) > 0 {
+		apiHandler = auth.Middleware(a.Tokens, apiHandler)
+	}
+	apiHandler = a.withRequestTimeout(apiHandler)
+	apiHandler = a.withMaxRequestBodySize(apiHandler)
+	if a.RateLimitPerSecond > 0 {
+		apiHandler = a.withRateLimit(newRateLimiter(a.RateLimitPerSecond, a.RateLimitBurst), apiHandler)
+	}
+	top.Handle("/", apiHandler)
+	return a.withRequestLogging(a.withCORS(top))
+}
+
+// withRequestTimeout bounds the context of every request passed to next,
+// so a slow downstream call gets cancelled instead of holding the
+// handler (and the client connection) open forever.
+func (a *API) withRequestTimeout(next http.Handler) http.Handler {
+	timeout := a.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// handleExplainScore returns the per-question breakdown behind a
+// submission's score, so reviewers can see exactly why a service scored
+// the way it did.
+func (a *API) handleExplainScore(w http.ResponseWriter, r *http.Request, submissionID string) {
+	sub, err := a.authorizedSubmission(r.Context(), r, submissionID)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	scoped, questions, err := a.scopeSubmissionToTemplate(r.Context(), sub)
+	if err != nil {
+		writeAPIError(w, "failed to resolve template", http.StatusInternalServerError)
+		return
+	}
+	thresholds, err := a.Store.GetScoringThresholds(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to load scoring config", http.StatusInternalServerError)
+		return
+	}
+
+	score := prr.ComputeScore(scoped, questions)
+	score.Grade = prr.ComputeGrade(score.Percent, thresholds)
+	writeJSON(w, http.StatusOK, score)
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleExplainScoreReturnsBreakdown(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?", Weight: 1}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "svc"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: "svc-1", Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/submissions/"+sub.ID+"/score/explain", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var score prr.Score
+	if err := json.Unmarshal(rec.Body.Bytes(), &score); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(score.Breakdown) != 1 || score.Breakdown[0].QuestionID != "q1" {
+		t.Fatalf("Breakdown = %+v", score.Breakdown)
+	}
+}
+
+func TestHandleExplainScoreRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-1", Name: "svc", TenantID: "tenant-a"}); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+	sub := prr.Submission{ID: "sub-1", ServiceID: "svc-1"}
+	if err := st.CreateSubmission(ctx, sub); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/submissions/"+sub.ID+"/score/explain", nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleExplainScoreNotFound(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/submissions/missing/score/explain", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
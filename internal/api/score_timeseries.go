@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// scorePoint is one submission's place in a score timeseries: just
+// enough to plot a sparkline without downloading the full submission.
+type scorePoint struct {
+	SubmissionID string  `json:"submission_id"`
+	CreatedAt    string  `json:"created_at"`
+	Percent      float64 `json:"percent"`
+}
+
+// handleScoreTimeseries returns a compact chronological series of
+// (timestamp, score) points across every submission for the service
+// identified by id, so the UI can render a trend sparkline without
+// downloading full submissions. When section_id is set, Percent is
+// that section's score rather than the overall score; submissions that
+// never scored the section are omitted.
+func (a *API) handleScoreTimeseries(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := a.authorizedService(r.Context(), r, id); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	sectionID := r.URL.Query().Get("section_id")
+
+	all, err := a.Store.ListSubmissionsByService(r.Context(), id)
+	if err != nil {
+		writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].CreatedAt.Before(all[j].CreatedAt) })
+
+	points := make([]scorePoint, 0, len(all))
+	for _, sub := range all {
+		scoped, questions, err := a.scopeSubmissionToTemplate(r.Context(), sub)
+		if err != nil {
+			writeStoreError(w, err, "template")
+			return
+		}
+		score := prr.ComputeScore(scoped, questions)
+
+		percent := score.Percent
+		if sectionID != "" {
+			found := false
+			for _, sec := range score.SectionScores {
+				if sec.SectionID == sectionID {
+					percent = sec.Percent
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		points = append(points, scorePoint{
+			SubmissionID: sub.ID,
+			CreatedAt:    sub.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Percent:      percent,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, points)
+}
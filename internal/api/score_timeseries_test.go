@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleScoreTimeseriesReturnsChronologicalPoints(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	a := New(st, prr.SystemClock{}, idGen, nil)
+	a.DisableStrictValidation = true
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1"}); err != nil {
+		t.Fatal(err)
+	}
+	svc := prr.Service{ID: idGen.NewID(), Name: "svc"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	values := []string{"no", "yes"}
+	for i, v := range values {
+		sub := prr.Submission{
+			ID:        idGen.NewID(),
+			ServiceID: svc.ID,
+			Answers:   []prr.Answer{{QuestionID: "q1", Value: v}},
+			CreatedAt: time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC),
+		}
+		if err := st.CreateSubmission(ctx, sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/scores/timeseries", nil)
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+	var points []scorePoint
+	if err := json.Unmarshal(rec.Body.Bytes(), &points); err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 || points[0].Percent != 0 || points[1].Percent != 100 {
+		t.Fatalf("points = %+v, want [0, 100]", points)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/scores/timeseries?section_id=sec-1", nil)
+	rec = httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+	points = nil
+	json.Unmarshal(rec.Body.Bytes(), &points)
+	if len(points) != 2 || points[1].Percent != 100 {
+		t.Fatalf("section points = %+v, want 2 points ending at 100", points)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/scores/timeseries?section_id=missing", nil)
+	rec = httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	points = nil
+	json.Unmarshal(rec.Body.Bytes(), &points)
+	if len(points) != 0 {
+		t.Fatalf("points for missing section = %+v, want none", points)
+	}
+}
+
+func TestHandleScoreTimeseriesRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	svc := prr.Service{ID: "svc-1", Name: "svc", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/scores/timeseries", nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// handleScoringConfig gets or sets the grade thresholds used to turn a
+// submission's score percentage into a Red/Amber/Green grade.
+func (a *API) handleScoringConfig(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		thresholds, err := a.Store.GetScoringThresholds(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to load scoring config", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, thresholds)
+	case http.MethodPut:
+		var thresholds prr.ScoringThresholds
+		if err := jsonvalidate.Decode(r.Body, &thresholds); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if thresholds.AmberMin > thresholds.GreenMin {
+			writeAPIError(w, "amber_min must not exceed green_min", http.StatusBadRequest)
+			return
+		}
+		if err := a.Store.SetScoringThresholds(r.Context(), thresholds); err != nil {
+			writeAPIError(w, "failed to save scoring config", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, thresholds)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// evaluateSubmission scores sub against its template (or the whole
+// question bank if it has none) and the configured thresholds, and
+// flags any essential questions it fails, returning the grade and
+// blocking issues for the caller to stamp onto the submission before
+// persisting it.
+func (a *API) evaluateSubmission(ctx context.Context, sub prr.Submission) (prr.Grade, []string, error) {
+	scoped, questions, err := a.scopeSubmissionToTemplate(ctx, sub)
+	if err != nil {
+		return "", nil, err
+	}
+	thresholds, err := a.Store.GetScoringThresholds(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	score := prr.ComputeScore(scoped, questions)
+	grade := prr.ComputeGrade(score.Percent, thresholds)
+	blocking := prr.ComputeBlockingIssues(scoped, questions)
+	return grade, blocking, nil
+}
+
+// questionsForTemplateID loads the current question bank, narrowed to a
+// template's selection when templateID is non-empty. It underlies both
+// scoring and answer validation so both use the same notion of "the
+// questions this submission may answer".
+func (a *API) questionsForTemplateID(ctx context.Context, templateID string) ([]prr.Question, error) {
+	questions, err := a.Store.ListQuestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if templateID == "" {
+		return questions, nil
+	}
+	tmpl, err := a.Store.GetTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+	return prr.QuestionsForTemplate(questions, &tmpl), nil
+}
+
+// scopeSubmissionToTemplate loads the current question bank and, if sub
+// has a TemplateID, narrows both the questions and sub's own answers
+// down to that template's selection, so scoring and gating only ever
+// consider questions the template actually asks. Submissions without a
+// template are returned unchanged against the full question bank.
+func (a *API) scopeSubmissionToTemplate(ctx context.Context, sub prr.Submission) (prr.Submission, []prr.Question, error) {
+	questions, err := a.questionsForTemplateID(ctx, sub.TemplateID)
+	if err != nil {
+		return prr.Submission{}, nil, err
+	}
+	if sub.TemplateID == "" {
+		return sub, questions, nil
+	}
+
+	allowed := make(map[string]bool, len(questions))
+	for _, q := range questions {
+		allowed[q.ID] = true
+	}
+	scoped := sub
+	scoped.Answers = make([]prr.Answer, 0, len(sub.Answers))
+	for _, ans := range sub.Answers {
+		if allowed[ans.QuestionID] {
+			scoped.Answers = append(scoped.Answers, ans)
+		}
+	}
+	return scoped, questions, nil
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestSubmissionGrading(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.UpsertQuestion(httptest.NewRequest(http.MethodGet, "/", nil).Context(), prr.Question{ID: "q1"}); err != nil {
+		t.Fatalf("seed question: %v", err)
+	}
+
+	createBody, _ := json.Marshal(map[string]string{"name": "svc"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(map[string]interface{}{
+		"service_id": svc.ID,
+		"answers":    []prr.Answer{{QuestionID: "q1", Value: "no"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+	if sub.Grade != prr.GradeRed {
+		t.Fatalf("grade = %q, want red", sub.Grade)
+	}
+
+	thresholdsBody, _ := json.Marshal(prr.ScoringThresholds{GreenMin: 100, AmberMin: 0})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/scoring", bytes.NewReader(thresholdsBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("set thresholds status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	subBody, _ = json.Marshal(map[string]interface{}{
+		"service_id": svc.ID,
+		"answers":    []prr.Answer{{QuestionID: "q1", Value: "no"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+	if sub.Grade != prr.GradeAmber {
+		t.Fatalf("grade = %q, want amber", sub.Grade)
+	}
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+)
+
+// answerTally counts how many submissions answered a question each way.
+type answerTally struct {
+	Yes   int `json:"yes"`
+	No    int `json:"no"`
+	NA    int `json:"na"`
+	Other int `json:"other,omitempty"`
+}
+
+func (t *answerTally) add(value string) {
+	switch value {
+	case "yes":
+		t.Yes++
+	case "no":
+		t.No++
+	case "na":
+		t.NA++
+	default:
+		t.Other++
+	}
+}
+
+// sectionAnalyticsPeriod is one period's answer tally for a question,
+// used to show how answers to it have trended over time.
+type sectionAnalyticsPeriod struct {
+	Period string `json:"period"`
+	answerTally
+}
+
+// questionAnalytics is the org-wide answer distribution for a single
+// question, both as of each service's latest submission and trended
+// over every historical submission.
+type questionAnalytics struct {
+	QuestionID string                   `json:"question_id"`
+	Latest     answerTally              `json:"latest"`
+	Trend      []sectionAnalyticsPeriod `json:"trend"`
+}
+
+type sectionAnalyticsResponse struct {
+	SectionID string              `json:"section_id"`
+	Questions []questionAnalytics `json:"questions"`
+}
+
+// handleSectionAnalytics aggregates, across every service, how answers
+// to a section's questions are distributed and how that distribution
+// has trended over time, so a platform team can see which readiness
+// criteria the org struggles with most.
+func (a *API) handleSectionAnalytics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sectionID := r.URL.Query().Get("section_id")
+	if sectionID == "" {
+		writeAPIError(w, "section_id is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	questions, err := a.Store.ListQuestions(ctx)
+	if err != nil {
+		writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+		return
+	}
+	questionIDs := make(map[string]bool)
+	for _, q := range questions {
+		if q.SectionID == sectionID {
+			questionIDs[q.ID] = true
+		}
+	}
+	if len(questionIDs) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+
+	services, err := a.visibleServices(ctx, resolveTenant(r))
+	if err != nil {
+		writeAPIError(w, "failed to list services", http.StatusInternalServerError)
+		return
+	}
+
+	latest := make(map[string]*answerTally, len(questionIDs))
+	trend := make(map[string]map[string]*answerTally, len(questionIDs))
+	for id := range questionIDs {
+		latest[id] = &answerTally{}
+		trend[id] = make(map[string]*answerTally)
+	}
+
+	for _, svc := range services {
+		subs, err := a.Store.ListSubmissionsByService(ctx, svc.ID)
+		if err != nil {
+			writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+			return
+		}
+		if len(subs) == 0 {
+			continue
+		}
+		sort.Slice(subs, func(i, j int) bool {
+			return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+		})
+
+		for i, sub := range subs {
+			for _, ans := range sub.Answers {
+				if !questionIDs[ans.QuestionID] {
+					continue
+				}
+				period := sub.CreatedAt.Format("2006-01")
+				byPeriod := trend[ans.QuestionID]
+				t, ok := byPeriod[period]
+				if !ok {
+					t = &answerTally{}
+					byPeriod[period] = t
+				}
+				t.add(ans.Value)
+
+				if i == len(subs)-1 {
+					latest[ans.QuestionID].add(ans.Value)
+				}
+			}
+		}
+	}
+
+	resp := sectionAnalyticsResponse{SectionID: sectionID}
+	orderedIDs := make([]string, 0, len(questionIDs))
+	for id := range questionIDs {
+		orderedIDs = append(orderedIDs, id)
+	}
+	sort.Strings(orderedIDs)
+
+	for _, id := range orderedIDs {
+		periods := make([]string, 0, len(trend[id]))
+		for p := range trend[id] {
+			periods = append(periods, p)
+		}
+		sort.Strings(periods)
+
+		qa := questionAnalytics{QuestionID: id, Latest: *latest[id]}
+		for _, p := range periods {
+			qa.Trend = append(qa.Trend, sectionAnalyticsPeriod{Period: p, answerTally: *trend[id][p]})
+		}
+		resp.Questions = append(resp.Questions, qa)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleSectionAnalytics(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	a := New(st, prr.SystemClock{}, idGen, nil)
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := prr.Service{ID: idGen.NewID(), Name: "svc"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	subs := []prr.Submission{
+		{
+			ID:        idGen.NewID(),
+			ServiceID: svc.ID,
+			Answers:   []prr.Answer{{QuestionID: "q1", Value: "no"}},
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        idGen.NewID(),
+			ServiceID: svc.ID,
+			Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+			CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, sub := range subs {
+		if err := st.CreateSubmission(ctx, sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/analytics/sections?section_id=sec1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp sectionAnalyticsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Questions) != 1 || resp.Questions[0].QuestionID != "q1" {
+		t.Fatalf("Questions = %+v, want one entry for q1", resp.Questions)
+	}
+	q := resp.Questions[0]
+	if q.Latest.Yes != 1 {
+		t.Errorf("Latest.Yes = %d, want 1 (most recent submission answered yes)", q.Latest.Yes)
+	}
+	if len(q.Trend) != 2 || q.Trend[0].Period != "2024-01" || q.Trend[0].No != 1 || q.Trend[1].Period != "2024-02" || q.Trend[1].Yes != 1 {
+		t.Fatalf("Trend = %+v, want Jan=no, Feb=yes", q.Trend)
+	}
+}
+
+func TestHandleSectionAnalyticsUnknownSection(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/analytics/sections?section_id=nope", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type createSectionRequest struct {
+	Name string `json:"name"`
+}
+
+type updateSectionRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// handleSections creates or lists sections. The list is served with an
+// ETag and honors If-None-Match, since it's fetched on every form load
+// but rarely changes.
+func (a *API) handleSections(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req createSectionRequest
+		if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" {
+			writeAPIError(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		section := prr.Section{ID: a.IDGen.NewID(), Name: req.Name}
+		if err := a.Store.UpsertSection(r.Context(), section); err != nil {
+			writeAPIError(w, "failed to create section", http.StatusInternalServerError)
+			return
+		}
+		a.recordAudit(r.Context(), "section", section.ID, prr.AuditActionCreate, nil, section)
+		writeJSON(w, http.StatusCreated, section)
+	case http.MethodGet:
+		sections, err := a.Store.ListSections(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to list sections", http.StatusInternalServerError)
+			return
+		}
+		sortSections(sections)
+		writeJSONWithETag(w, r, sections)
+	case http.MethodPut:
+		a.handleUpdateSection(w, r)
+	case http.MethodDelete:
+		deprecated(func(w http.ResponseWriter, r *http.Request) {
+			a.handleDeleteSection(w, r, r.URL.Query().Get("id"))
+		})(w, r)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleUpdateSection renames an existing section. It refuses to create
+// a new one under this verb so clients don't accidentally mint
+// unexpected IDs by misspelling one in a PUT.
+func (a *API) handleUpdateSection(w http.ResponseWriter, r *http.Request) {
+	var req updateSectionRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	before, err := a.Store.GetSection(r.Context(), req.ID)
+	if err != nil {
+		writeStoreError(w, err, "section")
+		return
+	}
+
+	section := prr.Section{ID: req.ID, Name: req.Name}
+	if err := a.Store.UpsertSection(r.Context(), section); err != nil {
+		writeAPIError(w, "failed to update section", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "section", section.ID, prr.AuditActionUpdate, before, section)
+	writeJSON(w, http.StatusOK, section)
+}
+
+// handleDeleteSection deletes the section identified by id. It refuses
+// to delete a section that still has questions attached unless
+// "force=true" is also set, in which case those questions are deleted
+// along with it.
+func (a *API) handleDeleteSection(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	force := r.URL.Query().Get("force") == "true"
+
+	before, err := a.Store.GetSection(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "section")
+		return
+	}
+
+	questions, err := a.Store.ListQuestions(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to list questions", http.StatusInternalServerError)
+		return
+	}
+	var attached []prr.Question
+	for _, q := range questions {
+		if q.SectionID == id {
+			attached = append(attached, q)
+		}
+	}
+	if len(attached) > 0 && !force {
+		writeAPIError(w, "section has questions attached; pass force=true to delete them too", http.StatusConflict)
+		return
+	}
+
+	attachedIDs := make(map[string]bool, len(attached))
+	for _, q := range attached {
+		attachedIDs[q.ID] = true
+	}
+	conflicts, err := a.questionDependencyConflicts(r.Context(), attachedIDs, id)
+	if err != nil {
+		writeAPIError(w, "failed to check section dependencies", http.StatusInternalServerError)
+		return
+	}
+	if len(conflicts) > 0 {
+		writeDependencyConflicts(w, conflicts)
+		return
+	}
+
+	for _, q := range attached {
+		if err := a.Store.DeleteQuestion(r.Context(), q.ID); err != nil {
+			writeAPIError(w, "failed to delete attached question", http.StatusInternalServerError)
+			return
+		}
+		a.recordAudit(r.Context(), "question", q.ID, prr.AuditActionDelete, q, nil)
+	}
+
+	if err := a.Store.DeleteSection(r.Context(), id); err != nil {
+		writeStoreError(w, err, "section")
+		return
+	}
+	a.recordAudit(r.Context(), "section", id, prr.AuditActionDelete, before, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sortSections orders sections by Order, breaking ties by ID so the
+// result is deterministic regardless of the store's iteration order.
+func sortSections(sections []prr.Section) {
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].Order != sections[j].Order {
+			return sections[i].Order < sections[j].Order
+		}
+		return sections[i].ID < sections[j].ID
+	})
+}
+
+// sortQuestions orders questions by Order, breaking ties by ID so the
+// result is deterministic regardless of the store's iteration order.
+func sortQuestions(questions []prr.Question) {
+	sort.Slice(questions, func(i, j int) bool {
+		if questions[i].Order != questions[j].Order {
+			return questions[i].Order < questions[j].Order
+		}
+		return questions[i].ID < questions[j].ID
+	})
+}
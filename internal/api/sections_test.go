@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestSectionUpdateAndDelete(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]string{"name": "Security"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/sections", bytes.NewReader(createBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var section prr.Section
+	json.Unmarshal(rec.Body.Bytes(), &section)
+
+	updateBody, _ := json.Marshal(map[string]string{"id": section.ID, "name": "Security & Compliance"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/sections", bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	if err := st.UpsertQuestion(httptest.NewRequest(http.MethodGet, "/", nil).Context(), prr.Question{ID: "q1", SectionID: section.ID}); err != nil {
+		t.Fatalf("seed question: %v", err)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/sections?id="+section.ID, nil))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("delete with attached question status = %d, want 409", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/sections?id="+section.ID+"&force=true", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("forced delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := st.GetSection(httptest.NewRequest(http.MethodGet, "/", nil).Context(), section.ID); err == nil {
+		t.Fatalf("expected section to be deleted")
+	}
+	if _, err := st.GetQuestion(httptest.NewRequest(http.MethodGet, "/", nil).Context(), "q1"); err == nil {
+		t.Fatalf("expected attached question to be deleted")
+	}
+}
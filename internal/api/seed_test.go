@@ -0,0 +1,42 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestSeedQuestionBankIsIdempotent(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/seed", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("call %d: status = %d, body=%s", i, rec.Code, rec.Body.String())
+		}
+	}
+
+	sections, err := st.ListSections(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sections) == 0 {
+		t.Fatal("ListSections() returned none, want the embedded default bank's sections")
+	}
+}
+
+func TestSeedQuestionBankRejectsGet(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/seed", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want 405", rec.Code)
+	}
+}
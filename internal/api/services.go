@@ -0,0 +1,220 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/mail"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type createServiceRequest struct {
+	Name string `json:"name"`
+	// SlackChannel, if set, overrides the default Slack channel for this
+	// service's PRR notifications.
+	SlackChannel  string          `json:"slack_channel,omitempty"`
+	Description   string          `json:"description,omitempty"`
+	OwnerTeam     string          `json:"owner_team,omitempty"`
+	OwnerEmail    string          `json:"owner_email,omitempty"`
+	Tier          prr.ServiceTier `json:"tier,omitempty"`
+	RepositoryURL string          `json:"repository_url,omitempty"`
+	RunbookURL    string          `json:"runbook_url,omitempty"`
+	// ReviewIntervalDays overrides the deployment-wide PRR review
+	// interval for this service. Zero keeps the deployment default.
+	ReviewIntervalDays int `json:"review_interval_days,omitempty"`
+}
+
+// handleCreateService creates a new service record.
+func (a *API) handleCreateService(w http.ResponseWriter, r *http.Request) {
+	var req createServiceRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Name == "" {
+		writeAPIError(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if err := validateServiceMetadata(req.Tier, req.OwnerEmail); err != nil {
+		writeAPIError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	svc := prr.Service{
+		ID:                 a.IDGen.NewID(),
+		Name:               req.Name,
+		CreatedAt:          a.Clock.Now(),
+		SlackChannel:       req.SlackChannel,
+		Description:        req.Description,
+		OwnerTeam:          req.OwnerTeam,
+		OwnerEmail:         req.OwnerEmail,
+		Tier:               req.Tier,
+		RepositoryURL:      req.RepositoryURL,
+		RunbookURL:         req.RunbookURL,
+		TenantID:           resolveTenant(r),
+		ReviewIntervalDays: req.ReviewIntervalDays,
+	}
+	if err := a.Store.CreateService(r.Context(), svc); err != nil {
+		writeAPIError(w, "failed to create service", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "service", svc.ID, prr.AuditActionCreate, nil, svc)
+
+	writeJSON(w, http.StatusCreated, svc)
+}
+
+// handleListServices returns known services, hiding archived ones unless
+// include_archived=true is set. On a multi-tenant deployment, only
+// services stamped with the caller's resolved tenant are returned; a
+// caller resolved to no tenant sees only services stamped with no
+// tenant, matching how the deployment behaves before any tenant is
+// provisioned. Each service is annotated with its computed PRR review
+// ComplianceStatus.
+func (a *API) handleListServices(w http.ResponseWriter, r *http.Request) {
+	services, err := a.visibleServices(r.Context(), resolveTenant(r))
+	if err != nil {
+		writeAPIError(w, "failed to list services", http.StatusInternalServerError)
+		return
+	}
+	visible := make([]prr.Service, 0, len(services))
+	for _, svc := range services {
+		if svc.Archived && r.URL.Query().Get("include_archived") != "true" {
+			continue
+		}
+		visible = append(visible, svc)
+	}
+	compliance, err := a.serviceComplianceForAll(r.Context(), visible, a.Clock.Now())
+	if err != nil {
+		writeAPIError(w, "failed to compute compliance status", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, compliance)
+}
+
+type updateServiceRequest struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	SlackChannel  string          `json:"slack_channel,omitempty"`
+	Description   string          `json:"description,omitempty"`
+	OwnerTeam     string          `json:"owner_team,omitempty"`
+	OwnerEmail    string          `json:"owner_email,omitempty"`
+	Tier          prr.ServiceTier `json:"tier,omitempty"`
+	RepositoryURL string          `json:"repository_url,omitempty"`
+	RunbookURL    string          `json:"runbook_url,omitempty"`
+	// ReviewIntervalDays overrides the deployment-wide PRR review
+	// interval for this service. Zero keeps the deployment default.
+	ReviewIntervalDays int `json:"review_interval_days,omitempty"`
+}
+
+// handleUpdateService replaces an existing service's metadata. It refuses
+// to create a new one under this verb so clients don't accidentally mint
+// unexpected IDs by misspelling one in a PUT. CreatedAt and TenantID are
+// preserved from the existing record: CreatedAt describes when the
+// service was first registered rather than when its metadata was last
+// edited, and TenantID isn't something a metadata edit should be able to
+// reassign.
+func (a *API) handleUpdateService(w http.ResponseWriter, r *http.Request) {
+	var req updateServiceRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	if req.Name == "" {
+		writeAPIError(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if err := validateServiceMetadata(req.Tier, req.OwnerEmail); err != nil {
+		writeAPIError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	existing, err := a.authorizedService(r.Context(), r, req.ID)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+
+	svc := prr.Service{
+		ID:                 req.ID,
+		Name:               req.Name,
+		CreatedAt:          existing.CreatedAt,
+		SlackChannel:       req.SlackChannel,
+		Description:        req.Description,
+		OwnerTeam:          req.OwnerTeam,
+		OwnerEmail:         req.OwnerEmail,
+		Tier:               req.Tier,
+		RepositoryURL:      req.RepositoryURL,
+		RunbookURL:         req.RunbookURL,
+		Archived:           existing.Archived,
+		TenantID:           existing.TenantID,
+		ReviewIntervalDays: req.ReviewIntervalDays,
+	}
+	if err := a.Store.UpdateService(r.Context(), svc); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	a.recordAudit(r.Context(), "service", svc.ID, prr.AuditActionUpdate, existing, svc)
+	writeJSON(w, http.StatusOK, svc)
+}
+
+// handleDeleteService archives the service identified by id. This is a
+// soft delete: the service is hidden from default listings and
+// search, but its record and PRR history are retained rather than
+// removed, so past submissions still resolve to a real service.
+func (a *API) handleDeleteService(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	before, err := a.authorizedService(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	svc := before
+	svc.Archived = true
+	if err := a.Store.UpdateService(r.Context(), svc); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	a.recordAudit(r.Context(), "service", svc.ID, prr.AuditActionDelete, before, svc)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRestoreService un-archives the service identified by id, making it
+// visible again in default listings and search.
+func (a *API) handleRestoreService(w http.ResponseWriter, r *http.Request, id string) {
+	before, err := a.authorizedService(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	svc := before
+	svc.Archived = false
+	if err := a.Store.UpdateService(r.Context(), svc); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	a.recordAudit(r.Context(), "service", svc.ID, prr.AuditActionUpdate, before, svc)
+	writeJSON(w, http.StatusOK, svc)
+}
+
+// validateServiceMetadata checks the fields shared by create and update
+// requests that aren't free text: tier must be a known ServiceTier (or
+// empty), and ownerEmail, if set, must be a syntactically valid address.
+func validateServiceMetadata(tier prr.ServiceTier, ownerEmail string) error {
+	if !prr.ValidTier(tier) {
+		return fmt.Errorf("tier must be one of %q, %q, %q, %q or omitted", prr.TierCritical, prr.TierHigh, prr.TierMedium, prr.TierLow)
+	}
+	if ownerEmail != "" {
+		if _, err := mail.ParseAddress(ownerEmail); err != nil {
+			return fmt.Errorf("owner_email is not a valid email address")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestServiceUpdateMetadata(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	updateBody, _ := json.Marshal(map[string]interface{}{
+		"id":             svc.ID,
+		"name":           "payments",
+		"owner_team":     "payments-team",
+		"owner_email":    "payments-team@example.com",
+		"tier":           "critical",
+		"repository_url": "https://git.example.com/payments",
+		"runbook_url":    "https://runbooks.example.com/payments",
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/services", bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var updated prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &updated)
+	if updated.OwnerTeam != "payments-team" || updated.Tier != prr.TierCritical {
+		t.Fatalf("updated = %+v, want owner/tier set", updated)
+	}
+	if !updated.CreatedAt.Equal(svc.CreatedAt) {
+		t.Fatalf("CreatedAt changed on update: got %v, want %v", updated.CreatedAt, svc.CreatedAt)
+	}
+}
+
+func TestServiceUpdateRejectsUnknownTier(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	updateBody, _ := json.Marshal(map[string]interface{}{"id": svc.ID, "name": "payments", "tier": "extreme"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/services", bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDeleteServiceArchivesAndHidesFromListing(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/services?id="+svc.ID, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services", nil))
+	var listed []prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	for _, s := range listed {
+		if s.ID == svc.ID {
+			t.Fatalf("archived service %s still visible in default listing", svc.ID)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services?include_archived=true", nil))
+	listed = nil
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	found := false
+	for _, s := range listed {
+		if s.ID == svc.ID && s.Archived {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("archived service %s not returned with include_archived=true", svc.ID)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services/"+svc.ID+"/restore", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("restore status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var restored prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &restored)
+	if restored.Archived {
+		t.Fatalf("restored service still marked archived")
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services", nil))
+	listed = nil
+	json.Unmarshal(rec.Body.Bytes(), &listed)
+	found = false
+	for _, s := range listed {
+		if s.ID == svc.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("restored service %s missing from default listing", svc.ID)
+	}
+}
+
+func TestServiceByIDEndpointsRejectCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+
+	updateBody, _ := json.Marshal(map[string]interface{}{"id": svc.ID, "name": "payments", "tier": "critical"})
+	updateReq := httptest.NewRequest(http.MethodPut, "/services", bytes.NewReader(updateBody))
+
+	for _, req := range []*http.Request{
+		updateReq,
+		httptest.NewRequest(http.MethodDelete, "/services?id="+svc.ID, nil),
+		httptest.NewRequest(http.MethodPost, "/services/"+svc.ID+"/restore", nil),
+	} {
+		req.Header.Set(tenantHeader, "tenant-b")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s %s: status = %d, want 404, body=%s", req.Method, req.URL.Path, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestCreateServiceRejectsInvalidOwnerEmail(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	body, _ := json.Marshal(map[string]string{"name": "payments", "owner_email": "not-an-email"})
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(body)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400, body=%s", rec.Code, rec.Body.String())
+	}
+}
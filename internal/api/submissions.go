@@ -0,0 +1,449 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+type createSubmissionRequest struct {
+	ServiceID  string               `json:"service_id"`
+	TemplateID string               `json:"template_id,omitempty"`
+	Answers    []prr.Answer         `json:"answers"`
+	Status     prr.SubmissionStatus `json:"status"`
+	// IdempotencyKey, if set (or given via the Idempotency-Key header,
+	// which takes precedence), lets a retried POST return the
+	// submission created by the original request instead of creating a
+	// duplicate, as long as the retry lands within IdempotencyWindow.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// SubmitterEmail, if set, is where approval/rejection notifications
+	// for this submission are sent. Empty skips those notifications.
+	SubmitterEmail string `json:"submitter_email,omitempty"`
+}
+
+// handleCreateSubmission records a new PRR submission for a service. If
+// status is omitted or "final" it is scored and notified immediately; if
+// "draft" it can be edited later via handleUpdateSubmission and finalized
+// via handleFinalizeSubmission.
+//
+// async=true defers the scoring/notification work (the part that gets
+// slower as templates and webhooks are added) to a background job and
+// returns 202 with a job ID immediately after the cheap, synchronous
+// validation below; GET /prr/jobs/{id} reports its outcome. It requires
+// a.Jobs to be configured and is rejected with 501 otherwise.
+func (a *API) handleCreateSubmission(w http.ResponseWriter, r *http.Request) {
+	var req createSubmissionRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ServiceID == "" {
+		writeAPIError(w, "service_id is required", http.StatusBadRequest)
+		return
+	}
+	status := req.Status
+	if status == "" {
+		status = prr.SubmissionFinal
+	}
+	if status != prr.SubmissionDraft && status != prr.SubmissionFinal {
+		writeAPIError(w, "status must be \"draft\" or \"final\"", http.StatusBadRequest)
+		return
+	}
+
+	key := req.IdempotencyKey
+	if h := r.Header.Get("Idempotency-Key"); h != "" {
+		key = h
+	}
+	if key != "" {
+		existing, err := a.Store.GetSubmissionByIdempotencyKey(r.Context(), key)
+		if err != nil && err != store.ErrNotFound {
+			writeAPIError(w, "failed to check idempotency key", http.StatusInternalServerError)
+			return
+		}
+		if err == nil {
+			window := a.IdempotencyWindow
+			if window <= 0 {
+				window = defaultIdempotencyWindow
+			}
+			if a.Clock.Now().Sub(existing.CreatedAt) <= window {
+				writeJSON(w, http.StatusOK, existing)
+				return
+			}
+		}
+	}
+
+	svc, err := a.authorizedService(r.Context(), r, req.ServiceID)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	if req.TemplateID != "" {
+		if _, err := a.Store.GetTemplate(r.Context(), req.TemplateID); err != nil {
+			writeStoreError(w, err, "template")
+			return
+		}
+	}
+
+	if !a.DisableStrictValidation {
+		allowed, err := a.questionsForTemplateID(r.Context(), req.TemplateID)
+		if err != nil {
+			writeAPIError(w, "failed to validate answers", http.StatusInternalServerError)
+			return
+		}
+		if errs := prr.ValidateAnswers(req.Answers, allowed); len(errs) > 0 {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": errs})
+			return
+		}
+	}
+
+	if r.URL.Query().Get("async") == "true" {
+		a.handleCreateSubmissionAsync(w, r, svc, req, status, key)
+		return
+	}
+
+	sub, err := a.finishSubmission(r.Context(), svc, req, status, key)
+	if err != nil {
+		writeAPIError(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusCreated, sub)
+}
+
+// handleCreateSubmissionAsync enqueues the slow part of submission
+// creation (see finishSubmission) on a.Jobs and responds 202 with a job
+// ID the caller can poll at GET /prr/jobs/{id}.
+func (a *API) handleCreateSubmissionAsync(w http.ResponseWriter, r *http.Request, svc prr.Service, req createSubmissionRequest, status prr.SubmissionStatus, key string) {
+	if a.Jobs == nil {
+		writeAPIError(w, "asynchronous submission processing is not enabled on this deployment", http.StatusNotImplemented)
+		return
+	}
+	jobID := a.IDGen.NewID()
+	a.Jobs.Submit(jobID, func(ctx context.Context) (any, error) {
+		return a.finishSubmission(ctx, svc, req, status, key)
+	})
+	writeJSON(w, http.StatusAccepted, map[string]string{
+		"job_id":     jobID,
+		"status_url": "/prr/jobs/" + jobID,
+	})
+}
+
+// finishSubmission stamps question versions onto the submitted answers,
+// scores and stores the submission, and dispatches its notifications.
+// It's shared by the synchronous and async paths of handleCreateSubmission.
+func (a *API) finishSubmission(ctx context.Context, svc prr.Service, req createSubmissionRequest, status prr.SubmissionStatus, key string) (prr.Submission, error) {
+	answers, err := a.stampAnswerVersions(ctx, req.Answers)
+	if err != nil {
+		return prr.Submission{}, fmt.Errorf("failed to stamp question versions: %w", err)
+	}
+
+	now := a.Clock.Now()
+	sub := prr.Submission{
+		ID:             a.IDGen.NewID(),
+		ServiceID:      req.ServiceID,
+		TemplateID:     req.TemplateID,
+		Answers:        answers,
+		Status:         status,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		IdempotencyKey: key,
+		SubmitterEmail: req.SubmitterEmail,
+	}
+	if status == prr.SubmissionFinal {
+		sub.Approval = prr.ApprovalPending
+		grade, blocking, err := a.evaluateSubmission(ctx, sub)
+		if err != nil {
+			return prr.Submission{}, fmt.Errorf("failed to grade submission: %w", err)
+		}
+		sub.Grade = grade
+		sub.BlockingIssues = blocking
+	}
+	sub = a.stampContentHash(sub)
+	if err := a.Store.CreateSubmission(ctx, sub); err != nil {
+		if errors.Is(err, store.ErrDuplicateIdempotencyKey) {
+			// Lost the race against a concurrent retry carrying the same
+			// key: return the submission it created instead of erroring,
+			// so both requests converge on one result the way the
+			// Idempotency-Key header promises.
+			winner, getErr := a.Store.GetSubmissionByIdempotencyKey(ctx, sub.IdempotencyKey)
+			if getErr != nil {
+				return prr.Submission{}, fmt.Errorf("failed to create submission: %w", err)
+			}
+			return winner, nil
+		}
+		return prr.Submission{}, fmt.Errorf("failed to create submission: %w", err)
+	}
+	a.recordAudit(ctx, "submission", sub.ID, prr.AuditActionCreate, nil, sub)
+	a.refreshServiceReadiness(ctx, sub.ServiceID)
+
+	if status == prr.SubmissionFinal {
+		a.notifySubmissionCreated(ctx, svc, sub)
+		a.alertOnFailingAnswers(ctx, svc, sub)
+		a.notifySlackSummary(ctx, svc, sub)
+		a.sendLifecycleEmail(svc.OwnerEmail, a.EmailTemplates.SubmissionReceived, defaultEmailTemplates.SubmissionReceived, emailTemplateData{Service: svc, Submission: sub})
+		a.dispatchWebhookEvent(ctx, prr.WebhookEventSubmissionCreated, svc, sub)
+		if sub.Grade == prr.GradeRed {
+			a.dispatchWebhookEvent(ctx, prr.WebhookEventReadinessDegraded, svc, sub)
+		}
+		if withTickets := a.syncJiraTickets(ctx, svc, sub); len(withTickets.JiraIssueKeys) > 0 {
+			sub = withTickets
+			if err := a.Store.UpdateSubmission(ctx, sub); err != nil {
+				return prr.Submission{}, fmt.Errorf("failed to save jira issue keys: %w", err)
+			}
+		}
+	}
+
+	return sub, nil
+}
+
+type updateSubmissionRequest struct {
+	Answers []prr.Answer `json:"answers"`
+}
+
+// handleUpdateSubmission replaces the answers on a draft submission,
+// allowing submitters to save progress and resume later. Final
+// submissions cannot be edited this way.
+func (a *API) handleUpdateSubmission(w http.ResponseWriter, r *http.Request, id string) {
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	if sub.Status != prr.SubmissionDraft {
+		writeAPIError(w, "only draft submissions can be edited", http.StatusConflict)
+		return
+	}
+	before := sub
+
+	var req updateSubmissionRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if !a.DisableStrictValidation {
+		allowed, err := a.questionsForTemplateID(r.Context(), sub.TemplateID)
+		if err != nil {
+			writeAPIError(w, "failed to validate answers", http.StatusInternalServerError)
+			return
+		}
+		if errs := prr.ValidateAnswers(req.Answers, allowed); len(errs) > 0 {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": errs})
+			return
+		}
+	}
+
+	answers, err := a.stampAnswerVersions(r.Context(), req.Answers)
+	if err != nil {
+		writeAPIError(w, "failed to stamp question versions", http.StatusInternalServerError)
+		return
+	}
+	sub.Answers = answers
+	sub.UpdatedAt = a.Clock.Now()
+	sub = a.stampContentHash(sub)
+	if err := a.Store.UpdateSubmission(r.Context(), sub); err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	a.recordAudit(r.Context(), "submission", sub.ID, prr.AuditActionUpdate, before, sub)
+
+	writeJSON(w, http.StatusOK, sub)
+}
+
+// handleFinalizeSubmission marks a draft submission as final, scoring
+// and notifying as if it had been submitted complete from the start.
+func (a *API) handleFinalizeSubmission(w http.ResponseWriter, r *http.Request, id string) {
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	if sub.Status == prr.SubmissionFinal {
+		writeAPIError(w, "submission is already final", http.StatusConflict)
+		return
+	}
+	before := sub
+
+	svc, err := a.Store.GetService(r.Context(), sub.ServiceID)
+	if err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+
+	sub.Status = prr.SubmissionFinal
+	sub.Approval = prr.ApprovalPending
+	sub.UpdatedAt = a.Clock.Now()
+	grade, blocking, err := a.evaluateSubmission(r.Context(), sub)
+	if err != nil {
+		writeAPIError(w, "failed to grade submission", http.StatusInternalServerError)
+		return
+	}
+	sub.Grade = grade
+	sub.BlockingIssues = blocking
+	if err := a.Store.UpdateSubmission(r.Context(), sub); err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	a.recordAudit(r.Context(), "submission", sub.ID, prr.AuditActionUpdate, before, sub)
+	a.refreshServiceReadiness(r.Context(), sub.ServiceID)
+
+	a.notifySubmissionCreated(r.Context(), svc, sub)
+	a.alertOnFailingAnswers(r.Context(), svc, sub)
+	a.notifySlackSummary(r.Context(), svc, sub)
+	a.sendLifecycleEmail(svc.OwnerEmail, a.EmailTemplates.SubmissionReceived, defaultEmailTemplates.SubmissionReceived, emailTemplateData{Service: svc, Submission: sub})
+	a.dispatchWebhookEvent(r.Context(), prr.WebhookEventSubmissionCreated, svc, sub)
+	if sub.Grade == prr.GradeRed {
+		a.dispatchWebhookEvent(r.Context(), prr.WebhookEventReadinessDegraded, svc, sub)
+	}
+	if withTickets := a.syncJiraTickets(r.Context(), svc, sub); len(withTickets.JiraIssueKeys) > 0 {
+		sub = withTickets
+		if err := a.Store.UpdateSubmission(r.Context(), sub); err != nil {
+			writeStoreError(w, err, "submission")
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, sub)
+}
+
+// notifySubmissionCreated best-effort notifies configured chat
+// integrations about a new submission. Failures are logged but never
+// fail the submission request itself.
+func (a *API) notifySubmissionCreated(ctx context.Context, svc prr.Service, sub prr.Submission) {
+	if a.Teams == nil {
+		return
+	}
+	text := fmt.Sprintf("A new PRR submission (%s) was recorded for **%s**.", sub.ID, svc.Name)
+	if err := a.Teams.Send(ctx, "New PRR submission", text); err != nil && a.Logger != nil {
+		a.Logger.Printf("failed to send teams notification: %v", err)
+	}
+}
+
+// notifySlackSummary best-effort posts a PRR summary (service name,
+// overall grade, failing essential questions, a link to the submission)
+// to Slack, overriding the channel with svc.SlackChannel if one is set.
+// Failures are logged but never fail the submission request itself.
+func (a *API) notifySlackSummary(ctx context.Context, svc prr.Service, sub prr.Submission) {
+	if a.Slack == nil {
+		return
+	}
+	failing := "none"
+	if len(sub.BlockingIssues) > 0 {
+		failing = strings.Join(sub.BlockingIssues, ", ")
+	}
+	link := sub.ID
+	if a.BaseURL != "" {
+		link = strings.TrimRight(a.BaseURL, "/") + "/submissions/" + sub.ID
+	}
+	text := fmt.Sprintf("*%s* submitted a PRR — grade *%s*\nFailing essential questions: %s\n%s", svc.Name, sub.Grade, failing, link)
+	if err := a.Slack.Send(ctx, svc.SlackChannel, text); err != nil && a.Logger != nil {
+		a.Logger.Printf("failed to send slack notification: %v", err)
+	}
+}
+
+// alertOnFailingAnswers raises an Opsgenie alert when a submission
+// contains any "no" answers, indicating the service is not production
+// ready. This is a coarse heuristic: it alerts on any "no" answer, not
+// only on the essential-question failures recorded in BlockingIssues.
+func (a *API) alertOnFailingAnswers(ctx context.Context, svc prr.Service, sub prr.Submission) {
+	if a.Opsgenie == nil {
+		return
+	}
+	var failing []string
+	for _, ans := range sub.Answers {
+		if ans.Value == "no" {
+			failing = append(failing, ans.QuestionID)
+		}
+	}
+	if len(failing) == 0 {
+		return
+	}
+
+	message := fmt.Sprintf("PRR submission %s for %s has failing answers", sub.ID, svc.Name)
+	description := fmt.Sprintf("Questions answered \"no\": %v", failing)
+	if err := a.Opsgenie.CreateAlert(ctx, message, description, "prr", svc.ID); err != nil && a.Logger != nil {
+		a.Logger.Printf("failed to create opsgenie alert: %v", err)
+	}
+}
+
+// expandedAnswer is a prr.Answer enriched with the question text, its
+// section name, and whether it's essential, so a client building a
+// review UI doesn't need to separately fetch the question bank to show
+// anything more than the raw answer value.
+type expandedAnswer struct {
+	prr.Answer
+	QuestionText string `json:"question_text,omitempty"`
+	SectionName  string `json:"section_name,omitempty"`
+	IsEssential  bool   `json:"is_essential,omitempty"`
+}
+
+// expandedSubmission is a prr.Submission whose Answers have been
+// enriched by expandAnswers, returned in place of the bare submission
+// when the request asks for expand=questions.
+type expandedSubmission struct {
+	prr.Submission
+	Answers []expandedAnswer `json:"answers"`
+}
+
+// expandAnswers resolves each of sub's answers against the question
+// bank and the section it belongs to, for handleGetSubmission's
+// expand=questions option. Question text, blurb, and section name are
+// localized into locale (see resolveLocale), falling back to their
+// base wording when locale is empty or untranslated.
+func (a *API) expandAnswers(ctx context.Context, sub prr.Submission, locale string) (expandedSubmission, error) {
+	questions, err := a.questionsForTemplateID(ctx, sub.TemplateID)
+	if err != nil {
+		return expandedSubmission{}, err
+	}
+	byID := make(map[string]prr.Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+
+	sections := make(map[string]string)
+	answers := make([]expandedAnswer, len(sub.Answers))
+	for i, answer := range sub.Answers {
+		expanded := expandedAnswer{Answer: answer}
+		if q, ok := byID[answer.QuestionID]; ok {
+			expanded.QuestionText, _ = localizedQuestionText(q, locale)
+			expanded.IsEssential = q.IsEssential
+			if name, ok := sections[q.SectionID]; ok {
+				expanded.SectionName = name
+			} else if section, err := a.Store.GetSection(ctx, q.SectionID); err == nil {
+				expanded.SectionName = localizedSectionName(section, locale)
+				sections[q.SectionID] = expanded.SectionName
+			}
+		}
+		answers[i] = expanded
+	}
+	return expandedSubmission{Submission: sub, Answers: answers}, nil
+}
+
+// handleGetSubmission returns a single submission by ID. With
+// expand=questions, each answer is enriched with its question text,
+// section name, and essential flag so the client doesn't need a
+// separate question-bank lookup to render a review; the text is
+// localized per resolveLocale (Accept-Language, overridden by lang=).
+func (a *API) handleGetSubmission(w http.ResponseWriter, r *http.Request, id string) {
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	if r.URL.Query().Get("expand") != "questions" {
+		writeJSON(w, http.StatusOK, sub)
+		return
+	}
+
+	expanded, err := a.expandAnswers(r.Context(), sub, resolveLocale(r))
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	writeJSON(w, http.StatusOK, expanded)
+}
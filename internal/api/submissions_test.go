@@ -0,0 +1,284 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestCreateSubmissionIdempotencyKeyReplaysOriginal(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionDraft})
+	req := httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body))
+	req.Header.Set("Idempotency-Key", "retry-key-1")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("first create status = %d, want 201, body=%s", rec.Code, rec.Body.String())
+	}
+	var first prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &first)
+
+	req = httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body))
+	req.Header.Set("Idempotency-Key", "retry-key-1")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("retried create status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+	var second prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &second)
+	if second.ID != first.ID {
+		t.Fatalf("retried create returned submission %s, want the original %s", second.ID, first.ID)
+	}
+
+	subs, err := st.ListSubmissionsByService(req.Context(), svc.ID)
+	if err != nil {
+		t.Fatalf("ListSubmissionsByService() error = %v", err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("len(subs) = %d, want 1 (retry must not create a duplicate)", len(subs))
+	}
+}
+
+func TestGetSubmissionExpandQuestionsEnrichesAnswers(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{ID: "sec-1", Name: "Security"}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is data encrypted at rest?", IsEssential: true}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{
+		ServiceID: svc.ID,
+		Status:    prr.SubmissionDraft,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body)))
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/submissions/"+sub.ID+"?expand=questions", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+	var got expandedSubmission
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(got.Answers) != 1 {
+		t.Fatalf("len(Answers) = %d, want 1", len(got.Answers))
+	}
+	answer := got.Answers[0]
+	if answer.QuestionText != "Is data encrypted at rest?" || answer.SectionName != "Security" || !answer.IsEssential {
+		t.Fatalf("answer = %+v, want resolved question text/section name/essential flag", answer)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/submissions/"+sub.ID, nil))
+	var plain prr.Submission
+	if err := json.Unmarshal(rec.Body.Bytes(), &plain); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(plain.Answers) != 1 || plain.Answers[0].QuestionID != "q1" {
+		t.Fatalf("plain get response = %+v, want unenriched answers", plain)
+	}
+}
+
+func TestCreateSubmissionDistinctIdempotencyKeysCreateSeparateSubmissions(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	for _, key := range []string{"key-a", "key-b"} {
+		body, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionDraft})
+		req := httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body))
+		req.Header.Set("Idempotency-Key", key)
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create with key %q status = %d, want 201", key, rec.Code)
+		}
+	}
+
+	subs, err := st.ListSubmissionsByService(context.Background(), svc.ID)
+	if err != nil {
+		t.Fatalf("ListSubmissionsByService() error = %v", err)
+	}
+	if len(subs) != 2 {
+		t.Fatalf("len(subs) = %d, want 2", len(subs))
+	}
+}
+
+func TestCreateSubmissionConcurrentRetriesWithSameIdempotencyKeyCreateOneSubmission(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionDraft})
+
+	const attempts = 8
+	var wg sync.WaitGroup
+	codes := make([]int, attempts)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body))
+			req.Header.Set("Idempotency-Key", "concurrent-retry-key")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			codes[i] = rec.Code
+		}(i)
+	}
+	wg.Wait()
+
+	var created int
+	for _, code := range codes {
+		switch code {
+		case http.StatusCreated:
+			created++
+		case http.StatusOK:
+		default:
+			t.Fatalf("unexpected status %d among concurrent retries", code)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("created count = %d, want exactly 1 of %d concurrent retries to win", created, attempts)
+	}
+
+	subs, err := st.ListSubmissionsByService(context.Background(), svc.ID)
+	if err != nil {
+		t.Fatalf("ListSubmissionsByService() error = %v", err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("len(subs) = %d, want 1 (no duplicate submission from the losing retries)", len(subs))
+	}
+}
+
+func TestCreateSubmissionRejectsCrossTenantService(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	svcReq := httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody))
+	svcReq.Header.Set(tenantHeader, "tenant-a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, svcReq)
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionDraft})
+	req := httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body))
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetSubmissionRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.FixedClock{At: mustParseTime(t, "2024-01-01T00:00:00Z")}, &prr.SequentialIDGenerator{}, nil)
+	a.DisableStrictValidation = true
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	svcReq := httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody))
+	svcReq.Header.Set(tenantHeader, "tenant-a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, svcReq)
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	body, _ := json.Marshal(createSubmissionRequest{ServiceID: svc.ID, Status: prr.SubmissionDraft})
+	subReq := httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(body))
+	subReq.Header.Set(tenantHeader, "tenant-a")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, subReq)
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+
+	getReq := httptest.NewRequest(http.MethodGet, "/submissions/"+sub.ID, nil)
+	getReq.Header.Set(tenantHeader, "tenant-b")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, getReq)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("get status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+
+	updateBody, _ := json.Marshal(updateSubmissionRequest{Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}})
+	updateReq := httptest.NewRequest(http.MethodPut, "/submissions/"+sub.ID, bytes.NewReader(updateBody))
+	updateReq.Header.Set(tenantHeader, "tenant-b")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, updateReq)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("update status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+
+	finalizeReq := httptest.NewRequest(http.MethodPost, "/submissions/"+sub.ID+"/finalize", nil)
+	finalizeReq.Header.Set(tenantHeader, "tenant-b")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, finalizeReq)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("finalize status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func mustParseTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("time.Parse(%q) error = %v", s, err)
+	}
+	return parsed
+}
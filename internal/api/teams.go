@@ -0,0 +1,191 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type createTeamRequest struct {
+	Name string `json:"name"`
+}
+
+type updateTeamRequest struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// handleTeams creates or lists teams.
+func (a *API) handleTeams(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req createTeamRequest
+		if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" {
+			writeAPIError(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		team := prr.Team{ID: a.IDGen.NewID(), Name: req.Name}
+		if err := a.Store.UpsertTeam(r.Context(), team); err != nil {
+			writeAPIError(w, "failed to create team", http.StatusInternalServerError)
+			return
+		}
+		a.recordAudit(r.Context(), "team", team.ID, prr.AuditActionCreate, nil, team)
+		writeJSON(w, http.StatusCreated, team)
+	case http.MethodGet:
+		teams, err := a.Store.ListTeams(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to list teams", http.StatusInternalServerError)
+			return
+		}
+		sort.Slice(teams, func(i, j int) bool { return teams[i].ID < teams[j].ID })
+		writeJSON(w, http.StatusOK, teams)
+	case http.MethodPut:
+		a.handleUpdateTeam(w, r)
+	case http.MethodDelete:
+		deprecated(func(w http.ResponseWriter, r *http.Request) {
+			a.handleDeleteTeam(w, r, r.URL.Query().Get("id"))
+		})(w, r)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleUpdateTeam renames an existing team. It refuses to create a new
+// one under this verb so clients don't accidentally mint unexpected IDs
+// by misspelling one in a PUT.
+func (a *API) handleUpdateTeam(w http.ResponseWriter, r *http.Request) {
+	var req updateTeamRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	before, err := a.Store.GetTeam(r.Context(), req.ID)
+	if err != nil {
+		writeStoreError(w, err, "team")
+		return
+	}
+
+	team := prr.Team{ID: req.ID, Name: req.Name}
+	if err := a.Store.UpsertTeam(r.Context(), team); err != nil {
+		writeAPIError(w, "failed to update team", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "team", team.ID, prr.AuditActionUpdate, before, team)
+	writeJSON(w, http.StatusOK, team)
+}
+
+// handleDeleteTeam deletes the team identified by id. Services already
+// assigned to it via OwnerTeam keep that value, matching how deleting a
+// Section leaves orphaned references on its own questions (only
+// DeleteSection's stricter force semantics cascade); a deleted team
+// simply stops resolving at GET /teams/{id}/readiness.
+func (a *API) handleDeleteTeam(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	before, err := a.Store.GetTeam(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "team")
+		return
+	}
+	if err := a.Store.DeleteTeam(r.Context(), id); err != nil {
+		writeStoreError(w, err, "team")
+		return
+	}
+	a.recordAudit(r.Context(), "team", id, prr.AuditActionDelete, before, nil)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// teamReadinessEntry is one service's latest readiness within a team
+// rollup.
+type teamReadinessEntry struct {
+	ServiceID     string    `json:"service_id"`
+	ServiceName   string    `json:"service_name"`
+	SubmissionID  string    `json:"submission_id,omitempty"`
+	Percent       float64   `json:"percent,omitempty"`
+	Grade         prr.Grade `json:"grade,omitempty"`
+	NoSubmissions bool      `json:"no_submissions,omitempty"`
+}
+
+type teamReadinessResponse struct {
+	TeamID   string               `json:"team_id"`
+	TeamName string               `json:"team_name"`
+	Services []teamReadinessEntry `json:"services"`
+}
+
+// handleTeamReadiness aggregates the latest PRR grade for every service
+// assigned to the team identified by id, so an engineering manager can
+// see the whole team's readiness in one call instead of walking each
+// service individually.
+func (a *API) handleTeamReadiness(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	team, err := a.Store.GetTeam(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "team")
+		return
+	}
+
+	services, err := a.visibleServices(r.Context(), resolveTenant(r))
+	if err != nil {
+		writeAPIError(w, "failed to list services", http.StatusInternalServerError)
+		return
+	}
+
+	thresholds, err := a.Store.GetScoringThresholds(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to load scoring config", http.StatusInternalServerError)
+		return
+	}
+
+	resp := teamReadinessResponse{TeamID: team.ID, TeamName: team.Name, Services: []teamReadinessEntry{}}
+	for _, svc := range services {
+		if svc.OwnerTeam != team.ID {
+			continue
+		}
+		subs, err := a.Store.ListSubmissionsByService(r.Context(), svc.ID)
+		if err != nil {
+			writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+			return
+		}
+		if len(subs) == 0 {
+			resp.Services = append(resp.Services, teamReadinessEntry{
+				ServiceID: svc.ID, ServiceName: svc.Name, NoSubmissions: true,
+			})
+			continue
+		}
+		sort.Slice(subs, func(i, j int) bool { return subs[i].CreatedAt.Before(subs[j].CreatedAt) })
+		latest := subs[len(subs)-1]
+
+		scoped, questions, err := a.scopeSubmissionToTemplate(r.Context(), latest)
+		if err != nil {
+			writeStoreError(w, err, "template")
+			return
+		}
+		score := prr.ComputeScore(scoped, questions)
+		grade := prr.ComputeGrade(score.Percent, thresholds)
+		resp.Services = append(resp.Services, teamReadinessEntry{
+			ServiceID:    svc.ID,
+			ServiceName:  svc.Name,
+			SubmissionID: latest.ID,
+			Percent:      score.Percent,
+			Grade:        grade,
+		})
+	}
+	sort.Slice(resp.Services, func(i, j int) bool { return resp.Services[i].ServiceID < resp.Services[j].ServiceID })
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestTeamCreateUpdateAndDelete(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]string{"name": "Payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/teams", bytes.NewReader(createBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var team prr.Team
+	json.Unmarshal(rec.Body.Bytes(), &team)
+
+	updateBody, _ := json.Marshal(map[string]string{"id": team.ID, "name": "Payments Platform"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/teams", bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/teams/"+team.ID, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := st.GetTeam(httptest.NewRequest(http.MethodGet, "/", nil).Context(), team.ID); err == nil {
+		t.Fatalf("expected team to be deleted")
+	}
+}
+
+func TestTeamReadinessAggregatesLatestSubmissionsForOwnedServices(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	team := prr.Team{ID: "team-1", Name: "Payments"}
+	if err := st.UpsertTeam(ctx, team); err != nil {
+		t.Fatalf("upsert team: %v", err)
+	}
+	owned := prr.Service{ID: "svc-owned", Name: "checkout", OwnerTeam: team.ID}
+	other := prr.Service{ID: "svc-other", Name: "search", OwnerTeam: "team-2"}
+	unscored := prr.Service{ID: "svc-unscored", Name: "ledger", OwnerTeam: team.ID}
+	for _, svc := range []prr.Service{owned, other, unscored} {
+		if err := st.CreateService(ctx, svc); err != nil {
+			t.Fatalf("create service: %v", err)
+		}
+	}
+	older := prr.Submission{ID: "sub-1", ServiceID: owned.ID, CreatedAt: mustParseTime(t, "2024-01-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}}
+	newer := prr.Submission{ID: "sub-2", ServiceID: owned.ID, CreatedAt: mustParseTime(t, "2024-02-01T00:00:00Z"), Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}
+	if err := st.CreateSubmission(ctx, older); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+	if err := st.CreateSubmission(ctx, newer); err != nil {
+		t.Fatalf("create submission: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/teams/"+team.ID+"/readiness", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp teamReadinessResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(resp.Services) != 2 {
+		t.Fatalf("services = %d, want 2 (only svc-owned and svc-unscored)", len(resp.Services))
+	}
+	for _, entry := range resp.Services {
+		switch entry.ServiceID {
+		case owned.ID:
+			if entry.SubmissionID != "sub-2" {
+				t.Fatalf("owned latest submission = %s, want sub-2", entry.SubmissionID)
+			}
+		case unscored.ID:
+			if !entry.NoSubmissions {
+				t.Fatalf("unscored service should be reported with no_submissions=true")
+			}
+		default:
+			t.Fatalf("unexpected service %s in rollup", entry.ServiceID)
+		}
+	}
+}
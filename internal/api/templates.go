@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type createTemplateRequest struct {
+	Name        string   `json:"name"`
+	SectionIDs  []string `json:"section_ids,omitempty"`
+	QuestionIDs []string `json:"question_ids"`
+}
+
+type updateTemplateRequest struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	SectionIDs  []string `json:"section_ids,omitempty"`
+	QuestionIDs []string `json:"question_ids"`
+}
+
+// handleTemplates creates or lists templates.
+func (a *API) handleTemplates(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req createTemplateRequest
+		if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.Name == "" {
+			writeAPIError(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		tmpl := prr.Template{
+			ID:          a.IDGen.NewID(),
+			Name:        req.Name,
+			SectionIDs:  req.SectionIDs,
+			QuestionIDs: req.QuestionIDs,
+		}
+		if err := a.Store.UpsertTemplate(r.Context(), tmpl); err != nil {
+			writeAPIError(w, "failed to create template", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusCreated, tmpl)
+	case http.MethodGet:
+		templates, err := a.Store.ListTemplates(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to list templates", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, templates)
+	case http.MethodPut:
+		a.handleUpdateTemplate(w, r)
+	case http.MethodDelete:
+		deprecated(func(w http.ResponseWriter, r *http.Request) {
+			a.handleDeleteTemplate(w, r, r.URL.Query().Get("id"))
+		})(w, r)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleUpdateTemplate replaces an existing template's section/question
+// selection. It refuses to create a new one under this verb so clients
+// don't accidentally mint unexpected IDs by misspelling one in a PUT.
+func (a *API) handleUpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req updateTemplateRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := a.Store.GetTemplate(r.Context(), req.ID); err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+
+	tmpl := prr.Template{
+		ID:          req.ID,
+		Name:        req.Name,
+		SectionIDs:  req.SectionIDs,
+		QuestionIDs: req.QuestionIDs,
+	}
+	if err := a.Store.UpsertTemplate(r.Context(), tmpl); err != nil {
+		writeAPIError(w, "failed to update template", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, tmpl)
+}
+
+// handleDeleteTemplate deletes the template identified by id.
+func (a *API) handleDeleteTemplate(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	if err := a.Store.DeleteTemplate(r.Context(), id); err != nil {
+		writeStoreError(w, err, "template")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestTemplateCRUD(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]interface{}{"name": "Batch job", "question_ids": []string{"q1"}})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/templates", bytes.NewReader(createBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var tmpl prr.Template
+	json.Unmarshal(rec.Body.Bytes(), &tmpl)
+
+	updateBody, _ := json.Marshal(map[string]interface{}{"id": tmpl.ID, "name": "Batch job", "question_ids": []string{"q1", "q2"}})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/templates", bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/templates?id="+tmpl.ID, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if _, err := st.GetTemplate(context.Background(), tmpl.ID); err == nil {
+		t.Fatalf("expected template to be deleted")
+	}
+}
+
+func TestSubmissionScoringScopedToTemplate(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	// Answering q2, which isn't in the template, would otherwise be
+	// rejected by strict validation; disable it here to isolate scoring
+	// scoping from answer validation, which has its own tests.
+	a.DisableStrictValidation = true
+	ctx := context.Background()
+
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", Text: "in template"}); err != nil {
+		t.Fatalf("seed q1: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q2", Text: "not in template"}); err != nil {
+		t.Fatalf("seed q2: %v", err)
+	}
+	if err := st.UpsertTemplate(ctx, prr.Template{ID: "tmpl-1", Name: "Batch job", QuestionIDs: []string{"q1"}}); err != nil {
+		t.Fatalf("seed template: %v", err)
+	}
+
+	router := a.Router()
+	createBody, _ := json.Marshal(map[string]string{"name": "svc"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(createBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	subBody, _ := json.Marshal(map[string]interface{}{
+		"service_id":  svc.ID,
+		"template_id": "tmpl-1",
+		"answers": []prr.Answer{
+			{QuestionID: "q1", Value: "yes"},
+			{QuestionID: "q2", Value: "no"},
+		},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/submissions", bytes.NewReader(subBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create submission status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var sub prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &sub)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/submissions/"+sub.ID+"/score/explain", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("score explain status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var score prr.Score
+	json.Unmarshal(rec.Body.Bytes(), &score)
+	if score.MaxPoints != 1 {
+		t.Fatalf("MaxPoints = %d, want 1 (q2 excluded by template)", score.MaxPoints)
+	}
+	if len(score.Breakdown) != 1 || score.Breakdown[0].QuestionID != "q1" {
+		t.Fatalf("Breakdown = %+v, want only q1", score.Breakdown)
+	}
+}
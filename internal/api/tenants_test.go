@@ -0,0 +1,204 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestTenantCreateUpdateAndDelete(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]string{"name": "Acme Corp"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/tenants", bytes.NewReader(createBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var tenant prr.Tenant
+	json.Unmarshal(rec.Body.Bytes(), &tenant)
+
+	updateBody, _ := json.Marshal(map[string]string{"id": tenant.ID, "name": "Acme Corporation"})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/tenants", bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/tenants/"+tenant.ID, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	if _, err := st.GetTenant(httptest.NewRequest(http.MethodGet, "/", nil).Context(), tenant.ID); err == nil {
+		t.Fatalf("expected tenant to be deleted")
+	}
+}
+
+func TestListServicesIsScopedToResolvedTenant(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createIn := func(tenant string) prr.Service {
+		req := httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(mustJSON(t, map[string]string{"name": "svc-" + tenant})))
+		if tenant != "" {
+			req.Header.Set(tenantHeader, tenant)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+		}
+		var svc prr.Service
+		json.Unmarshal(rec.Body.Bytes(), &svc)
+		return svc
+	}
+
+	none := createIn("")
+	tenantA := createIn("tenant-a")
+	createIn("tenant-b")
+
+	listAs := func(tenant string) []prr.Service {
+		req := httptest.NewRequest(http.MethodGet, "/services", nil)
+		if tenant != "" {
+			req.Header.Set(tenantHeader, tenant)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("list status = %d, body=%s", rec.Code, rec.Body.String())
+		}
+		var services []prr.Service
+		json.Unmarshal(rec.Body.Bytes(), &services)
+		return services
+	}
+
+	if got := listAs(""); len(got) != 1 || got[0].ID != none.ID {
+		t.Fatalf("no-tenant caller sees %+v, want only %s", got, none.ID)
+	}
+	if got := listAs("tenant-a"); len(got) != 1 || got[0].ID != tenantA.ID {
+		t.Fatalf("tenant-a caller sees %+v, want only %s", got, tenantA.ID)
+	}
+}
+
+func TestExpiryAndDashboardAggregatesAreScopedToResolvedTenant(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-a", Name: "svc-a", TenantID: "tenant-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-b", Name: "svc-b", TenantID: "tenant-b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	getAs := func(path, tenant string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if tenant != "" {
+			req.Header.Set(tenantHeader, tenant)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := getAs("/prr/stale", "tenant-a")
+	var stale []staleService
+	json.Unmarshal(rec.Body.Bytes(), &stale)
+	if len(stale) != 1 || stale[0].Service.ID != "svc-a" {
+		t.Fatalf("/prr/stale as tenant-a = %+v, want only svc-a", stale)
+	}
+
+	rec = getAs("/dashboard/summary", "tenant-a")
+	var summary dashboardSummary
+	json.Unmarshal(rec.Body.Bytes(), &summary)
+	if summary.ServicesWithoutPRR != 1 {
+		t.Fatalf("/dashboard/summary as tenant-a ServicesWithoutPRR = %d, want 1 (svc-b must not count)", summary.ServicesWithoutPRR)
+	}
+}
+
+func TestSectionAnalyticsIsScopedToResolvedTenant(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-a", Name: "svc-a", TenantID: "tenant-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-b", Name: "svc-b", TenantID: "tenant-b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-a", ServiceID: "svc-a", CreatedAt: a.Clock.Now(), Answers: []prr.Answer{{QuestionID: "q1", Value: "no"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateSubmission(ctx, prr.Submission{ID: "sub-b", ServiceID: "svc-b", CreatedAt: a.Clock.Now(), Answers: []prr.Answer{{QuestionID: "q1", Value: "yes"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/analytics/sections?section_id=sec1", nil)
+	req.Header.Set(tenantHeader, "tenant-a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp sectionAnalyticsResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp.Questions) != 1 || resp.Questions[0].Latest.No != 1 || resp.Questions[0].Latest.Yes != 0 {
+		t.Fatalf("section analytics as tenant-a = %+v, want only svc-a's no answer counted", resp.Questions)
+	}
+}
+
+func TestTeamReadinessIsScopedToResolvedTenant(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+	ctx := httptest.NewRequest(http.MethodGet, "/", nil).Context()
+
+	if err := st.UpsertTeam(ctx, prr.Team{ID: "team-1", Name: "Payments"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-a", Name: "svc-a", OwnerTeam: "team-1", TenantID: "tenant-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.CreateService(ctx, prr.Service{ID: "svc-b", Name: "svc-b", OwnerTeam: "team-1", TenantID: "tenant-b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/teams/team-1/readiness", nil)
+	req.Header.Set(tenantHeader, "tenant-a")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var resp teamReadinessResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp.Services) != 1 || resp.Services[0].ServiceID != "svc-a" {
+		t.Fatalf("team readiness as tenant-a = %+v, want only svc-a", resp.Services)
+	}
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return b
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// handleQuestionTranslation sets or clears the translation of the
+// question identified by id for the given locale, leaving its base
+// Text/Blurb and every other locale's translation untouched.
+func (a *API) handleQuestionTranslation(w http.ResponseWriter, r *http.Request, id, locale string) {
+	before, err := a.Store.GetQuestion(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "question")
+		return
+	}
+	q := before
+	switch r.Method {
+	case http.MethodPut:
+		var t prr.QuestionTranslation
+		if err := jsonvalidate.Decode(r.Body, &t); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if t.Text == "" {
+			writeAPIError(w, "text is required", http.StatusBadRequest)
+			return
+		}
+		if q.Translations == nil {
+			q.Translations = make(map[string]prr.QuestionTranslation)
+		} else {
+			q.Translations = copyQuestionTranslations(q.Translations)
+		}
+		q.Translations[locale] = t
+	case http.MethodDelete:
+		if _, ok := q.Translations[locale]; !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		q.Translations = copyQuestionTranslations(q.Translations)
+		delete(q.Translations, locale)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := a.Store.UpsertQuestion(r.Context(), q); err != nil {
+		writeAPIError(w, "failed to save translation", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "question", q.ID, prr.AuditActionUpdate, before, q)
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+func copyQuestionTranslations(in map[string]prr.QuestionTranslation) map[string]prr.QuestionTranslation {
+	out := make(map[string]prr.QuestionTranslation, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// handleSectionTranslation sets or clears the translation of the
+// section identified by id for the given locale, leaving its base Name
+// and every other locale's translation untouched.
+func (a *API) handleSectionTranslation(w http.ResponseWriter, r *http.Request, id, locale string) {
+	before, err := a.Store.GetSection(r.Context(), id)
+	if err != nil {
+		writeStoreError(w, err, "section")
+		return
+	}
+	sec := before
+	switch r.Method {
+	case http.MethodPut:
+		var t prr.SectionTranslation
+		if err := jsonvalidate.Decode(r.Body, &t); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if t.Name == "" {
+			writeAPIError(w, "name is required", http.StatusBadRequest)
+			return
+		}
+		if sec.Translations == nil {
+			sec.Translations = make(map[string]prr.SectionTranslation)
+		} else {
+			sec.Translations = copySectionTranslations(sec.Translations)
+		}
+		sec.Translations[locale] = t
+	case http.MethodDelete:
+		if _, ok := sec.Translations[locale]; !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		sec.Translations = copySectionTranslations(sec.Translations)
+		delete(sec.Translations, locale)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := a.Store.UpsertSection(r.Context(), sec); err != nil {
+		writeAPIError(w, "failed to save translation", http.StatusInternalServerError)
+		return
+	}
+	a.recordAudit(r.Context(), "section", sec.ID, prr.AuditActionUpdate, before, sec)
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, sec)
+}
+
+func copySectionTranslations(in map[string]prr.SectionTranslation) map[string]prr.SectionTranslation {
+	out := make(map[string]prr.SectionTranslation, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestReviewFormLocalizesTextWithFallback(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertSection(ctx, prr.Section{
+		ID: "sec-1", Name: "Security",
+		Translations: map[string]prr.SectionTranslation{"fr": {Name: "Sécurité"}},
+	}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertQuestion(ctx, prr.Question{
+		ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?",
+		Translations: map[string]prr.QuestionTranslation{"fr": {Text: "L'authentification est-elle appliquée ?"}},
+	}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/prr/form?lang=fr", nil))
+	var form []formSection
+	json.Unmarshal(rec.Body.Bytes(), &form)
+	if len(form) != 1 || form[0].Name != "Sécurité" || form[0].Questions[0].Text != "L'authentification est-elle appliquée ?" {
+		t.Fatalf("unexpected localized form: %+v", form)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/prr/form", nil)
+	req.Header.Set("Accept-Language", "de")
+	router.ServeHTTP(rec, req)
+	form = nil
+	json.Unmarshal(rec.Body.Bytes(), &form)
+	if len(form) != 1 || form[0].Name != "Security" || form[0].Questions[0].Text != "Is auth enforced?" {
+		t.Fatalf("untranslated locale should fall back to base text: %+v", form)
+	}
+}
+
+func TestQuestionTranslationPutAndDelete(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	body, _ := json.Marshal(prr.QuestionTranslation{Text: "L'authentification est-elle appliquée ?"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/questions/q1/translations/fr", bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("put status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var q prr.Question
+	json.Unmarshal(rec.Body.Bytes(), &q)
+	if q.Text != "Is auth enforced?" || q.Translations["fr"].Text == "" {
+		t.Fatalf("unexpected question after translation put: %+v", q)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/questions/q1/translations/fr", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	got, err := st.GetQuestion(ctx, "q1")
+	if err != nil {
+		t.Fatalf("get question: %v", err)
+	}
+	if _, ok := got.Translations["fr"]; ok {
+		t.Fatalf("translation still present after delete: %+v", got.Translations)
+	}
+}
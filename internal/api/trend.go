@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+const defaultTrendLimit = 10
+
+// trendPoint is a single submission's place in a service's readiness
+// trend: its overall score and grade at the time it was created.
+type trendPoint struct {
+	SubmissionID string    `json:"submission_id"`
+	CreatedAt    string    `json:"created_at"`
+	Percent      float64   `json:"percent"`
+	Grade        prr.Grade `json:"grade"`
+}
+
+// questionTimelineEntry is one answer to a single question, as it
+// appeared in one submission along the trend.
+type questionTimelineEntry struct {
+	SubmissionID string `json:"submission_id"`
+	CreatedAt    string `json:"created_at"`
+	Value        string `json:"value"`
+}
+
+type trendResponse struct {
+	ServiceID string                             `json:"service_id"`
+	Points    []trendPoint                       `json:"points"`
+	Questions map[string][]questionTimelineEntry `json:"questions"`
+}
+
+// handleTrend returns a time-series of overall score/grade and
+// per-question answers across a service's last N submissions, oldest
+// first, so a team can see at a glance whether readiness is trending up
+// or down rather than only comparing two points in time.
+func (a *API) handleTrend(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	serviceID := r.URL.Query().Get("service_id")
+	if serviceID == "" {
+		writeAPIError(w, "service_id is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := a.authorizedService(r.Context(), r, serviceID); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+
+	limit := queryInt(r, "limit", defaultTrendLimit)
+	if limit <= 0 || limit > maxHistoryLimit {
+		limit = defaultTrendLimit
+	}
+
+	all, err := a.Store.ListSubmissionsByService(r.Context(), serviceID)
+	if err != nil {
+		writeAPIError(w, "failed to list submissions", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].CreatedAt.Before(all[j].CreatedAt)
+	})
+	if len(all) > limit {
+		all = all[len(all)-limit:]
+	}
+
+	thresholds, err := a.Store.GetScoringThresholds(r.Context())
+	if err != nil {
+		writeAPIError(w, "failed to load scoring config", http.StatusInternalServerError)
+		return
+	}
+
+	resp := trendResponse{
+		ServiceID: serviceID,
+		Points:    make([]trendPoint, 0, len(all)),
+		Questions: make(map[string][]questionTimelineEntry),
+	}
+	for _, sub := range all {
+		scoped, questions, err := a.scopeSubmissionToTemplate(r.Context(), sub)
+		if err != nil {
+			writeStoreError(w, err, "template")
+			return
+		}
+		score := prr.ComputeScore(scoped, questions)
+		grade := prr.ComputeGrade(score.Percent, thresholds)
+
+		resp.Points = append(resp.Points, trendPoint{
+			SubmissionID: sub.ID,
+			CreatedAt:    sub.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Percent:      score.Percent,
+			Grade:        grade,
+		})
+		for _, ans := range sub.Answers {
+			resp.Questions[ans.QuestionID] = append(resp.Questions[ans.QuestionID], questionTimelineEntry{
+				SubmissionID: sub.ID,
+				CreatedAt:    sub.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+				Value:        ans.Value,
+			})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
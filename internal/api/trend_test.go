@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestHandleTrendReturnsTimeSeries(t *testing.T) {
+	st := memory.New()
+	idGen := &prr.SequentialIDGenerator{}
+	a := New(st, prr.SystemClock{}, idGen, nil)
+	a.DisableStrictValidation = true
+
+	ctx := context.Background()
+	if err := st.UpsertQuestion(ctx, prr.Question{ID: "q1"}); err != nil {
+		t.Fatal(err)
+	}
+	svc := prr.Service{ID: idGen.NewID(), Name: "svc"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	values := []string{"no", "yes"}
+	for i, v := range values {
+		sub := prr.Submission{
+			ID:        idGen.NewID(),
+			ServiceID: svc.ID,
+			Answers:   []prr.Answer{{QuestionID: "q1", Value: v}},
+			CreatedAt: time.Date(2024, 1, i+1, 0, 0, 0, 0, time.UTC),
+		}
+		if err := st.CreateSubmission(ctx, sub); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/trend?service_id="+svc.ID, nil)
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200, body=%s", rec.Code, rec.Body.String())
+	}
+
+	var resp trendResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Points) != 2 {
+		t.Fatalf("len(Points) = %d, want 2", len(resp.Points))
+	}
+	if resp.Points[0].Percent != 0 || resp.Points[1].Percent != 100 {
+		t.Fatalf("Points = %+v, want [0, 100]", resp.Points)
+	}
+	timeline := resp.Questions["q1"]
+	if len(timeline) != 2 || timeline[0].Value != "no" || timeline[1].Value != "yes" {
+		t.Fatalf("Questions[q1] = %+v, want [no, yes]", timeline)
+	}
+}
+
+func TestHandleTrendRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	req := httptest.NewRequest(http.MethodGet, "/prr/trend?service_id="+svc.ID, nil)
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandleTrendRequiresServiceID(t *testing.T) {
+	a := New(memory.New(), prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/prr/trend", nil)
+	rec := httptest.NewRecorder()
+	a.Router().ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", rec.Code)
+	}
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/auth"
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type unlockSubmissionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// handleUnlockSubmission clears Locked on the submission identified by
+// id, restoring Store.CreateSubmission/CreateAttachment's willingness
+// to accept amendments, partial resubmissions, and attachments against
+// it. Unlocking is gated to auth.RoleAdmin by the router: lifting the
+// immutability an approval earned is rare and consequential enough that
+// reviewers, who can grant the approval that locks a submission, should
+// not also be able to undo its effect unilaterally. The caller's reason
+// is required and recorded both on the submission (see prr.Unlock) and
+// in the audit log.
+func (a *API) handleUnlockSubmission(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req unlockSubmissionRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Reason == "" {
+		writeAPIError(w, "reason is required", http.StatusBadRequest)
+		return
+	}
+
+	sub, err := a.authorizedSubmission(r.Context(), r, id)
+	if err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	if !sub.Locked {
+		writeAPIError(w, "submission is not locked", http.StatusConflict)
+		return
+	}
+	before := sub
+
+	admin := "anonymous"
+	if principal, ok := auth.FromContext(r.Context()); ok {
+		admin = principal.Subject
+	}
+
+	sub.Locked = false
+	sub.LastUnlock = &prr.Unlock{By: admin, Reason: req.Reason, At: a.Clock.Now()}
+	sub.UpdatedAt = a.Clock.Now()
+
+	if err := a.Store.UpdateSubmission(r.Context(), sub); err != nil {
+		writeStoreError(w, err, "submission")
+		return
+	}
+	a.recordAudit(r.Context(), "submission", sub.ID, prr.AuditActionUpdate, before, sub)
+
+	writeJSON(w, http.StatusOK, sub)
+}
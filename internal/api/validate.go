@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type validateSubmissionRequest struct {
+	ServiceID  string       `json:"service_id"`
+	TemplateID string       `json:"template_id,omitempty"`
+	Answers    []prr.Answer `json:"answers"`
+}
+
+// validationReport is the result of a pre-submit check: whether the
+// payload would be accepted by handleCreateSubmission, and if not, why.
+type validationReport struct {
+	Valid bool `json:"valid"`
+	// Errors lists unknown question IDs, archived or hidden questions,
+	// type mismatches and the other per-answer problems ValidateAnswers
+	// checks for.
+	Errors []prr.ValidationError `json:"errors,omitempty"`
+	// MissingEssentialQuestions lists essential questions in the chosen
+	// template (or the whole question bank, if none) that weren't
+	// answered at all. These don't fail ValidateAnswers on their own,
+	// since answering is optional server-side, but leaving one
+	// unanswered means the submission can't come out release-ready.
+	MissingEssentialQuestions []string `json:"missing_essential_questions,omitempty"`
+}
+
+// handleValidateSubmission runs the same validation handleCreateSubmission
+// would, plus a check for unanswered essential questions, without
+// persisting anything. It lets a client show a complete list of problems
+// before the user attempts a final submit.
+func (a *API) handleValidateSubmission(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req validateSubmissionRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ServiceID == "" {
+		writeAPIError(w, "service_id is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := a.authorizedService(r.Context(), r, req.ServiceID); err != nil {
+		writeStoreError(w, err, "service")
+		return
+	}
+	if req.TemplateID != "" {
+		if _, err := a.Store.GetTemplate(r.Context(), req.TemplateID); err != nil {
+			writeStoreError(w, err, "template")
+			return
+		}
+	}
+
+	questions, err := a.questionsForTemplateID(r.Context(), req.TemplateID)
+	if err != nil {
+		writeAPIError(w, "failed to validate answers", http.StatusInternalServerError)
+		return
+	}
+
+	report := validationReport{
+		Errors:                    prr.ValidateAnswers(req.Answers, questions),
+		MissingEssentialQuestions: prr.MissingEssentialQuestions(prr.Submission{TemplateID: req.TemplateID, Answers: req.Answers}, questions),
+	}
+	report.Valid = len(report.Errors) == 0 && len(report.MissingEssentialQuestions) == 0
+
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestValidateSubmissionReportsMissingEssentialQuestion(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	if err := st.UpsertSection(context.Background(), prr.Section{ID: "sec-1", Name: "Security"}); err != nil {
+		t.Fatalf("upsert section: %v", err)
+	}
+	if err := st.UpsertQuestion(context.Background(), prr.Question{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?", IsEssential: true}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	validateBody, _ := json.Marshal(validateSubmissionRequest{ServiceID: svc.ID})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/validate", bytes.NewReader(validateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("validate status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var report validationReport
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	if report.Valid {
+		t.Fatalf("report.Valid = true, want false (q1 is unanswered and essential)")
+	}
+	if len(report.MissingEssentialQuestions) != 1 || report.MissingEssentialQuestions[0] != "q1" {
+		t.Fatalf("missing essential questions = %v, want [q1]", report.MissingEssentialQuestions)
+	}
+
+	validateBody, _ = json.Marshal(validateSubmissionRequest{
+		ServiceID: svc.ID,
+		Answers:   []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/validate", bytes.NewReader(validateBody)))
+	report = validationReport{}
+	json.Unmarshal(rec.Body.Bytes(), &report)
+	if !report.Valid {
+		t.Fatalf("report.Valid = false after answering q1, errors=%v missing=%v", report.Errors, report.MissingEssentialQuestions)
+	}
+
+	// Nothing should have been persisted: no submissions exist for the service.
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/services/"+svc.ID+"/submissions", nil))
+	var history []prr.Submission
+	json.Unmarshal(rec.Body.Bytes(), &history)
+	if len(history) != 0 {
+		t.Fatalf("len(history) = %d, want 0: /prr/validate must not persist anything", len(history))
+	}
+}
+
+func TestValidateSubmissionRejectsCrossTenantCaller(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svc := prr.Service{ID: "svc-1", Name: "payments", TenantID: "tenant-a"}
+	if err := st.CreateService(ctx, svc); err != nil {
+		t.Fatalf("create service: %v", err)
+	}
+
+	validateBody, _ := json.Marshal(validateSubmissionRequest{ServiceID: svc.ID})
+	req := httptest.NewRequest(http.MethodPost, "/prr/validate", bytes.NewReader(validateBody))
+	req.Header.Set(tenantHeader, "tenant-b")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404, body=%s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestValidateSubmissionRejectsUnknownQuestion(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	svcBody, _ := json.Marshal(map[string]string{"name": "payments"})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/services", bytes.NewReader(svcBody)))
+	var svc prr.Service
+	json.Unmarshal(rec.Body.Bytes(), &svc)
+
+	validateBody, _ := json.Marshal(validateSubmissionRequest{
+		ServiceID: svc.ID,
+		Answers:   []prr.Answer{{QuestionID: "does-not-exist", Value: "yes"}},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prr/validate", bytes.NewReader(validateBody)))
+	var report validationReport
+	json.Unmarshal(rec.Body.Bytes(), &report)
+	if report.Valid {
+		t.Fatalf("report.Valid = true, want false for unknown question id")
+	}
+	if len(report.Errors) != 1 || report.Errors[0].QuestionID != "does-not-exist" {
+		t.Fatalf("errors = %+v, want one error for does-not-exist", report.Errors)
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"context"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// stampAnswerVersions records, on each answer, the Version, Text and
+// SectionID of the question it was answered against at submission time.
+// It overwrites anything the client sent for these fields, since only
+// the server knows the question bank's current state. Snapshotting Text
+// and SectionID keeps a submission's answers readable by ID even after
+// the question itself is later hard-deleted.
+func (a *API) stampAnswerVersions(ctx context.Context, answers []prr.Answer) ([]prr.Answer, error) {
+	questions, err := a.Store.ListQuestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]prr.Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+
+	stamped := make([]prr.Answer, len(answers))
+	for i, ans := range answers {
+		q := byID[ans.QuestionID]
+		ans.QuestionVersion = q.Version
+		ans.QuestionText = q.Text
+		ans.SectionID = q.SectionID
+		stamped[i] = ans
+	}
+	return stamped, nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Eambo/vxcvxvc/internal/jsonvalidate"
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+type createWebhookRequest struct {
+	URL    string             `json:"url"`
+	Events []prr.WebhookEvent `json:"events"`
+	Secret string             `json:"secret,omitempty"`
+}
+
+type updateWebhookRequest struct {
+	ID     string             `json:"id"`
+	URL    string             `json:"url"`
+	Events []prr.WebhookEvent `json:"events"`
+	Secret string             `json:"secret,omitempty"`
+}
+
+// handleWebhooks creates or lists webhooks.
+func (a *API) handleWebhooks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req createWebhookRequest
+		if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+			writeAPIError(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		if req.URL == "" {
+			writeAPIError(w, "url is required", http.StatusBadRequest)
+			return
+		}
+		if len(req.Events) == 0 {
+			writeAPIError(w, "events is required", http.StatusBadRequest)
+			return
+		}
+		hook := prr.Webhook{
+			ID:     a.IDGen.NewID(),
+			URL:    req.URL,
+			Events: req.Events,
+			Secret: req.Secret,
+		}
+		if err := a.Store.CreateWebhook(r.Context(), hook); err != nil {
+			writeAPIError(w, "failed to create webhook", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusCreated, hook)
+	case http.MethodGet:
+		hooks, err := a.Store.ListWebhooks(r.Context())
+		if err != nil {
+			writeAPIError(w, "failed to list webhooks", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, http.StatusOK, hooks)
+	case http.MethodPut:
+		a.handleUpdateWebhook(w, r)
+	case http.MethodDelete:
+		deprecated(func(w http.ResponseWriter, r *http.Request) {
+			a.handleDeleteWebhook(w, r, r.URL.Query().Get("id"))
+		})(w, r)
+	default:
+		writeAPIError(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleUpdateWebhook replaces an existing webhook's URL, events and
+// secret. It refuses to create a new one under this verb so clients don't
+// accidentally mint unexpected IDs by misspelling one in a PUT.
+func (a *API) handleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req updateWebhookRequest
+	if err := jsonvalidate.Decode(r.Body, &req); err != nil {
+		writeAPIError(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := a.Store.GetWebhook(r.Context(), req.ID); err != nil {
+		writeStoreError(w, err, "webhook")
+		return
+	}
+
+	hook := prr.Webhook{
+		ID:     req.ID,
+		URL:    req.URL,
+		Events: req.Events,
+		Secret: req.Secret,
+	}
+	if err := a.Store.CreateWebhook(r.Context(), hook); err != nil {
+		writeAPIError(w, "failed to update webhook", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, hook)
+}
+
+// handleDeleteWebhook deletes the webhook identified by id.
+func (a *API) handleDeleteWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	if id == "" {
+		writeAPIError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	if err := a.Store.DeleteWebhook(r.Context(), id); err != nil {
+		writeStoreError(w, err, "webhook")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// webhookPayload is the JSON body delivered to a subscribed webhook.
+type webhookPayload struct {
+	Event      prr.WebhookEvent `json:"event"`
+	Service    prr.Service      `json:"service"`
+	Submission prr.Submission   `json:"submission"`
+}
+
+// dispatchWebhookEvent best-effort delivers event to every webhook
+// subscribed to it, and publishes it to every GET /events subscriber
+// (see handleEvents) so a connected dashboard observes the same
+// lifecycle transitions live. Delivery happens in its own goroutine,
+// detached from ctx, since callers fire this from request handlers that
+// must not block the response on a slow or unreachable integrator URL;
+// a failed delivery is retried by WebhookDeliverer and, if it still
+// fails, recorded as a dead letter instead of being retried forever.
+func (a *API) dispatchWebhookEvent(ctx context.Context, event prr.WebhookEvent, svc prr.Service, sub prr.Submission) {
+	payload, err := json.Marshal(webhookPayload{Event: event, Service: svc, Submission: sub})
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("failed to marshal %s webhook payload: %v", event, err)
+		}
+		return
+	}
+	a.Events.publish(svc.TenantID, payload)
+
+	hooks, err := a.Store.ListWebhooks(ctx)
+	if err != nil {
+		if a.Logger != nil {
+			a.Logger.Printf("failed to list webhooks for %s event: %v", event, err)
+		}
+		return
+	}
+
+	for _, hook := range hooks {
+		if !hook.Subscribes(event) {
+			continue
+		}
+		go a.deliverWebhook(hook, event, payload)
+	}
+}
+
+// deliverWebhook delivers payload to hook and records the outcome,
+// marking it a dead letter once delivery has exhausted its retries.
+func (a *API) deliverWebhook(hook prr.Webhook, event prr.WebhookEvent, payload []byte) {
+	ctx := context.Background()
+	deliverErr := a.WebhookDeliverer.Deliver(ctx, hook.URL, hook.Secret, payload)
+
+	delivery := prr.WebhookDelivery{
+		ID:        a.IDGen.NewID(),
+		WebhookID: hook.ID,
+		Event:     event,
+		Payload:   string(payload),
+		Attempts:  1,
+		At:        a.Clock.Now(),
+	}
+	if deliverErr != nil {
+		delivery.Error = deliverErr.Error()
+		delivery.DeadLetter = true
+	}
+	if err := a.Store.RecordWebhookDelivery(ctx, delivery); err != nil && a.Logger != nil {
+		a.Logger.Printf("failed to record webhook delivery for %s: %v", hook.ID, err)
+	}
+	if deliverErr != nil && a.Logger != nil {
+		a.Logger.Printf("failed to deliver %s webhook to %s: %v", event, hook.URL, deliverErr)
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestWebhookCRUD(t *testing.T) {
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+	router := a.Router()
+
+	createBody, _ := json.Marshal(map[string]interface{}{
+		"url":    "https://example.com/hook",
+		"events": []string{"submission.created"},
+	})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks", bytes.NewReader(createBody)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	var hook prr.Webhook
+	json.Unmarshal(rec.Body.Bytes(), &hook)
+
+	updateBody, _ := json.Marshal(map[string]interface{}{
+		"id":     hook.ID,
+		"url":    "https://example.com/hook2",
+		"events": []string{"submission.created", "submission.approved"},
+	})
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/webhooks", bytes.NewReader(updateBody)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("update status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/webhooks?id="+hook.ID, nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("delete status = %d, body=%s", rec.Code, rec.Body.String())
+	}
+	if _, err := st.GetWebhook(context.Background(), hook.ID); err == nil {
+		t.Fatalf("expected webhook to be deleted")
+	}
+}
+
+func TestDispatchWebhookEventDeliversAndSigns(t *testing.T) {
+	var mu sync.Mutex
+	var gotSignature, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		gotSignature = r.Header.Get("X-PRR-Signature")
+		body := make([]byte, r.ContentLength)
+		r.Body.Read(body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	st := memory.New()
+	a := New(st, prr.SystemClock{}, &prr.SequentialIDGenerator{}, nil)
+
+	hook := prr.Webhook{ID: "hook-1", URL: srv.URL, Events: []prr.WebhookEvent{prr.WebhookEventSubmissionCreated}, Secret: "shh"}
+	if err := st.CreateWebhook(context.Background(), hook); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := prr.Service{ID: "svc-1", Name: "svc"}
+	sub := prr.Submission{ID: "sub-1", ServiceID: svc.ID}
+	a.dispatchWebhookEvent(context.Background(), prr.WebhookEventSubmissionCreated, svc, sub)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		delivered := gotBody != ""
+		mu.Unlock()
+		if delivered {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if gotSignature == "" {
+		t.Fatalf("expected a signature header to be set")
+	}
+	if gotBody == "" {
+		t.Fatalf("expected the webhook to receive a payload")
+	}
+
+	deliveries, err := st.ListWebhookDeliveries(context.Background(), hook.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 1 || deliveries[0].DeadLetter {
+		t.Fatalf("deliveries = %+v, want one successful delivery", deliveries)
+	}
+}
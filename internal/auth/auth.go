@@ -0,0 +1,90 @@
+// Package auth provides bearer-token authentication and role-based
+// authorization for the API.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Role identifies what a Principal is allowed to do.
+type Role string
+
+const (
+	// RoleRestricted is a read-only viewer that is never granted any
+	// other role's privileges; see HasRole. It exists for callers who
+	// should see a submission's scores and status but not the
+	// free-text detail (answer comments, evidence links) within it,
+	// redacted centrally by the API's response serialization rather
+	// than by individual handlers.
+	RoleRestricted Role = "restricted"
+	RoleSubmitter  Role = "submitter"
+	RoleReviewer   Role = "reviewer"
+	RoleAdmin      Role = "admin"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string
+	Role    Role
+	// TenantID scopes the principal to a tenant on a multi-tenant
+	// deployment. Empty means the principal isn't scoped to any tenant,
+	// which is the only state on a single-tenant deployment.
+	TenantID string
+}
+
+// HasRole reports whether the principal has at least the given role.
+// Admin implies reviewer and submitter; reviewer implies submitter.
+// Restricted sits below submitter and implies nothing else.
+func (p Principal) HasRole(want Role) bool {
+	rank := map[Role]int{RoleRestricted: -1, RoleSubmitter: 0, RoleReviewer: 1, RoleAdmin: 2}
+	return rank[p.Role] >= rank[want]
+}
+
+type contextKey int
+
+const principalKey contextKey = 0
+
+// WithPrincipal returns a context carrying the given principal.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// FromContext returns the principal attached to ctx, if any.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey).(Principal)
+	return p, ok
+}
+
+// TokenStore maps API tokens to the principal they authenticate.
+type TokenStore map[string]Principal
+
+// ParseTokens parses a TokenStore from the format
+// "token:subject:role[:tenant],token:subject:role[:tenant],...", as used
+// by the PRR_TOKENS environment variable. The tenant segment is optional
+// and omitted entirely on a single-tenant deployment.
+func ParseTokens(spec string) (TokenStore, error) {
+	tokens := make(TokenStore)
+	if spec == "" {
+		return tokens, nil
+	}
+	for _, entry := range strings.Split(spec, ",") {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 && len(parts) != 4 {
+			return nil, fmt.Errorf("auth: invalid token entry %q, want token:subject:role[:tenant]", entry)
+		}
+		token, subject, role := parts[0], parts[1], Role(parts[2])
+		switch role {
+		case RoleRestricted, RoleSubmitter, RoleReviewer, RoleAdmin:
+		default:
+			return nil, fmt.Errorf("auth: invalid role %q in entry %q", role, entry)
+		}
+		var tenantID string
+		if len(parts) == 4 {
+			tenantID = parts[3]
+		}
+		tokens[token] = Principal{Subject: subject, Role: role, TenantID: tenantID}
+	}
+	return tokens, nil
+}
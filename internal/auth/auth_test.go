@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTokens(t *testing.T) {
+	tokens, err := ParseTokens("tok1:alice:admin,tok2:bob:submitter")
+	if err != nil {
+		t.Fatalf("ParseTokens() error = %v", err)
+	}
+	if tokens["tok1"].Role != RoleAdmin || tokens["tok2"].Subject != "bob" {
+		t.Fatalf("ParseTokens() = %+v", tokens)
+	}
+}
+
+func TestParseTokensWithTenant(t *testing.T) {
+	tokens, err := ParseTokens("tok1:alice:admin:tenant-a,tok2:bob:submitter")
+	if err != nil {
+		t.Fatalf("ParseTokens() error = %v", err)
+	}
+	if tokens["tok1"].TenantID != "tenant-a" {
+		t.Fatalf("tok1 TenantID = %q, want tenant-a", tokens["tok1"].TenantID)
+	}
+	if tokens["tok2"].TenantID != "" {
+		t.Fatalf("tok2 TenantID = %q, want empty", tokens["tok2"].TenantID)
+	}
+}
+
+func TestParseTokensRejectsBadRole(t *testing.T) {
+	if _, err := ParseTokens("tok1:alice:superuser"); err == nil {
+		t.Fatal("ParseTokens() expected error for invalid role")
+	}
+}
+
+func TestMiddlewareAndRequireRole(t *testing.T) {
+	tokens, _ := ParseTokens("admintok:alice:admin,subtok:bob:submitter")
+	protected := RequireRole(RoleAdmin, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := Middleware(tokens, http.HandlerFunc(protected))
+
+	cases := []struct {
+		token string
+		want  int
+	}{
+		{"admintok", http.StatusOK},
+		{"subtok", http.StatusForbidden},
+		{"bogus", http.StatusUnauthorized},
+		{"", http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != c.want {
+			t.Errorf("token %q: status = %d, want %d", c.token, rec.Code, c.want)
+		}
+	}
+}
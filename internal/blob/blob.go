@@ -0,0 +1,25 @@
+// Package blob provides a pluggable binary storage abstraction for
+// submission attachments, decoupling the API from any particular
+// backend. DiskStore is the only implementation shipped here; a
+// network-backed one (S3 or similar) implements the same Store
+// interface and can be swapped in without changing callers.
+package blob
+
+import (
+	"context"
+	"io"
+)
+
+// Store puts, fetches and deletes opaque binary blobs by key. All
+// methods are safe for concurrent use.
+type Store interface {
+	// Put writes the contents of r under key, overwriting any existing
+	// blob with that key, and returns the number of bytes written.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Get returns a reader for the blob stored under key. The caller
+	// must Close it. It returns ErrNotFound if no blob exists for key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob stored under key. It returns ErrNotFound
+	// if no blob exists for key.
+	Delete(ctx context.Context, key string) error
+}
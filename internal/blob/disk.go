@@ -0,0 +1,82 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound is returned by Store methods when the requested blob does
+// not exist.
+var ErrNotFound = errors.New("blob: not found")
+
+// DiskStore stores blobs as files under a root directory. It's the
+// default backend for local development and single-node deployments
+// that don't want to run a separate object store.
+type DiskStore struct {
+	root string
+}
+
+// NewDiskStore returns a DiskStore rooted at dir, creating it if it
+// doesn't already exist.
+func NewDiskStore(dir string) (*DiskStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DiskStore{root: dir}, nil
+}
+
+// path rejects keys that could escape root via ".." or an absolute
+// path, since keys are caller-supplied attachment IDs, not trusted
+// filenames.
+func (d *DiskStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || filepath.Base(key) != key {
+		return "", errors.New("blob: invalid key")
+	}
+	return filepath.Join(d.root, key), nil
+}
+
+// Put writes r to a file named key under root.
+func (d *DiskStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	p, err := d.path(key)
+	if err != nil {
+		return 0, err
+	}
+	f, err := os.Create(p)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return io.Copy(f, r)
+}
+
+// Get opens the file named key under root.
+func (d *DiskStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	p, err := d.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
+
+// Delete removes the file named key under root.
+func (d *DiskStore) Delete(ctx context.Context, key string) error {
+	p, err := d.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+var _ Store = (*DiskStore)(nil)
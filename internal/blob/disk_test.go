@@ -0,0 +1,59 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestDiskStorePutGetDelete(t *testing.T) {
+	d, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	ctx := context.Background()
+
+	n, err := d.Put(ctx, "att-1", bytes.NewReader([]byte("hello")))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if n != 5 {
+		t.Fatalf("Put wrote %d bytes, want 5", n)
+	}
+
+	rc, err := d.Get(ctx, "att-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(got) != "hello" {
+		t.Fatalf("got %q, want %q", got, "hello")
+	}
+
+	if err := d.Delete(ctx, "att-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := d.Get(ctx, "att-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after delete = %v, want ErrNotFound", err)
+	}
+	if err := d.Delete(ctx, "att-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Delete missing = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDiskStoreRejectsPathEscapingKeys(t *testing.T) {
+	d, err := NewDiskStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	ctx := context.Background()
+	if _, err := d.Put(ctx, "../escape", bytes.NewReader(nil)); err == nil {
+		t.Fatalf("expected error for path-escaping key")
+	}
+}
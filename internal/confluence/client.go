@@ -0,0 +1,81 @@
+// Package confluence publishes PRR reports to a Confluence space via the
+// Confluence REST API, so reviewers can read results alongside other team
+// documentation.
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Client publishes page content to a single Confluence instance.
+type Client struct {
+	BaseURL    string // e.g. "https://example.atlassian.net/wiki"
+	Token      string // personal access token, sent as a Bearer token
+	HTTPClient *http.Client
+}
+
+// NewClient builds a Client for the given Confluence instance.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		Token:      token,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+type updateContentRequest struct {
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	Body  struct {
+		Storage struct {
+			Value          string `json:"value"`
+			Representation string `json:"representation"`
+		} `json:"storage"`
+	} `json:"body"`
+}
+
+// PublishPage overwrites the content of the Confluence page identified by
+// pageID with html, bumping it to nextVersion. Confluence requires the
+// next version number on every update, so callers must know the page's
+// current version (typically by fetching it first). ctx is honored for
+// cancellation/timeouts; it is typically the originating HTTP request's
+// context, so publishing is aborted if the caller disconnects.
+func (c *Client) PublishPage(ctx context.Context, pageID, title, html string, nextVersion int) error {
+	var req updateContentRequest
+	req.Version.Number = nextVersion
+	req.Title = title
+	req.Type = "page"
+	req.Body.Storage.Value = html
+	req.Body.Storage.Representation = "storage"
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("confluence: marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/rest/api/content/%s", c.BaseURL, pageID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("confluence: build request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.Token)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("confluence: publish page %s: %w", pageID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("confluence: publish page %s: unexpected status %d", pageID, resp.StatusCode)
+	}
+	return nil
+}
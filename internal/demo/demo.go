@@ -0,0 +1,57 @@
+// Package demo seeds a store with a curated sample organization, so the
+// tool can be evaluated end-to-end without any supporting infrastructure.
+package demo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+var sampleServices = []string{
+	"checkout-api",
+	"payments-gateway",
+	"notification-worker",
+	"user-profile-service",
+}
+
+var sampleAnswers = []prr.Answer{
+	{QuestionID: "q-oncall", Value: "yes"},
+	{QuestionID: "q-runbook", Value: "yes"},
+	{QuestionID: "q-dashboards", Value: "no"},
+	{QuestionID: "q-load-tested", Value: "yes"},
+}
+
+// Load populates st with a fixed set of services and three quarterly
+// submissions per service, spanning the last six months, using clock and
+// idGen for timestamps and
+// IDs so the result is reproducible given fixed dependencies.
+func Load(ctx context.Context, st store.Store, clock prr.Clock, idGen prr.IDGenerator) error {
+	now := clock.Now()
+
+	for _, name := range sampleServices {
+		svc := prr.Service{
+			ID:        idGen.NewID(),
+			Name:      name,
+			CreatedAt: now.AddDate(0, -6, 0),
+		}
+		if err := st.CreateService(ctx, svc); err != nil {
+			return fmt.Errorf("demo: create service %s: %w", name, err)
+		}
+
+		for quarter := 0; quarter < 3; quarter++ {
+			sub := prr.Submission{
+				ID:        idGen.NewID(),
+				ServiceID: svc.ID,
+				Answers:   sampleAnswers,
+				CreatedAt: now.AddDate(0, -3*(2-quarter), 0),
+			}
+			if err := st.CreateSubmission(ctx, sub); err != nil {
+				return fmt.Errorf("demo: create submission for %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
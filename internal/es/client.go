@@ -0,0 +1,150 @@
+// Package es is a minimal Elasticsearch HTTP client used by the
+// Elasticsearch-backed storage implementation.
+package es
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/esconfig"
+)
+
+// Pinger is the subset of Client that handleReadyz depends on, so tests
+// can substitute a fake instead of standing up a real cluster.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+const (
+	maxRetries     = 3
+	retryBaseDelay = 100 * time.Millisecond
+)
+
+// Client is a thin wrapper around net/http configured to talk to an
+// Elasticsearch cluster. A program builds one Client at startup (see
+// cmd/prrserver/main.go) and reuses it for the life of the process; its
+// http.Client pools and reuses connections across calls rather than
+// dialing fresh ones.
+type Client struct {
+	cfg        esconfig.Config
+	httpClient *http.Client
+}
+
+// NewClient builds a Client from cfg, configured to pool connections to
+// the cluster and retry transient failures.
+func NewClient(cfg esconfig.Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 10
+	transport.IdleConnTimeout = 90 * time.Second
+
+	return &Client{cfg: cfg, httpClient: &http.Client{Transport: transport}}, nil
+}
+
+// Ping checks that the configured cluster is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.Addresses[0], nil)
+	if err != nil {
+		return fmt.Errorf("es: build ping request: %w", err)
+	}
+	c.setAuth(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("es: ping %s: %w", c.cfg.Addresses[0], err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("es: ping %s: unexpected status %d", c.cfg.Addresses[0], resp.StatusCode)
+	}
+	return nil
+}
+
+// Do issues an arbitrary request against the cluster's first configured
+// address, with auth applied, and returns the raw response for the
+// caller to decode and close. path is joined onto the address as-is, so
+// callers pass something like "/questions" or "/_reindex". A connection
+// error or a 429/502/503/504 response is retried up to maxRetries times with
+// exponential backoff, since those are the cases a retry can plausibly
+// fix; any other response is returned to the caller as-is.
+func (c *Client) Do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	var bodyBytes []byte
+	if body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("es: read %s %s request body: %w", method, path, err)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := retryBaseDelay * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		var reqBody io.Reader
+		if bodyBytes != nil {
+			reqBody = strings.NewReader(string(bodyBytes))
+		}
+		req, err := http.NewRequestWithContext(ctx, method, c.cfg.Addresses[0]+path, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("es: build %s %s request: %w", method, path, err)
+		}
+		if bodyBytes != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		c.setAuth(req)
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("es: %s %s: %w", method, path, err)
+			continue
+		}
+		if isRetryableStatus(resp.StatusCode) && attempt < maxRetries {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("es: %s %s: unexpected status %d", method, path, resp.StatusCode)
+			continue
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+// isRetryableStatus reports whether status is one a retry can plausibly
+// resolve: an overloaded or momentarily unavailable cluster.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// DoJSON is Do with the body passed as a string, for the common case of a
+// small literal JSON request document.
+func (c *Client) DoJSON(ctx context.Context, method, path, body string) (*http.Response, error) {
+	return c.Do(ctx, method, path, strings.NewReader(body))
+}
+
+func (c *Client) setAuth(req *http.Request) {
+	switch {
+	case c.cfg.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+c.cfg.APIKey)
+	case c.cfg.Username != "":
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+}
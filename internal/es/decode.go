@@ -0,0 +1,103 @@
+package es
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotFound is returned by GetInto when the requested document does
+// not exist, mirroring store.ErrNotFound's role for the in-memory
+// backend.
+var ErrNotFound = errors.New("es: not found")
+
+// searchResponse is the envelope Elasticsearch wraps search results in;
+// only the parts needed to reach each hit's _source are modelled here.
+type searchResponse[T any] struct {
+	Hits struct {
+		Hits []struct {
+			ID     string `json:"_id"`
+			Source T      `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// getResponse is the envelope Elasticsearch wraps a single document
+// fetch in.
+type getResponse[T any] struct {
+	ID     string `json:"_id"`
+	Found  bool   `json:"found"`
+	Source T      `json:"_source"`
+}
+
+// SearchInto decodes an Elasticsearch search response body into typed
+// hits, correctly reaching through the "hits.hits[]._source" envelope
+// instead of the handler doing its own untyped map[string]interface{}
+// round trip.
+func SearchInto[T any](body io.Reader) ([]T, error) {
+	var resp searchResponse[T]
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("es: decode search response: %w", err)
+	}
+	out := make([]T, len(resp.Hits.Hits))
+	for i, hit := range resp.Hits.Hits {
+		out[i] = hit.Source
+	}
+	return out, nil
+}
+
+// latestPerKeyResponse is the envelope a LatestPerKeyQuery's response is
+// wrapped in: one bucket per distinct key value, each carrying at most
+// one hit in its top_hits sub-aggregation.
+type latestPerKeyResponse[T any] struct {
+	Aggregations struct {
+		ByKey struct {
+			Buckets []struct {
+				Key    string `json:"key"`
+				Latest struct {
+					Hits struct {
+						Hits []struct {
+							Source T `json:"_source"`
+						} `json:"hits"`
+					} `json:"hits"`
+				} `json:"latest"`
+			} `json:"buckets"`
+		} `json:"by_key"`
+	} `json:"aggregations"`
+}
+
+// DecodeLatestPerKey decodes the response of a query built by
+// NewLatestPerKeyQuery into a map from each key's bucket value to its
+// single most recent document. A key with no matching documents at all
+// is simply absent from the map.
+func DecodeLatestPerKey[T any](body io.Reader) (map[string]T, error) {
+	var resp latestPerKeyResponse[T]
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("es: decode latest-per-key response: %w", err)
+	}
+	out := make(map[string]T, len(resp.Aggregations.ByKey.Buckets))
+	for _, bucket := range resp.Aggregations.ByKey.Buckets {
+		if len(bucket.Latest.Hits.Hits) == 0 {
+			continue
+		}
+		out[bucket.Key] = bucket.Latest.Hits.Hits[0].Source
+	}
+	return out, nil
+}
+
+// GetInto decodes an Elasticsearch single-document get response body
+// into a typed value. It returns ErrNotFound if the document doesn't
+// exist, matching the convention the rest of the codebase uses for
+// missing objects (see store.ErrNotFound).
+func GetInto[T any](body io.Reader) (T, error) {
+	var resp getResponse[T]
+	var zero T
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return zero, fmt.Errorf("es: decode get response: %w", err)
+	}
+	if !resp.Found {
+		return zero, ErrNotFound
+	}
+	return resp.Source, nil
+}
@@ -0,0 +1,66 @@
+package es
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testDoc struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestSearchInto(t *testing.T) {
+	const body = `{"hits":{"hits":[
+		{"_id":"1","_source":{"id":"1","name":"checkout"}},
+		{"_id":"2","_source":{"id":"2","name":"billing"}}
+	]}}`
+
+	got, err := SearchInto[testDoc](strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("SearchInto() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "checkout" || got[1].Name != "billing" {
+		t.Fatalf("SearchInto() = %+v", got)
+	}
+}
+
+func TestGetIntoNotFound(t *testing.T) {
+	const body = `{"_id":"1","found":false}`
+
+	_, err := GetInto[testDoc](strings.NewReader(body))
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetInto() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDecodeLatestPerKey(t *testing.T) {
+	const body = `{"aggregations":{"by_key":{"buckets":[
+		{"key":"svc-1","latest":{"hits":{"hits":[{"_source":{"id":"sub-1","name":"first"}}]}}},
+		{"key":"svc-2","latest":{"hits":{"hits":[]}}}
+	]}}}`
+
+	got, err := DecodeLatestPerKey[testDoc](strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("DecodeLatestPerKey() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("DecodeLatestPerKey() = %+v, want exactly one entry (svc-2 has no hits)", got)
+	}
+	if got["svc-1"].Name != "first" {
+		t.Fatalf("DecodeLatestPerKey()[\"svc-1\"] = %+v", got["svc-1"])
+	}
+}
+
+func TestGetIntoFound(t *testing.T) {
+	const body = `{"_id":"1","found":true,"_source":{"id":"1","name":"checkout"}}`
+
+	got, err := GetInto[testDoc](strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("GetInto() error = %v", err)
+	}
+	if got.Name != "checkout" {
+		t.Fatalf("GetInto() = %+v", got)
+	}
+}
@@ -0,0 +1,312 @@
+package es
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// mappingVersion is bumped whenever one of the mappings below changes in
+// a way that requires reindexing existing documents rather than just
+// creating new indices. It's recorded in each index's _meta purely for
+// an operator reading index settings to see at a glance which mapping
+// generation they're on; Reindex derives the next physical index name
+// from the alias's current backing index, not from this constant.
+const mappingVersion = 2
+
+// IndexMapping is the explicit mapping an index must be created with, so
+// fields the handlers filter or sort on (service_id, name) get a
+// `.keyword` sub-field instead of Elasticsearch's dynamically-mapped
+// default, which would tokenize them and break exact-match queries.
+//
+// Alias is the logical name the rest of the system refers to this index
+// by (via ReadAlias/WriteAlias); it never changes. The physical index
+// backing it does change across a Reindex, which is the whole point of
+// going through aliases: callers keep querying "questions_read" while
+// Bootstrap/Reindex move which physical index that alias points at.
+type IndexMapping struct {
+	Alias string
+	Body  string
+}
+
+// Indices are the mappings the handlers assume exist: questions,
+// sections, services and prr_submissions. sections, services and
+// prr_submissions each have a service_id.keyword or name.keyword
+// sub-field for exact-match filtering; questions maps its IDs as plain
+// keyword fields instead.
+var Indices = []IndexMapping{
+	{
+		Alias: "questions",
+		Body: fmt.Sprintf(`{
+  "mappings": {
+    "_meta": {"mapping_version": %d},
+    "properties": {
+      "id": {"type": "keyword"},
+      "section_id": {"type": "keyword"},
+      "text": {"type": "text"}
+    }
+  }
+}`, mappingVersion),
+	},
+	{
+		Alias: "sections",
+		Body: fmt.Sprintf(`{
+  "mappings": {
+    "_meta": {"mapping_version": %d},
+    "properties": {
+      "id": {"type": "keyword"},
+      "name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}}
+    }
+  }
+}`, mappingVersion),
+	},
+	{
+		Alias: "services",
+		Body: fmt.Sprintf(`{
+  "mappings": {
+    "_meta": {"mapping_version": %d},
+    "properties": {
+      "id": {"type": "keyword"},
+      "name": {"type": "text", "fields": {"keyword": {"type": "keyword"}}},
+      "created_at": {"type": "date"},
+      "description": {"type": "text"},
+      "owner_team": {"type": "keyword"},
+      "owner_email": {"type": "keyword"},
+      "tier": {"type": "keyword"},
+      "repository_url": {"type": "keyword"},
+      "runbook_url": {"type": "keyword"}
+    }
+  }
+}`, mappingVersion),
+	},
+	{
+		Alias: "prr_submissions",
+		Body: fmt.Sprintf(`{
+  "mappings": {
+    "_meta": {"mapping_version": %d},
+    "properties": {
+      "id": {"type": "keyword"},
+      "service_id": {"type": "keyword", "fields": {"keyword": {"type": "keyword"}}},
+      "status": {"type": "keyword"},
+      "grade": {"type": "keyword"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`, mappingVersion),
+	},
+}
+
+// ReadAlias and WriteAlias are the names queries and indexing requests
+// should actually target, rather than a physical index name that may be
+// swapped out from under them by Reindex. Having two separate aliases
+// rather than one lets a future reindex point reads at the new index a
+// step ahead of writes (or vice versa) if that's ever needed; today
+// Bootstrap and Reindex always move both together.
+func ReadAlias(alias string) string  { return alias + "_read" }
+func WriteAlias(alias string) string { return alias + "_write" }
+
+// Bootstrap ensures each of Indices' read/write aliases point at a
+// physical index with the expected mapping, creating a fresh one at
+// "<alias>_v1" for any alias that doesn't exist yet. An alias that
+// already exists is left untouched rather than having its backing
+// index's mapping patched, since Elasticsearch rejects incompatible
+// mapping updates to an index with existing documents in place — a real
+// mapping change goes through Reindex instead, which creates a new
+// physical index and swaps the aliases onto it with no downtime.
+func (c *Client) Bootstrap(ctx context.Context) error {
+	for _, idx := range Indices {
+		exists, err := c.aliasExists(ctx, WriteAlias(idx.Alias))
+		if err != nil {
+			return fmt.Errorf("es: check alias %s: %w", WriteAlias(idx.Alias), err)
+		}
+		if exists {
+			continue
+		}
+		physical := idx.Alias + "_v1"
+		if err := c.createIndex(ctx, physical, idx.Body); err != nil {
+			return fmt.Errorf("es: create index %s: %w", physical, err)
+		}
+		if err := c.swapAliases(ctx, idx.Alias, "", physical); err != nil {
+			return fmt.Errorf("es: alias %s to %s: %w", idx.Alias, physical, err)
+		}
+	}
+	return nil
+}
+
+// Reindex creates a new physical index for alias with body as its
+// mapping, copies every existing document across with Elasticsearch's
+// _reindex API, then atomically repoints alias's read and write aliases
+// at the new index. It returns the old physical index name so the
+// caller can decide whether and when to delete it; Reindex itself never
+// deletes data, since a mapping change that turns out to be wrong is
+// much easier to roll back with the old index still sitting there.
+func (c *Client) Reindex(ctx context.Context, alias, body string) (oldIndex, newIndex string, err error) {
+	oldIndex, err = c.currentIndex(ctx, WriteAlias(alias))
+	if err != nil {
+		return "", "", fmt.Errorf("es: resolve current index for %s: %w", alias, err)
+	}
+	newIndex = nextPhysicalIndexName(oldIndex, alias)
+
+	if err := c.createIndex(ctx, newIndex, body); err != nil {
+		return "", "", fmt.Errorf("es: create index %s: %w", newIndex, err)
+	}
+	if err := c.reindexDocuments(ctx, oldIndex, newIndex); err != nil {
+		return "", "", fmt.Errorf("es: copy documents from %s to %s: %w", oldIndex, newIndex, err)
+	}
+	if err := c.swapAliases(ctx, alias, oldIndex, newIndex); err != nil {
+		return "", "", fmt.Errorf("es: alias %s to %s: %w", alias, newIndex, err)
+	}
+	return oldIndex, newIndex, nil
+}
+
+// nextPhysicalIndexName returns the next "<alias>_vN" name after
+// current, starting over at "<alias>_v1" if current doesn't match that
+// pattern (e.g. it predates this naming scheme).
+func nextPhysicalIndexName(current, alias string) string {
+	var n int
+	if _, err := fmt.Sscanf(current, alias+"_v%d", &n); err != nil {
+		return alias + "_v1"
+	}
+	return fmt.Sprintf("%s_v%d", alias, n+1)
+}
+
+func (c *Client) aliasExists(ctx context.Context, alias string) (bool, error) {
+	resp, err := c.Do(ctx, "HEAD", "/_alias/"+alias, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == 200, nil
+}
+
+// currentIndex returns the name of the single physical index alias
+// currently points at.
+func (c *Client) currentIndex(ctx context.Context, alias string) (string, error) {
+	resp, err := c.Do(ctx, "GET", "/_alias/"+alias, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("unexpected status %d: %s", resp.StatusCode, detail)
+	}
+	var byIndex map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&byIndex); err != nil {
+		return "", fmt.Errorf("decode alias response: %w", err)
+	}
+	for index := range byIndex {
+		return index, nil
+	}
+	return "", fmt.Errorf("alias %s has no backing index", alias)
+}
+
+func (c *Client) createIndex(ctx context.Context, index, body string) error {
+	resp, err := c.DoJSON(ctx, "PUT", "/"+index, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, detail)
+	}
+	return nil
+}
+
+// reindexDocuments copies every document from source to dest with
+// Elasticsearch's _reindex API, which does the copy cluster-side
+// without the client having to scroll and re-index documents itself.
+func (c *Client) reindexDocuments(ctx context.Context, source, dest string) error {
+	body, err := json.Marshal(reindexRequest{
+		Source: reindexEndpoint{Index: source},
+		Dest:   reindexEndpoint{Index: dest},
+	})
+	if err != nil {
+		return fmt.Errorf("build _reindex request: %w", err)
+	}
+	resp, err := c.Do(ctx, "POST", "/_reindex?wait_for_completion=true", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, detail)
+	}
+	var result reindexResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode _reindex response: %w", err)
+	}
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("_reindex reported %d document failure(s)", len(result.Failures))
+	}
+	return nil
+}
+
+type reindexRequest struct {
+	Source reindexEndpoint `json:"source"`
+	Dest   reindexEndpoint `json:"dest"`
+}
+
+type reindexEndpoint struct {
+	Index string `json:"index"`
+}
+
+type reindexResponse struct {
+	Failures []json.RawMessage `json:"failures"`
+}
+
+// swapAliases atomically points alias's read and write aliases at
+// newIndex, removing them from oldIndex in the same request if oldIndex
+// is non-empty. Elasticsearch's _aliases endpoint applies every action
+// in one request atomically, so callers of this alias never observe a
+// moment where neither index (or both) is aliased.
+func (c *Client) swapAliases(ctx context.Context, alias, oldIndex, newIndex string) error {
+	var actions []aliasAction
+	if oldIndex != "" {
+		actions = append(actions,
+			aliasAction{Remove: &aliasRef{Index: oldIndex, Alias: ReadAlias(alias)}},
+			aliasAction{Remove: &aliasRef{Index: oldIndex, Alias: WriteAlias(alias)}},
+		)
+	}
+	actions = append(actions,
+		aliasAction{Add: &aliasRef{Index: newIndex, Alias: ReadAlias(alias)}},
+		aliasAction{Add: &aliasRef{Index: newIndex, Alias: WriteAlias(alias)}},
+	)
+
+	body, err := json.Marshal(aliasActions{Actions: actions})
+	if err != nil {
+		return fmt.Errorf("build _aliases request: %w", err)
+	}
+	resp, err := c.Do(ctx, "POST", "/_aliases", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, detail)
+	}
+	return nil
+}
+
+type aliasActions struct {
+	Actions []aliasAction `json:"actions"`
+}
+
+type aliasAction struct {
+	Add    *aliasRef `json:"add,omitempty"`
+	Remove *aliasRef `json:"remove,omitempty"`
+}
+
+type aliasRef struct {
+	Index string `json:"index"`
+	Alias string `json:"alias"`
+}
@@ -0,0 +1,128 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/esconfig"
+)
+
+func TestBootstrapCreatesMissingAliasesOnly(t *testing.T) {
+	existingAliases := map[string]bool{"sections_write": true}
+	created := map[string]bool{}
+	aliased := map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && len(r.URL.Path) > len("/_alias/"):
+			alias := r.URL.Path[len("/_alias/"):]
+			if existingAliases[alias] {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			created[r.URL.Path[1:]] = true
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/_aliases":
+			var req aliasActions
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode _aliases body: %v", err)
+			}
+			for _, action := range req.Actions {
+				if action.Add != nil {
+					aliased[action.Add.Alias] = true
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(esconfig.Config{Addresses: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if err := c.Bootstrap(context.Background()); err != nil {
+		t.Fatalf("Bootstrap() error = %v", err)
+	}
+
+	if created["sections_v1"] {
+		t.Fatalf("Bootstrap() recreated the physical index behind the already-existing sections alias")
+	}
+	for _, idx := range Indices {
+		if idx.Alias == "sections" {
+			continue
+		}
+		if !created[idx.Alias+"_v1"] {
+			t.Fatalf("Bootstrap() did not create a physical index for alias %s", idx.Alias)
+		}
+		if !aliased[ReadAlias(idx.Alias)] || !aliased[WriteAlias(idx.Alias)] {
+			t.Fatalf("Bootstrap() did not point read/write aliases at the new index for %s", idx.Alias)
+		}
+	}
+}
+
+func TestReindexCopiesDocumentsAndSwapsAliases(t *testing.T) {
+	var createdIndex, reindexSource, reindexDest string
+	var removedOld, addedNew []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/_alias/questions_write":
+			json.NewEncoder(w).Encode(map[string]any{"questions_v1": map[string]any{}})
+		case r.Method == http.MethodPut:
+			createdIndex = r.URL.Path[1:]
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/_reindex":
+			var req reindexRequest
+			json.NewDecoder(r.Body).Decode(&req)
+			reindexSource, reindexDest = req.Source.Index, req.Dest.Index
+			json.NewEncoder(w).Encode(reindexResponse{})
+		case r.Method == http.MethodPost && r.URL.Path == "/_aliases":
+			var req aliasActions
+			json.NewDecoder(r.Body).Decode(&req)
+			for _, action := range req.Actions {
+				if action.Remove != nil {
+					removedOld = append(removedOld, action.Remove.Index+"/"+action.Remove.Alias)
+				}
+				if action.Add != nil {
+					addedNew = append(addedNew, action.Add.Index+"/"+action.Add.Alias)
+				}
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(esconfig.Config{Addresses: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	oldIndex, newIndex, err := c.Reindex(context.Background(), "questions", `{"mappings":{}}`)
+	if err != nil {
+		t.Fatalf("Reindex() error = %v", err)
+	}
+	if oldIndex != "questions_v1" || newIndex != "questions_v2" {
+		t.Fatalf("Reindex() = (%q, %q), want (questions_v1, questions_v2)", oldIndex, newIndex)
+	}
+	if createdIndex != "questions_v2" {
+		t.Fatalf("created index = %q, want questions_v2", createdIndex)
+	}
+	if reindexSource != "questions_v1" || reindexDest != "questions_v2" {
+		t.Fatalf("_reindex source/dest = %q/%q", reindexSource, reindexDest)
+	}
+	wantRemoved := []string{"questions_v1/questions_read", "questions_v1/questions_write"}
+	wantAdded := []string{"questions_v2/questions_read", "questions_v2/questions_write"}
+	if len(removedOld) != len(wantRemoved) || removedOld[0] != wantRemoved[0] || removedOld[1] != wantRemoved[1] {
+		t.Fatalf("removed aliases = %v, want %v", removedOld, wantRemoved)
+	}
+	if len(addedNew) != len(wantAdded) || addedNew[0] != wantAdded[0] || addedNew[1] != wantAdded[1] {
+		t.Fatalf("added aliases = %v, want %v", addedNew, wantAdded)
+	}
+}
@@ -0,0 +1,195 @@
+package es
+
+import "encoding/json"
+
+// Query is a typed Elasticsearch query body, marshalled with
+// encoding/json rather than built with fmt.Sprintf, so a value
+// containing a quote or backslash can't break the resulting JSON or let
+// a caller inject query clauses. It is for building request bodies to
+// pass to Client.Do/DoJSON; nothing in this module issues search queries,
+// since no store.Store implementation is backed by Elasticsearch.
+type Query struct {
+	Query map[string]any `json:"query"`
+}
+
+// TermQuery builds an exact-match query on field, e.g. a keyword
+// sub-field such as "service_id.keyword".
+func TermQuery(field, value string) Query {
+	return Query{Query: map[string]any{
+		"term": map[string]any{field: value},
+	}}
+}
+
+// MatchQuery builds a full-text match query on field.
+func MatchQuery(field, value string) Query {
+	return Query{Query: map[string]any{
+		"match": map[string]any{field: value},
+	}}
+}
+
+// TermsQuery builds an exact-match filter on field for any of values, the
+// multi-value form of TermQuery. It's the building block for fetching
+// data scoped to a known set of IDs (e.g. the services a prior search
+// matched) in a single round trip instead of one query per ID.
+func TermsQuery(field string, values []string) Query {
+	return Query{Query: map[string]any{
+		"terms": map[string]any{field: values},
+	}}
+}
+
+// LatestPerKeyQuery is a Query augmented with a terms aggregation, keyed
+// by keyField, whose top_hits sub-aggregation returns only the most
+// recent document (by sortField, descending) in each bucket. Searching
+// with Size 0 skips returning the matched documents themselves, since
+// only the aggregated top_hits are of interest.
+type LatestPerKeyQuery struct {
+	query     map[string]any
+	keyField  string
+	sortField string
+	size      int
+}
+
+// latestPerKeyAggName and latestHitAggName are the aggregation names
+// LatestPerKeyQuery.Bytes emits and DecodeLatestPerKey expects.
+const (
+	latestPerKeyAggName = "by_key"
+	latestHitAggName    = "latest"
+)
+
+// NewLatestPerKeyQuery builds a query that, in one round trip, fetches
+// the single most recent document (by sortField) for each distinct value
+// of keyField among documents matching keys, replacing an N+1 pattern of
+// querying each key's latest document individually.
+func NewLatestPerKeyQuery(keyField string, keys []string, sortField string) LatestPerKeyQuery {
+	return LatestPerKeyQuery{
+		query:     TermsQuery(keyField, keys).Query,
+		keyField:  keyField,
+		sortField: sortField,
+		size:      len(keys),
+	}
+}
+
+// RangeQuery builds a range filter on field. A nil bound is omitted, so
+// callers can express a one-sided range ("older than X days" as lte
+// only, or "newer than" as gte only) without sending a redundant
+// unbounded clause.
+func RangeQuery(field string, gte, lte any) Query {
+	bounds := map[string]any{}
+	if gte != nil {
+		bounds["gte"] = gte
+	}
+	if lte != nil {
+		bounds["lte"] = lte
+	}
+	return Query{Query: map[string]any{
+		"range": map[string]any{field: bounds},
+	}}
+}
+
+// ExistsQuery builds a filter matching documents that have a non-null
+// value for field, the building block for a "has a PRR" filter.
+func ExistsQuery(field string) Query {
+	return Query{Query: map[string]any{
+		"exists": map[string]any{"field": field},
+	}}
+}
+
+// NotQuery negates q, the building block for a "has no PRR" filter via
+// NotQuery(ExistsQuery(...)).
+func NotQuery(q Query) Query {
+	return Query{Query: map[string]any{
+		"bool": map[string]any{"must_not": q.Query},
+	}}
+}
+
+// BoolQuery combines filters with AND semantics in an Elasticsearch
+// filter context, which matches like a term/range query but doesn't
+// contribute to relevance scoring. It's the building block for a search
+// narrowed by several simultaneous criteria (e.g. owner team, tier, and
+// PRR age) rather than a single TermQuery/RangeQuery.
+func BoolQuery(filters ...Query) Query {
+	clauses := make([]any, len(filters))
+	for i, f := range filters {
+		clauses[i] = f.Query
+	}
+	return Query{Query: map[string]any{
+		"bool": map[string]any{"filter": clauses},
+	}}
+}
+
+// SortOrder is the direction of a SearchRequest sort clause.
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// SortClause orders a SearchRequest's results by Field, e.g. a keyword
+// or numeric sub-field such as "name.keyword" or "readiness_score".
+type SortClause struct {
+	Field string
+	Order SortOrder
+}
+
+// SearchRequest composes a Query with sort order and pagination into a
+// full Elasticsearch _search request body, for callers that need more
+// than the bare query TermQuery/BoolQuery/etc. produce on their own
+// (e.g. a dashboard offering several sort options over a filtered set of
+// services).
+type SearchRequest struct {
+	Query Query
+	Sort  []SortClause
+	From  int
+	Size  int
+}
+
+// Bytes renders r as its request body. Sort, From, and Size are each
+// omitted when left at their zero value, leaving Elasticsearch's own
+// defaults (relevance order, no offset, ten hits) in place.
+func (r SearchRequest) Bytes() ([]byte, error) {
+	body := map[string]any{"query": r.Query.Query}
+	if len(r.Sort) > 0 {
+		sort := make([]any, len(r.Sort))
+		for i, s := range r.Sort {
+			sort[i] = map[string]any{s.Field: s.Order}
+		}
+		body["sort"] = sort
+	}
+	if r.From > 0 {
+		body["from"] = r.From
+	}
+	if r.Size > 0 {
+		body["size"] = r.Size
+	}
+	return json.Marshal(body)
+}
+
+// Bytes renders q as its request body.
+func (q LatestPerKeyQuery) Bytes() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"query": q.query,
+		"size":  0,
+		"aggs": map[string]any{
+			latestPerKeyAggName: map[string]any{
+				"terms": map[string]any{"field": q.keyField, "size": q.size},
+				"aggs": map[string]any{
+					latestHitAggName: map[string]any{
+						"top_hits": map[string]any{
+							"size": 1,
+							"sort": []any{map[string]any{q.sortField: "desc"}},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// Bytes renders q as its request body. Errors are only possible for
+// values json.Marshal can't handle at all (channels, functions), which
+// never occur in a Query built from TermQuery/MatchQuery, so callers
+// needing a string for DoJSON can ignore the error in practice.
+func (q Query) Bytes() ([]byte, error) {
+	return json.Marshal(q)
+}
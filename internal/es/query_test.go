@@ -0,0 +1,83 @@
+package es
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTermQueryEscapesSpecialCharacters(t *testing.T) {
+	q := TermQuery("service_id.keyword", `o'brien's "checkout" service`)
+	b, err := q.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+
+	const want = `{"query":{"term":{"service_id.keyword":"o'brien's \"checkout\" service"}}}`
+	if string(b) != want {
+		t.Fatalf("Bytes() = %s, want %s", b, want)
+	}
+}
+
+func TestSearchRequestBuildsFilteredSortedBody(t *testing.T) {
+	query := BoolQuery(
+		TermQuery("owner_team.keyword", "checkout"),
+		TermsQuery("tier.keyword", []string{"1", "2"}),
+		RangeQuery("last_prr_at", nil, "2026-01-01"),
+		NotQuery(ExistsQuery("last_prr_at")),
+	)
+	r := SearchRequest{
+		Query: query,
+		Sort:  []SortClause{{Field: "readiness_score", Order: SortDescending}, {Field: "name.keyword", Order: SortAscending}},
+		Size:  25,
+	}
+	b, err := r.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if parsed["size"].(float64) != 25 {
+		t.Fatalf("size = %v, want 25", parsed["size"])
+	}
+	if _, ok := parsed["from"]; ok {
+		t.Fatalf("from = %v, want omitted when zero", parsed["from"])
+	}
+	sortJSON, _ := json.Marshal(parsed["sort"])
+	if !strings.Contains(string(sortJSON), `"desc"`) || !strings.Contains(string(sortJSON), `"asc"`) {
+		t.Fatalf("sort = %s, want both a desc and an asc clause", sortJSON)
+	}
+	queryJSON, _ := json.Marshal(parsed["query"])
+	for _, want := range []string{`"term"`, `"terms"`, `"range"`, `"must_not"`, `"filter"`} {
+		if !strings.Contains(string(queryJSON), want) {
+			t.Fatalf("query = %s, want it to contain %s", queryJSON, want)
+		}
+	}
+}
+
+func TestLatestPerKeyQueryBuildsTermsFilterAndTopHitsAgg(t *testing.T) {
+	q := NewLatestPerKeyQuery("service_id.keyword", []string{"svc-1", "svc-2"}, "created_at")
+	b, err := q.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(b, &parsed); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if parsed["size"].(float64) != 0 {
+		t.Fatalf("size = %v, want 0: the matched documents themselves aren't needed", parsed["size"])
+	}
+	query, _ := json.Marshal(parsed["query"])
+	if !strings.Contains(string(query), `"terms"`) {
+		t.Fatalf("query = %s, want a terms filter", query)
+	}
+	aggs, _ := json.Marshal(parsed["aggs"])
+	if !strings.Contains(string(aggs), `"by_key"`) || !strings.Contains(string(aggs), `"top_hits"`) {
+		t.Fatalf("aggs = %s, want a by_key terms aggregation with a top_hits sub-aggregation", aggs)
+	}
+}
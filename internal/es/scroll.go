@@ -0,0 +1,125 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// defaultScanPageSize bounds how many documents ScanAll fetches per
+// request, so a single page can't itself balloon into a multi-megabyte
+// response.
+const defaultScanPageSize = 1000
+
+// scanResponse is the envelope a PIT-backed search response is wrapped
+// in: each hit carries its Sort values alongside _source, needed to ask
+// for the next page via search_after, and the response as a whole
+// carries the (possibly rotated) PitID to reuse on the next request.
+type scanResponse[T any] struct {
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Hits []struct {
+			Source T     `json:"_source"`
+			Sort   []any `json:"sort"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// ScanAll fetches every document in index via Elasticsearch's
+// point-in-time + search_after pattern, sorting on "_shard_doc" (the
+// tiebreaker Elasticsearch recommends for a plain, criteria-free scan).
+// Unlike a single match_all search with a fixed size, it has no upper
+// bound on the number of documents it can return and doesn't hold a
+// scroll context open cluster-side for longer than it takes to read one
+// page at a time.
+//
+// Nothing in this module wires it in yet, since there's no
+// Elasticsearch-backed store.Store implementation for it to serve; it
+// exists so that such an implementation can fetch
+// a whole index without reintroducing the size:10000 match_all pattern.
+func ScanAll[T any](ctx context.Context, c *Client, index string, pageSize int) ([]T, error) {
+	if pageSize <= 0 {
+		pageSize = defaultScanPageSize
+	}
+
+	pitID, err := c.openPIT(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("es: scan %s: %w", index, err)
+	}
+	defer c.closePIT(ctx, pitID)
+
+	var out []T
+	var searchAfter []any
+	for {
+		page, err := json.Marshal(map[string]any{
+			"size":         pageSize,
+			"pit":          map[string]any{"id": pitID, "keep_alive": "1m"},
+			"sort":         []any{map[string]any{"_shard_doc": "asc"}},
+			"search_after": searchAfter,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("es: scan %s: marshal request: %w", index, err)
+		}
+
+		resp, err := c.DoJSON(ctx, "POST", "/_search", string(page))
+		if err != nil {
+			return nil, fmt.Errorf("es: scan %s: %w", index, err)
+		}
+		var parsed scanResponse[T]
+		decodeErr := json.NewDecoder(resp.Body).Decode(&parsed)
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return nil, fmt.Errorf("es: scan %s: unexpected status %d", index, resp.StatusCode)
+		}
+		if decodeErr != nil {
+			return nil, fmt.Errorf("es: scan %s: decode response: %w", index, decodeErr)
+		}
+
+		if parsed.PitID != "" {
+			pitID = parsed.PitID
+		}
+		for _, hit := range parsed.Hits.Hits {
+			out = append(out, hit.Source)
+			searchAfter = hit.Sort
+		}
+		if len(parsed.Hits.Hits) < pageSize {
+			return out, nil
+		}
+	}
+}
+
+func (c *Client) openPIT(ctx context.Context, index string) (string, error) {
+	resp, err := c.Do(ctx, "POST", "/"+index+"/_pit?keep_alive=1m", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("open pit: unexpected status %d: %s", resp.StatusCode, detail)
+	}
+	var parsed struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", fmt.Errorf("open pit: decode response: %w", err)
+	}
+	return parsed.ID, nil
+}
+
+// closePIT releases the point-in-time context, best-effort: a PIT that
+// outlives its keep_alive is reclaimed by the cluster on its own, so a
+// failure here isn't worth surfacing as a ScanAll error after the
+// caller's documents were already returned successfully.
+func (c *Client) closePIT(ctx context.Context, pitID string) {
+	body, err := json.Marshal(map[string]string{"id": pitID})
+	if err != nil {
+		return
+	}
+	resp, err := c.DoJSON(ctx, "DELETE", "/_pit", string(body))
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+}
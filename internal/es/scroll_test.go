@@ -0,0 +1,76 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/esconfig"
+)
+
+func TestScanAllPaginatesUntilShortPage(t *testing.T) {
+	const pitID = "fake-pit"
+	searches := 0
+	closed := false
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/_pit"):
+			json.NewEncoder(w).Encode(map[string]string{"id": pitID})
+		case r.Method == http.MethodDelete && r.URL.Path == "/_pit":
+			closed = true
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/_search":
+			var req struct {
+				Size        int   `json:"size"`
+				SearchAfter []any `json:"search_after"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			searches++
+			switch searches {
+			case 1:
+				if req.SearchAfter != nil {
+					t.Fatalf("first page search_after = %v, want nil", req.SearchAfter)
+				}
+				w.Write([]byte(`{"pit_id":"` + pitID + `","hits":{"hits":[
+					{"_source":{"id":"q1"},"sort":[1]},
+					{"_source":{"id":"q2"},"sort":[2]}
+				]}}`))
+			case 2:
+				if len(req.SearchAfter) != 1 {
+					t.Fatalf("second page search_after = %v, want one value", req.SearchAfter)
+				}
+				w.Write([]byte(`{"pit_id":"` + pitID + `","hits":{"hits":[
+					{"_source":{"id":"q3"},"sort":[3]}
+				]}}`))
+			default:
+				t.Fatalf("unexpected extra search request %d", searches)
+			}
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(esconfig.Config{Addresses: []string{srv.URL}})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	got, err := ScanAll[testDoc](context.Background(), c, "questions", 2)
+	if err != nil {
+		t.Fatalf("ScanAll() error = %v", err)
+	}
+	if len(got) != 3 || got[0].ID != "q1" || got[1].ID != "q2" || got[2].ID != "q3" {
+		t.Fatalf("ScanAll() = %+v", got)
+	}
+	if searches != 2 {
+		t.Fatalf("searches = %d, want 2 (stop once a page is shorter than pageSize)", searches)
+	}
+	if !closed {
+		t.Fatalf("ScanAll() did not close the PIT")
+	}
+}
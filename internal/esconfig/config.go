@@ -0,0 +1,54 @@
+// Package esconfig loads Elasticsearch connection settings from the
+// environment or a config file, so deployments can point at different
+// clusters without code changes.
+package esconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Config holds the settings needed to connect to an Elasticsearch
+// cluster.
+type Config struct {
+	Addresses []string `json:"addresses"`
+	Username  string   `json:"username"`
+	Password  string   `json:"password"`
+	APIKey    string   `json:"api_key"`
+}
+
+// FromEnv builds a Config from ES_ADDRESSES (comma-separated),
+// ES_USERNAME, ES_PASSWORD and ES_API_KEY.
+func FromEnv() Config {
+	var cfg Config
+	if addrs := os.Getenv("ES_ADDRESSES"); addrs != "" {
+		cfg.Addresses = strings.Split(addrs, ",")
+	}
+	cfg.Username = os.Getenv("ES_USERNAME")
+	cfg.Password = os.Getenv("ES_PASSWORD")
+	cfg.APIKey = os.Getenv("ES_API_KEY")
+	return cfg
+}
+
+// FromFile loads a Config from a JSON config file.
+func FromFile(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("esconfig: read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("esconfig: parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate reports an error if the config is missing required fields.
+func (c Config) Validate() error {
+	if len(c.Addresses) == 0 {
+		return fmt.Errorf("esconfig: at least one address is required")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package esconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFromFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "es.json")
+	const doc = `{"addresses": ["http://localhost:9200"], "username": "elastic", "password": "changeme"}`
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := FromFile(path)
+	if err != nil {
+		t.Fatalf("FromFile() error = %v", err)
+	}
+	if len(cfg.Addresses) != 1 || cfg.Addresses[0] != "http://localhost:9200" {
+		t.Fatalf("cfg.Addresses = %v", cfg.Addresses)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+}
+
+func TestValidateRequiresAddresses(t *testing.T) {
+	if err := (Config{}).Validate(); err == nil {
+		t.Fatal("Validate() expected error for empty config")
+	}
+}
@@ -0,0 +1,85 @@
+// Package gitops syncs the question bank from a directory checked out
+// from a git repository, so questionnaire changes follow the same
+// review process as code.
+package gitops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// sectionFile is the on-disk representation of one section and its
+// questions, one JSON file per section.
+type sectionFile struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Questions []questionFile `json:"questions"`
+}
+
+type questionFile struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// Summary reports what a Sync call did.
+type Summary struct {
+	Sections  int
+	Questions int
+}
+
+// Sync reads every *.json file in dir (expected to be a checkout of the
+// question bank repository) and upserts the sections and questions it
+// describes into st. It does not delete sections/questions that are no
+// longer present in dir.
+func Sync(ctx context.Context, st store.Store, dir string) (Summary, error) {
+	var summary Summary
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return summary, fmt.Errorf("gitops: read question bank dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return summary, fmt.Errorf("gitops: read %s: %w", path, err)
+		}
+
+		var sf sectionFile
+		if err := json.Unmarshal(data, &sf); err != nil {
+			return summary, fmt.Errorf("gitops: parse %s: %w", path, err)
+		}
+		if sf.ID == "" {
+			return summary, fmt.Errorf("gitops: %s is missing an id", path)
+		}
+
+		if err := st.UpsertSection(ctx, prr.Section{ID: sf.ID, Name: sf.Name}); err != nil {
+			return summary, fmt.Errorf("gitops: upsert section %s: %w", sf.ID, err)
+		}
+		summary.Sections++
+
+		for _, qf := range sf.Questions {
+			if qf.ID == "" {
+				return summary, fmt.Errorf("gitops: %s has a question missing an id", path)
+			}
+			q := prr.Question{ID: qf.ID, SectionID: sf.ID, Text: qf.Text}
+			if err := st.UpsertQuestion(ctx, q); err != nil {
+				return summary, fmt.Errorf("gitops: upsert question %s: %w", qf.ID, err)
+			}
+			summary.Questions++
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,44 @@
+package gitops
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestSync(t *testing.T) {
+	dir := t.TempDir()
+	const doc = `{
+		"id": "security",
+		"name": "Security",
+		"questions": [
+			{"id": "q-oncall", "text": "Is there an on-call rotation?"},
+			{"id": "q-runbook", "text": "Is there a runbook?"}
+		]
+	}`
+	if err := os.WriteFile(filepath.Join(dir, "security.json"), []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	st := memory.New()
+	summary, err := Sync(context.Background(), st, dir)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if summary.Sections != 1 || summary.Questions != 2 {
+		t.Fatalf("Sync() summary = %+v, want 1 section, 2 questions", summary)
+	}
+
+	sections, err := st.ListSections(context.Background())
+	if err != nil || len(sections) != 1 || sections[0].Name != "Security" {
+		t.Fatalf("ListSections() = %+v, %v", sections, err)
+	}
+
+	q, err := st.GetQuestion(context.Background(), "q-oncall")
+	if err != nil || q.SectionID != "security" {
+		t.Fatalf("GetQuestion() = %+v, %v", q, err)
+	}
+}
@@ -0,0 +1,119 @@
+// Package jira creates and updates issues in a Jira Cloud project, used
+// to auto-file tickets for PRR submissions with failing essential
+// questions.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Client creates and updates issues in a single Jira Cloud project.
+type Client struct {
+	BaseURL    string // e.g. "https://example.atlassian.net"
+	Email      string // Atlassian account email, paired with APIToken
+	APIToken   string
+	HTTPClient *http.Client
+}
+
+// NewClient builds a Client authenticating to baseURL as email, using
+// apiToken as a Jira Cloud API token.
+func NewClient(baseURL, email, apiToken string) *Client {
+	return &Client{
+		BaseURL:    baseURL,
+		Email:      email,
+		APIToken:   apiToken,
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+type issueFields struct {
+	Project     struct{ Key string }  `json:"project"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description"`
+	IssueType   struct{ Name string } `json:"issuetype"`
+}
+
+type createIssueRequest struct {
+	Fields issueFields `json:"fields"`
+}
+
+type createIssueResponse struct {
+	Key string `json:"key"`
+}
+
+// CreateIssue files a new issue in projectKey and returns its key (e.g.
+// "PRR-123").
+func (c *Client) CreateIssue(ctx context.Context, projectKey, issueType, summary, description string) (string, error) {
+	var req createIssueRequest
+	req.Fields.Project.Key = projectKey
+	req.Fields.Summary = summary
+	req.Fields.Description = description
+	req.Fields.IssueType.Name = issueType
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("jira: marshal create issue request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/rest/api/2/issue", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("jira: build create issue request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.SetBasicAuth(c.Email, c.APIToken)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("jira: create issue: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("jira: create issue: unexpected status %d", resp.StatusCode)
+	}
+	var out createIssueResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("jira: decode create issue response: %w", err)
+	}
+	return out.Key, nil
+}
+
+type updateIssueRequest struct {
+	Fields struct {
+		Description string `json:"description"`
+	} `json:"fields"`
+}
+
+// UpdateIssue overwrites the description of the existing issue
+// identified by key.
+func (c *Client) UpdateIssue(ctx context.Context, key, description string) error {
+	var req updateIssueRequest
+	req.Fields.Description = description
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("jira: marshal update issue request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPut, c.BaseURL+"/rest/api/2/issue/"+key, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("jira: build update issue request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.SetBasicAuth(c.Email, c.APIToken)
+
+	resp, err := c.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("jira: update issue %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("jira: update issue %s: unexpected status %d", key, resp.StatusCode)
+	}
+	return nil
+}
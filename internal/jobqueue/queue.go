@@ -0,0 +1,105 @@
+// Package jobqueue runs caller-submitted work on a fixed pool of
+// worker goroutines and tracks each job's status, so a slow operation
+// (like scoring and notifying on a large submission) can be kicked off
+// from an HTTP handler and polled for completion instead of blocking
+// the request.
+package jobqueue
+
+import (
+	"context"
+	"sync"
+)
+
+// Status is a job's position in its lifecycle.
+type Status string
+
+const (
+	StatusQueued     Status = "queued"
+	StatusProcessing Status = "processing"
+	StatusDone       Status = "done"
+	StatusFailed     Status = "failed"
+)
+
+// Job is a unit of work tracked by a Queue. Result is set when Status is
+// StatusDone; Error is set when Status is StatusFailed.
+type Job struct {
+	ID     string
+	Status Status
+	Result any
+	Error  string
+}
+
+type task struct {
+	id string
+	fn func(context.Context) (any, error)
+}
+
+// Queue runs submitted work on a fixed pool of worker goroutines
+// pulling from an unbounded backlog.
+type Queue struct {
+	mu   sync.RWMutex
+	jobs map[string]*Job
+	work chan task
+}
+
+// New starts a Queue with the given number of worker goroutines. A
+// non-positive workers is treated as 1.
+func New(workers int) *Queue {
+	if workers < 1 {
+		workers = 1
+	}
+	q := &Queue{
+		jobs: make(map[string]*Job),
+		work: make(chan task),
+	}
+	for i := 0; i < workers; i++ {
+		go q.runWorker()
+	}
+	return q
+}
+
+// Submit enqueues fn to run on a worker goroutine under the given id,
+// which the caller is responsible for making unique (e.g. via its own
+// ID generator). The job is immediately visible to Get with
+// StatusQueued.
+func (q *Queue) Submit(id string, fn func(context.Context) (any, error)) {
+	q.mu.Lock()
+	q.jobs[id] = &Job{ID: id, Status: StatusQueued}
+	q.mu.Unlock()
+	go func() { q.work <- task{id: id, fn: fn} }()
+}
+
+// Get returns the current state of the job submitted under id, or
+// ok=false if no such job was ever submitted.
+func (q *Queue) Get(id string) (job Job, ok bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	j, ok := q.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *j, true
+}
+
+func (q *Queue) runWorker() {
+	for t := range q.work {
+		q.setStatus(t.id, StatusProcessing)
+		result, err := t.fn(context.Background())
+		q.mu.Lock()
+		j := q.jobs[t.id]
+		if err != nil {
+			j.Status = StatusFailed
+			j.Error = err.Error()
+		} else {
+			j.Status = StatusDone
+			j.Result = result
+		}
+		q.mu.Unlock()
+	}
+}
+
+func (q *Queue) setStatus(id string, status Status) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs[id].Status = status
+}
@@ -0,0 +1,64 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestQueueRunsSubmittedWorkAndReportsResult(t *testing.T) {
+	q := New(2)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	q.Submit("job-1", func(ctx context.Context) (any, error) {
+		close(started)
+		<-release
+		return "ok", nil
+	})
+
+	<-started
+	job, ok := q.Get("job-1")
+	if !ok || job.Status != StatusProcessing {
+		t.Fatalf("job mid-run = %+v, ok=%v, want StatusProcessing", job, ok)
+	}
+	close(release)
+
+	waitForStatus(t, q, "job-1", StatusDone)
+	job, _ = q.Get("job-1")
+	if job.Result != "ok" {
+		t.Fatalf("result = %v, want %q", job.Result, "ok")
+	}
+}
+
+func TestQueueReportsFailure(t *testing.T) {
+	q := New(1)
+	q.Submit("job-1", func(ctx context.Context) (any, error) {
+		return nil, errors.New("boom")
+	})
+
+	waitForStatus(t, q, "job-1", StatusFailed)
+	job, _ := q.Get("job-1")
+	if job.Error != "boom" {
+		t.Fatalf("error = %q, want %q", job.Error, "boom")
+	}
+}
+
+func TestQueueGetUnknownID(t *testing.T) {
+	q := New(1)
+	if _, ok := q.Get("missing"); ok {
+		t.Fatalf("expected ok=false for unknown job id")
+	}
+}
+
+func waitForStatus(t *testing.T, q *Queue, id string, want Status) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if job, ok := q.Get(id); ok && job.Status == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("job %s did not reach status %s in time", id, want)
+}
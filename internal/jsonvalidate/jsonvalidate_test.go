@@ -0,0 +1,40 @@
+package jsonvalidate
+
+import (
+	"strings"
+	"testing"
+)
+
+type sample struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestDecodeRejectsUnknownFields(t *testing.T) {
+	var s sample
+	err := Decode(strings.NewReader(`{"name":"a","bogus":true}`), &s)
+	if err == nil {
+		t.Fatal("Decode() error = nil, want an unknown field error")
+	}
+}
+
+func TestDecodeReportsTypeMismatchPath(t *testing.T) {
+	var s sample
+	err := Decode(strings.NewReader(`{"name":"a","count":"not a number"}`), &s)
+	if err == nil {
+		t.Fatal("Decode() error = nil, want a type mismatch error")
+	}
+	if !strings.Contains(err.Error(), "count") {
+		t.Fatalf("Decode() error = %q, want it to name the offending field", err)
+	}
+}
+
+func TestDecodeAcceptsValidBody(t *testing.T) {
+	var s sample
+	if err := Decode(strings.NewReader(`{"name":"a","count":3}`), &s); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if s.Name != "a" || s.Count != 3 {
+		t.Fatalf("Decode() = %+v", s)
+	}
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const (
+	emailMaxRetries     = 3
+	emailRetryBaseDelay = 200 * time.Millisecond
+)
+
+// EmailClient sends PRR lifecycle notifications over SMTP, retrying
+// transient failures before giving up, same as webhook.Deliverer does
+// for webhook deliveries.
+type EmailClient struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+
+	// SendFunc actually delivers one message; defaults to smtp.SendMail
+	// against Host:Port. Tests substitute a fake to avoid a real SMTP
+	// connection.
+	SendFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewEmailClient builds an EmailClient that authenticates to host:port
+// with username/password (if set) and sends mail as from.
+func NewEmailClient(host, port, username, password, from string) *EmailClient {
+	return &EmailClient{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+		SendFunc: smtp.SendMail,
+	}
+}
+
+// Send emails subject/body to to, retrying up to emailMaxRetries times
+// with exponential backoff before returning the last error. ctx is
+// accepted for consistency with the other notify clients; net/smtp has
+// no native context support, so an in-flight delivery attempt can't be
+// cancelled, only the wait before the next retry.
+func (c *EmailClient) Send(ctx context.Context, to, subject, body string) error {
+	send := c.SendFunc
+	if send == nil {
+		send = smtp.SendMail
+	}
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+	msg := buildEmailMessage(c.From, to, subject, body)
+	addr := c.Host + ":" + c.Port
+
+	var lastErr error
+	for attempt := 0; attempt <= emailMaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := emailRetryBaseDelay * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err := send(addr, auth, c.From, []string{to}, msg); err != nil {
+			lastErr = fmt.Errorf("notify: send email to %s: %w", to, err)
+			continue
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// buildEmailMessage renders an RFC 5322 message with a plain-text body.
+func buildEmailMessage(from, to, subject, body string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(body)
+	return []byte(b.String())
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// OpsgenieClient creates alerts via the Opsgenie REST API.
+type OpsgenieClient struct {
+	APIKey     string
+	BaseURL    string // defaults to https://api.opsgenie.com if empty
+	HTTPClient *http.Client
+}
+
+// NewOpsgenieClient builds an OpsgenieClient for the given API integration
+// key.
+func NewOpsgenieClient(apiKey string) *OpsgenieClient {
+	return &OpsgenieClient{
+		APIKey:     apiKey,
+		BaseURL:    "https://api.opsgenie.com",
+		HTTPClient: http.DefaultClient,
+	}
+}
+
+type createAlertRequest struct {
+	Message     string   `json:"message"`
+	Description string   `json:"description"`
+	Priority    string   `json:"priority,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// CreateAlert opens a new Opsgenie alert with the given message and
+// description.
+func (c *OpsgenieClient) CreateAlert(ctx context.Context, message, description string, tags ...string) error {
+	body, err := json.Marshal(createAlertRequest{
+		Message:     message,
+		Description: description,
+		Priority:    "P3",
+		Tags:        tags,
+	})
+	if err != nil {
+		return fmt.Errorf("notify: marshal opsgenie alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+"/v2/alerts", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify: build opsgenie request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: create opsgenie alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify: opsgenie returned status %d", resp.StatusCode)
+	}
+	return nil
+}
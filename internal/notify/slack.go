@@ -0,0 +1,53 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// SlackClient posts messages to a Slack incoming webhook.
+type SlackClient struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewSlackClient builds a SlackClient for the given incoming webhook URL.
+func NewSlackClient(webhookURL string) *SlackClient {
+	return &SlackClient{WebhookURL: webhookURL, HTTPClient: http.DefaultClient}
+}
+
+// slackMessage is the payload format Slack incoming webhooks accept.
+type slackMessage struct {
+	Channel string `json:"channel,omitempty"`
+	Text    string `json:"text"`
+}
+
+// Send posts text to the webhook, optionally overriding the channel it
+// was configured for. An empty channel leaves the webhook's own default
+// in place.
+func (c *SlackClient) Send(ctx context.Context, channel, text string) error {
+	body, err := json.Marshal(slackMessage{Channel: channel, Text: text})
+	if err != nil {
+		return fmt.Errorf("notify: marshal slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify: build slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: send slack message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify: slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
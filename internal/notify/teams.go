@@ -0,0 +1,61 @@
+// Package notify sends PRR lifecycle notifications to chat platforms.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// TeamsClient posts messages to a Microsoft Teams incoming webhook.
+type TeamsClient struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewTeamsClient builds a TeamsClient for the given incoming webhook URL.
+func NewTeamsClient(webhookURL string) *TeamsClient {
+	return &TeamsClient{WebhookURL: webhookURL, HTTPClient: http.DefaultClient}
+}
+
+// messageCard is the legacy Office 365 connector card format Teams
+// incoming webhooks accept.
+type messageCard struct {
+	Type    string `json:"@type"`
+	Context string `json:"@context"`
+	Title   string `json:"title"`
+	Text    string `json:"text"`
+}
+
+// Send posts a simple card with the given title and text to the webhook.
+func (c *TeamsClient) Send(ctx context.Context, title, text string) error {
+	card := messageCard{
+		Type:    "MessageCard",
+		Context: "http://schema.org/extensions",
+		Title:   title,
+		Text:    text,
+	}
+	body, err := json.Marshal(card)
+	if err != nil {
+		return fmt.Errorf("notify: marshal teams card: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify: build teams request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: send teams message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify: teams webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
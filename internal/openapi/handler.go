@@ -0,0 +1,26 @@
+package openapi
+
+import (
+	"embed"
+	"encoding/json"
+	"net/http"
+)
+
+//go:embed docs
+var docsFS embed.FS
+
+// SpecHandler and DocsHandler return the spec and Swagger UI handlers to mount on the
+// API's top-level mux: SpecHandler serves the JSON document itself, and
+// DocsHandler serves a Swagger UI page pointed at it. Swagger UI is
+// loaded from a CDN rather than vendored, so DocsHandler needs outbound
+// network access to render; SpecHandler works standalone.
+func SpecHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Build())
+	}
+}
+
+func DocsHandler() http.Handler {
+	return http.FileServer(http.FS(docsFS))
+}
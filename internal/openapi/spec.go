@@ -0,0 +1,240 @@
+// Package openapi builds a hand-written OpenAPI 3 document describing
+// the PRR API, so clients have a machine-readable contract without the
+// handlers needing to carry annotation comments that can drift from the
+// actual routes.
+package openapi
+
+// Document is the root of an OpenAPI 3.0 document. Only the fields this
+// API actually uses are modelled; it is built and marshalled to JSON
+// directly rather than pulling in a schema-generation library.
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type PathItem map[string]Operation
+
+type Operation struct {
+	Summary     string              `json:"summary"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string `json:"name"`
+	In       string `json:"in"`
+	Required bool   `json:"required"`
+	Schema   Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Schema is deliberately loose (map[string]interface{}-backed via Ref or
+// inline Type) since this document exists to describe shapes for
+// tooling, not to be the source of truth for validation.
+type Schema struct {
+	Ref        string            `json:"$ref,omitempty"`
+	Type       string            `json:"type,omitempty"`
+	Properties map[string]Schema `json:"properties,omitempty"`
+	Items      *Schema           `json:"items,omitempty"`
+}
+
+type Components struct {
+	Schemas map[string]Schema `json:"schemas"`
+}
+
+func ref(name string) Schema { return Schema{Ref: "#/components/schemas/" + name} }
+
+func jsonResponse(description, schemaRef string) Response {
+	resp := Response{Description: description}
+	if schemaRef != "" {
+		resp.Content = map[string]MediaType{"application/json": {Schema: ref(schemaRef)}}
+	}
+	return resp
+}
+
+// Build returns the OpenAPI document for the current set of routes
+// exposed by api.Router. It is rebuilt on every request rather than
+// cached, since the document is small and this keeps it trivially
+// correct if routes ever start being added conditionally.
+func Build() Document {
+	schemas := map[string]Schema{
+		"Service": {Type: "object", Properties: map[string]Schema{
+			"id":         {Type: "string"},
+			"name":       {Type: "string"},
+			"created_at": {Type: "string"},
+		}},
+		"Answer": {Type: "object", Properties: map[string]Schema{
+			"question_id": {Type: "string"},
+			"value":       {Type: "string"},
+		}},
+		"Submission": {Type: "object", Properties: map[string]Schema{
+			"id":              {Type: "string"},
+			"service_id":      {Type: "string"},
+			"answers":         {Type: "array", Items: ptr(ref("Answer"))},
+			"status":          {Type: "string"},
+			"approval":        {Type: "string"},
+			"grade":           {Type: "string"},
+			"blocking_issues": {Type: "array", Items: ptr(Schema{Type: "string"})},
+			"created_at":      {Type: "string"},
+			"updated_at":      {Type: "string"},
+		}},
+		"Section": {Type: "object", Properties: map[string]Schema{
+			"id":   {Type: "string"},
+			"name": {Type: "string"},
+		}},
+		"Score": {Type: "object", Properties: map[string]Schema{
+			"points":     {Type: "integer"},
+			"max_points": {Type: "integer"},
+			"percent":    {Type: "number"},
+			"grade":      {Type: "string"},
+		}},
+	}
+
+	paths := map[string]PathItem{
+		"/services": {
+			"get": {
+				Summary:   "List services",
+				Responses: map[string]Response{"200": jsonResponse("services", "")},
+			},
+			"post": {
+				Summary:     "Create a service",
+				RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{"application/json": {Schema: Schema{Type: "object", Properties: map[string]Schema{"name": {Type: "string"}}}}}},
+				Responses:   map[string]Response{"201": jsonResponse("created service", "Service")},
+			},
+		},
+		"/services/{id}/submissions": {
+			"get": {
+				Summary:    "List submission history for a service",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("submission history", "")},
+			},
+		},
+		"/submissions": {
+			"post": {
+				Summary: "Create a submission",
+				Responses: map[string]Response{
+					"201": jsonResponse("created submission", "Submission"),
+					"422": jsonResponse("answers failed strict validation", ""),
+				},
+			},
+		},
+		"/submissions/{id}": {
+			"get": {
+				Summary:    "Get a submission",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("submission", "Submission")},
+			},
+			"put": {
+				Summary:    "Update a draft submission's answers",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("updated submission", "Submission")},
+			},
+		},
+		"/submissions/{id}/finalize": {
+			"post": {
+				Summary:    "Finalize a draft submission",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("finalized submission", "Submission")},
+			},
+		},
+		"/submissions/{id}/approve": {
+			"post": {
+				Summary:    "Record a reviewer approval",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("approved submission", "Submission")},
+			},
+		},
+		"/submissions/{id}/reject": {
+			"post": {
+				Summary:    "Record a reviewer rejection",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("rejected submission", "Submission")},
+			},
+		},
+		"/submissions/{id}/score/explain": {
+			"get": {
+				Summary:    "Explain a submission's score",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("score breakdown", "Score")},
+			},
+		},
+		"/submissions/{id}/gate": {
+			"get": {
+				Summary:    "Evaluate the release gate policy for a submission",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("gate result", "")},
+			},
+		},
+		"/admin/sections": {
+			"get":    {Summary: "List sections", Responses: map[string]Response{"200": jsonResponse("sections", "")}},
+			"post":   {Summary: "Create a section", Responses: map[string]Response{"201": jsonResponse("created section", "Section")}},
+			"put":    {Summary: "Rename a section", Responses: map[string]Response{"200": jsonResponse("updated section", "Section")}},
+			"delete": {Summary: "Delete a section", Responses: map[string]Response{"204": {Description: "deleted"}}},
+		},
+		"/admin/questions/import": {
+			"post": {Summary: "Bulk import questions", Responses: map[string]Response{"200": jsonResponse("import summary", "")}},
+		},
+		"/admin/questions/export": {
+			"get": {Summary: "Export the question bank", Responses: map[string]Response{"200": jsonResponse("question bank dump", "")}},
+		},
+		"/admin/scoring": {
+			"get": {Summary: "Get scoring thresholds", Responses: map[string]Response{"200": jsonResponse("scoring thresholds", "")}},
+			"put": {Summary: "Set scoring thresholds", Responses: map[string]Response{"200": jsonResponse("scoring thresholds", "")}},
+		},
+		"/admin/templates": {
+			"get":    {Summary: "List templates", Responses: map[string]Response{"200": jsonResponse("templates", "")}},
+			"post":   {Summary: "Create a template", Responses: map[string]Response{"201": jsonResponse("created template", "")}},
+			"put":    {Summary: "Update a template", Responses: map[string]Response{"200": jsonResponse("updated template", "")}},
+			"delete": {Summary: "Delete a template", Responses: map[string]Response{"204": {Description: "deleted"}}},
+		},
+		"/admin/teams": {
+			"get":    {Summary: "List teams", Responses: map[string]Response{"200": jsonResponse("teams", "")}},
+			"post":   {Summary: "Create a team", Responses: map[string]Response{"201": jsonResponse("created team", "")}},
+			"put":    {Summary: "Rename a team", Responses: map[string]Response{"200": jsonResponse("updated team", "")}},
+			"delete": {Summary: "Delete a team", Responses: map[string]Response{"204": {Description: "deleted"}}},
+		},
+		"/teams/{id}/readiness": {
+			"get": {
+				Summary:    "Aggregate latest PRR grades for a team's services",
+				Parameters: []Parameter{{Name: "id", In: "path", Required: true, Schema: Schema{Type: "string"}}},
+				Responses:  map[string]Response{"200": jsonResponse("team readiness rollup", "")},
+			},
+		},
+		"/admin/tenants": {
+			"get":    {Summary: "List tenants", Responses: map[string]Response{"200": jsonResponse("tenants", "")}},
+			"post":   {Summary: "Provision a tenant", Responses: map[string]Response{"201": jsonResponse("created tenant", "")}},
+			"put":    {Summary: "Rename a tenant", Responses: map[string]Response{"200": jsonResponse("updated tenant", "")}},
+			"delete": {Summary: "Delete a tenant", Responses: map[string]Response{"204": {Description: "deleted"}}},
+		},
+	}
+
+	return Document{
+		OpenAPI:    "3.0.3",
+		Info:       Info{Title: "PRR API", Version: "1.0.0"},
+		Paths:      paths,
+		Components: Components{Schemas: schemas},
+	}
+}
+
+func ptr(s Schema) *Schema { return &s }
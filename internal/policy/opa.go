@@ -0,0 +1,83 @@
+// Package policy evaluates PRR submissions against policy-as-code rules
+// using the Open Policy Agent (OPA) CLI, so release-gating logic can be
+// reviewed and versioned like any other policy instead of being
+// hard-coded in Go.
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// OPAEvaluator evaluates a query against a rego policy file by shelling
+// out to the `opa` CLI. This avoids depending on the (much larger) OPA Go
+// SDK for a single boolean decision.
+type OPAEvaluator struct {
+	// BinaryPath is the path to the opa executable. Defaults to "opa"
+	// (resolved via PATH) if empty.
+	BinaryPath string
+	// PolicyPath is the rego file or directory to load.
+	PolicyPath string
+	// Query is the rego expression to evaluate, e.g. "data.prr.allow".
+	Query string
+}
+
+// NewOPAEvaluator builds an evaluator for the given policy file and query.
+func NewOPAEvaluator(policyPath, query string) *OPAEvaluator {
+	return &OPAEvaluator{PolicyPath: policyPath, Query: query}
+}
+
+type opaEvalResult struct {
+	Result []struct {
+		Expressions []struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"expressions"`
+	} `json:"result"`
+}
+
+// Evaluate runs the configured query against input and returns the
+// decoded boolean result.
+func (e *OPAEvaluator) Evaluate(ctx context.Context, input interface{}) (bool, error) {
+	binary := e.BinaryPath
+	if binary == "" {
+		binary = "opa"
+	}
+
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return false, fmt.Errorf("policy: marshal input: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, binary, "eval",
+		"--format=json",
+		"--data", e.PolicyPath,
+		"--stdin-input",
+		e.Query,
+	)
+	cmd.Stdin = bytes.NewReader(inputJSON)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return false, fmt.Errorf("policy: run opa eval: %w: %s", err, stderr.String())
+	}
+
+	var result opaEvalResult
+	if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+		return false, fmt.Errorf("policy: parse opa output: %w", err)
+	}
+	if len(result.Result) == 0 || len(result.Result[0].Expressions) == 0 {
+		return false, fmt.Errorf("policy: opa returned no result for query %q", e.Query)
+	}
+
+	var decision bool
+	if err := json.Unmarshal(result.Result[0].Expressions[0].Value, &decision); err != nil {
+		return false, fmt.Errorf("policy: query %q did not evaluate to a boolean: %w", e.Query, err)
+	}
+	return decision, nil
+}
@@ -0,0 +1,31 @@
+// Package prr contains the core domain types and logic for production
+// readiness reviews: services, questionnaires, submissions and scoring.
+package prr
+
+import "time"
+
+// Clock abstracts the current time so that scoring, comparisons, retention
+// and schedule logic can be tested deterministically instead of depending
+// on the wall clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock backed by time.Now.
+type SystemClock struct{}
+
+// Now returns the current wall-clock time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock is a Clock that always returns the same instant. It is
+// intended for use in tests.
+type FixedClock struct {
+	At time.Time
+}
+
+// Now returns the fixed instant the clock was created with.
+func (c FixedClock) Now() time.Time {
+	return c.At
+}
@@ -0,0 +1,24 @@
+package prr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedClock(t *testing.T) {
+	want := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := FixedClock{At: want}
+	if got := c.Now(); !got.Equal(want) {
+		t.Fatalf("Now() = %v, want %v", got, want)
+	}
+}
+
+func TestSequentialIDGenerator(t *testing.T) {
+	g := &SequentialIDGenerator{}
+	if got, want := g.NewID(), "id-1"; got != want {
+		t.Fatalf("NewID() = %q, want %q", got, want)
+	}
+	if got, want := g.NewID(), "id-2"; got != want {
+		t.Fatalf("NewID() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,229 @@
+package prr
+
+import "sort"
+
+// ScoreDirection classifies which way a score moved.
+type ScoreDirection string
+
+const (
+	DirectionImproved  ScoreDirection = "improved"
+	DirectionRegressed ScoreDirection = "regressed"
+	DirectionUnchanged ScoreDirection = "unchanged"
+)
+
+// scoreDirection classifies delta using the same sign convention
+// throughout the comparison: positive is an improvement, negative a
+// regression.
+func scoreDirection(delta float64) ScoreDirection {
+	switch {
+	case delta > 0:
+		return DirectionImproved
+	case delta < 0:
+		return DirectionRegressed
+	default:
+		return DirectionUnchanged
+	}
+}
+
+// SectionScoreDelta is how a single section's score moved between two
+// submissions of the same service. A section present in only one of the
+// two scores (e.g. a template change added or dropped it) has a zero
+// value standing in for its absent side.
+type SectionScoreDelta struct {
+	SectionID     string         `json:"section_id"`
+	BeforePercent float64        `json:"before_percent"`
+	AfterPercent  float64        `json:"after_percent"`
+	DeltaPercent  float64        `json:"delta_percent"`
+	Direction     ScoreDirection `json:"direction"`
+}
+
+// AnswerChange is a single question whose answer differs between two
+// submissions of the same service, for surfacing in a comparison
+// report. An empty Before or After means the question was left
+// unanswered on that side.
+type AnswerChange struct {
+	QuestionID   string `json:"question_id"`
+	QuestionText string `json:"question_text,omitempty"`
+	IsEssential  bool   `json:"is_essential,omitempty"`
+	Before       string `json:"before"`
+	After        string `json:"after"`
+	// QuestionDeleted is true when QuestionID no longer resolves against
+	// the current question bank (it was hard-deleted along with its
+	// section). QuestionText still reflects what it was answered
+	// against, snapshotted on the Answer at submission time.
+	QuestionDeleted bool `json:"question_deleted,omitempty"`
+}
+
+// Comparison is the result of comparing two submissions of the same
+// service: their scores, the section-by-section score movement, and
+// which answers changed between them. Regressions and Improvements are
+// ChangedAnswers narrowed to the subset automation cares about:
+// Regressions so a release can be blocked on them, Improvements so a
+// report can call out what got better rather than only what changed.
+type Comparison struct {
+	FromSubmissionID string              `json:"from_submission_id"`
+	ToSubmissionID   string              `json:"to_submission_id"`
+	FromScore        Score               `json:"from_score"`
+	ToScore          Score               `json:"to_score"`
+	ReadinessDelta   float64             `json:"readiness_delta_percent"`
+	SectionDeltas    []SectionScoreDelta `json:"section_deltas,omitempty"`
+	ChangedAnswers   []AnswerChange      `json:"changed_answers,omitempty"`
+	Regressions      []AnswerChange      `json:"regressions,omitempty"`
+	Improvements     []AnswerChange      `json:"improvements,omitempty"`
+}
+
+// CompareSubmissions computes a Comparison between from and to, which
+// must be two submissions of the same service already scored by the
+// caller (scoring depends on which template, if any, each was answered
+// against, which CompareSubmissions has no way to resolve on its own).
+// questions is used to resolve each changed answer's question text and
+// essential flag and should be the union of both submissions' question
+// banks.
+func CompareSubmissions(from, to Submission, fromScore, toScore Score, questions []Question) Comparison {
+	byID := make(map[string]Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+
+	changed := changedAnswers(from, to, byID)
+	cmp := Comparison{
+		FromSubmissionID: from.ID,
+		ToSubmissionID:   to.ID,
+		FromScore:        fromScore,
+		ToScore:          toScore,
+		ReadinessDelta:   toScore.Percent - fromScore.Percent,
+		SectionDeltas:    sectionScoreDeltas(fromScore, toScore),
+		ChangedAnswers:   changed,
+		Regressions:      regressions(changed),
+		Improvements:     improvements(changed),
+	}
+	return cmp
+}
+
+// regressions narrows changed to essential questions that moved from a
+// passing "yes" answer to a failing "no" one, the signal automation
+// should block a release on.
+func regressions(changed []AnswerChange) []AnswerChange {
+	var out []AnswerChange
+	for _, c := range changed {
+		if c.IsEssential && c.Before == "yes" && c.After == "no" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// improvements narrows changed to answers that moved from a failing
+// "no" answer to a passing "yes" one, on any question.
+func improvements(changed []AnswerChange) []AnswerChange {
+	var out []AnswerChange
+	for _, c := range changed {
+		if c.Before == "no" && c.After == "yes" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// sectionScoreDeltas pairs up fromScore and toScore's SectionScores by
+// SectionID, sorted for a stable report order.
+func sectionScoreDeltas(fromScore, toScore Score) []SectionScoreDelta {
+	before := make(map[string]float64, len(fromScore.SectionScores))
+	for _, s := range fromScore.SectionScores {
+		before[s.SectionID] = s.Percent
+	}
+	after := make(map[string]float64, len(toScore.SectionScores))
+	for _, s := range toScore.SectionScores {
+		after[s.SectionID] = s.Percent
+	}
+
+	seen := make(map[string]bool, len(before)+len(after))
+	var ids []string
+	for id := range before {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for id := range after {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	deltas := make([]SectionScoreDelta, 0, len(ids))
+	for _, id := range ids {
+		delta := after[id] - before[id]
+		deltas = append(deltas, SectionScoreDelta{
+			SectionID:     id,
+			BeforePercent: before[id],
+			AfterPercent:  after[id],
+			DeltaPercent:  delta,
+			Direction:     scoreDirection(delta),
+		})
+	}
+	return deltas
+}
+
+// changedAnswers reports every question whose answer value differs
+// between from and to, including a question answered on only one side.
+func changedAnswers(from, to Submission, byID map[string]Question) []AnswerChange {
+	fromValues := answeredValues(from.Answers)
+	toValues := answeredValues(to.Answers)
+	fromAnswers := answersByQuestionID(from.Answers)
+	toAnswers := answersByQuestionID(to.Answers)
+
+	seen := make(map[string]bool, len(fromValues)+len(toValues))
+	var ids []string
+	for id := range fromValues {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for id := range toValues {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	var changes []AnswerChange
+	for _, id := range ids {
+		before, after := fromValues[id], toValues[id]
+		if before == after {
+			continue
+		}
+		q, known := byID[id]
+		text, essential := q.Text, q.IsEssential
+		if !known {
+			if ans, ok := toAnswers[id]; ok {
+				text = ans.QuestionText
+			} else if ans, ok := fromAnswers[id]; ok {
+				text = ans.QuestionText
+			}
+		}
+		changes = append(changes, AnswerChange{
+			QuestionID:      id,
+			QuestionText:    text,
+			IsEssential:     essential,
+			Before:          before,
+			After:           after,
+			QuestionDeleted: !known,
+		})
+	}
+	return changes
+}
+
+// answersByQuestionID indexes answers by QuestionID, for recovering a
+// deleted question's snapshotted text.
+func answersByQuestionID(answers []Answer) map[string]Answer {
+	byID := make(map[string]Answer, len(answers))
+	for _, ans := range answers {
+		byID[ans.QuestionID] = ans
+	}
+	return byID
+}
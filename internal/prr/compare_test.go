@@ -0,0 +1,121 @@
+package prr
+
+import "testing"
+
+func TestCompareSubmissionsReportsChangedAnswersAndSectionDeltas(t *testing.T) {
+	questions := []Question{
+		{ID: "q1", SectionID: "sec-1", Text: "Is data encrypted?"},
+		{ID: "q2", SectionID: "sec-1", Text: "Is there an on-call runbook?"},
+	}
+	from := Submission{ID: "sub-1", Answers: []Answer{
+		{QuestionID: "q1", Value: "no"},
+		{QuestionID: "q2", Value: "yes"},
+	}}
+	to := Submission{ID: "sub-2", Answers: []Answer{
+		{QuestionID: "q1", Value: "yes"},
+		{QuestionID: "q2", Value: "yes"},
+	}}
+	fromScore := ComputeScore(from, questions)
+	toScore := ComputeScore(to, questions)
+
+	got := CompareSubmissions(from, to, fromScore, toScore, questions)
+
+	if got.FromSubmissionID != "sub-1" || got.ToSubmissionID != "sub-2" {
+		t.Fatalf("Comparison ids = %q/%q", got.FromSubmissionID, got.ToSubmissionID)
+	}
+	if len(got.ChangedAnswers) != 1 || got.ChangedAnswers[0].QuestionID != "q1" {
+		t.Fatalf("ChangedAnswers = %+v, want a single change to q1", got.ChangedAnswers)
+	}
+	if got.ChangedAnswers[0].QuestionText != "Is data encrypted?" {
+		t.Fatalf("ChangedAnswers[0].QuestionText = %q", got.ChangedAnswers[0].QuestionText)
+	}
+	if len(got.SectionDeltas) != 1 || got.SectionDeltas[0].SectionID != "sec-1" {
+		t.Fatalf("SectionDeltas = %+v", got.SectionDeltas)
+	}
+	if got.SectionDeltas[0].DeltaPercent <= 0 {
+		t.Fatalf("SectionDeltas[0].DeltaPercent = %v, want positive (answer improved)", got.SectionDeltas[0].DeltaPercent)
+	}
+	if got.ReadinessDelta <= 0 {
+		t.Fatalf("ReadinessDelta = %v, want positive", got.ReadinessDelta)
+	}
+}
+
+func TestCompareSubmissionsClassifiesRegressionsAndImprovements(t *testing.T) {
+	questions := []Question{
+		{ID: "q1", SectionID: "sec-1", Text: "Is auth enforced?", IsEssential: true},
+		{ID: "q2", SectionID: "sec-1", Text: "Is there a runbook?"},
+		{ID: "q3", SectionID: "sec-1", Text: "Are backups tested?"},
+	}
+	from := Submission{ID: "sub-1", Answers: []Answer{
+		{QuestionID: "q1", Value: "yes"},
+		{QuestionID: "q2", Value: "no"},
+		{QuestionID: "q3", Value: "yes"},
+	}}
+	to := Submission{ID: "sub-2", Answers: []Answer{
+		{QuestionID: "q1", Value: "no"},
+		{QuestionID: "q2", Value: "yes"},
+		{QuestionID: "q3", Value: "no"},
+	}}
+	fromScore := ComputeScore(from, questions)
+	toScore := ComputeScore(to, questions)
+
+	got := CompareSubmissions(from, to, fromScore, toScore, questions)
+
+	if len(got.Regressions) != 1 || got.Regressions[0].QuestionID != "q1" {
+		t.Fatalf("Regressions = %+v, want only essential q1 (yes->no)", got.Regressions)
+	}
+	if len(got.Improvements) != 1 || got.Improvements[0].QuestionID != "q2" {
+		t.Fatalf("Improvements = %+v, want only q2 (no->yes)", got.Improvements)
+	}
+	// q3 moved yes->no but isn't essential, so it's a changed answer but
+	// neither a Regression nor an Improvement.
+	if len(got.ChangedAnswers) != 3 {
+		t.Fatalf("ChangedAnswers = %+v, want all three", got.ChangedAnswers)
+	}
+
+	if len(got.SectionDeltas) != 1 {
+		t.Fatalf("SectionDeltas = %+v", got.SectionDeltas)
+	}
+	if got.SectionDeltas[0].Direction != DirectionRegressed {
+		t.Fatalf("SectionDeltas[0].Direction = %v, want regressed (2 of 3 essential-weight answers flipped to no)", got.SectionDeltas[0].Direction)
+	}
+}
+
+func TestCompareSubmissionsNoChangesReportsNoDiffs(t *testing.T) {
+	questions := []Question{{ID: "q1"}}
+	sub := Submission{ID: "sub-1", Answers: []Answer{{QuestionID: "q1", Value: "yes"}}}
+	score := ComputeScore(sub, questions)
+
+	got := CompareSubmissions(sub, sub, score, score, questions)
+	if len(got.ChangedAnswers) != 0 {
+		t.Fatalf("ChangedAnswers = %+v, want none", got.ChangedAnswers)
+	}
+	if got.ReadinessDelta != 0 {
+		t.Fatalf("ReadinessDelta = %v, want 0", got.ReadinessDelta)
+	}
+}
+
+func TestCompareSubmissionsFallsBackToSnapshottedTextForDeletedQuestion(t *testing.T) {
+	// q1 has since been hard-deleted along with its section, so it's
+	// absent from the current question bank; only the Answer's
+	// snapshotted QuestionText survives.
+	from := Submission{ID: "sub-1", Answers: []Answer{
+		{QuestionID: "q1", Value: "no", QuestionText: "Is the old runbook current?"},
+	}}
+	to := Submission{ID: "sub-2", Answers: []Answer{
+		{QuestionID: "q1", Value: "yes", QuestionText: "Is the old runbook current?"},
+	}}
+
+	got := CompareSubmissions(from, to, Score{}, Score{}, nil)
+
+	if len(got.ChangedAnswers) != 1 {
+		t.Fatalf("ChangedAnswers = %+v, want a single change", got.ChangedAnswers)
+	}
+	change := got.ChangedAnswers[0]
+	if !change.QuestionDeleted {
+		t.Fatalf("QuestionDeleted = false, want true for a question absent from the bank")
+	}
+	if change.QuestionText != "Is the old runbook current?" {
+		t.Fatalf("QuestionText = %q, want the snapshotted text", change.QuestionText)
+	}
+}
@@ -0,0 +1,40 @@
+package prr
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeComplianceStatusNeverSubmittedIsOverdue(t *testing.T) {
+	got := ComputeComplianceStatus(30, time.Time{}, false, time.Now())
+	if got != ComplianceOverdue {
+		t.Fatalf("ComputeComplianceStatus() = %q, want %q", got, ComplianceOverdue)
+	}
+}
+
+func TestComputeComplianceStatusRecentIsCurrent(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := now.AddDate(0, 0, -5)
+	got := ComputeComplianceStatus(30, last, true, now)
+	if got != ComplianceCurrent {
+		t.Fatalf("ComputeComplianceStatus() = %q, want %q", got, ComplianceCurrent)
+	}
+}
+
+func TestComputeComplianceStatusNearIntervalIsDueSoon(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := now.AddDate(0, 0, -28)
+	got := ComputeComplianceStatus(30, last, true, now)
+	if got != ComplianceDueSoon {
+		t.Fatalf("ComputeComplianceStatus() = %q, want %q", got, ComplianceDueSoon)
+	}
+}
+
+func TestComputeComplianceStatusPastIntervalIsOverdue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := now.AddDate(0, 0, -45)
+	got := ComputeComplianceStatus(30, last, true, now)
+	if got != ComplianceOverdue {
+		t.Fatalf("ComputeComplianceStatus() = %q, want %q", got, ComplianceOverdue)
+	}
+}
@@ -0,0 +1,37 @@
+package prr
+
+// IsVisible reports whether q applies given answered, the map of
+// question ID to answered value. A question with no ParentQuestionID
+// always applies; a follow-up question only applies once its parent was
+// answered ShowWhenAnswer.
+func (q Question) IsVisible(answered map[string]string) bool {
+	if q.ParentQuestionID == "" {
+		return true
+	}
+	return answered[q.ParentQuestionID] == q.ShowWhenAnswer
+}
+
+// answeredValues indexes answers by QuestionID for IsVisible lookups.
+func answeredValues(answers []Answer) map[string]string {
+	answered := make(map[string]string, len(answers))
+	for _, ans := range answers {
+		answered[ans.QuestionID] = ans.Value
+	}
+	return answered
+}
+
+// VisibleQuestions narrows questions to those that currently apply given
+// sub's answers, so scoring, gating and validation all agree on which
+// questions are in play. A follow-up question whose parent wasn't
+// answered the triggering way is excluded rather than counted against
+// the submission.
+func VisibleQuestions(sub Submission, questions []Question) []Question {
+	answered := answeredValues(sub.Answers)
+	out := make([]Question, 0, len(questions))
+	for _, q := range questions {
+		if q.IsVisible(answered) {
+			out = append(out, q)
+		}
+	}
+	return out
+}
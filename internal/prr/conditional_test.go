@@ -0,0 +1,54 @@
+package prr
+
+import "testing"
+
+func databaseQuestions() []Question {
+	return []Question{
+		{ID: "has-db", SectionID: "sec-1", IsEssential: true},
+		{ID: "has-backups", SectionID: "sec-1", ParentQuestionID: "has-db", ShowWhenAnswer: "yes", IsEssential: true},
+	}
+}
+
+func TestComputeScoreExcludesHiddenQuestion(t *testing.T) {
+	sub := Submission{Answers: []Answer{{QuestionID: "has-db", Value: "no"}}}
+	score := ComputeScore(sub, databaseQuestions())
+	if score.MaxPoints != 1 {
+		t.Fatalf("MaxPoints = %d, want 1 (backup question hidden since has-db is no)", score.MaxPoints)
+	}
+}
+
+func TestComputeScoreIncludesUnlockedQuestion(t *testing.T) {
+	sub := Submission{Answers: []Answer{
+		{QuestionID: "has-db", Value: "yes"},
+		{QuestionID: "has-backups", Value: "yes"},
+	}}
+	score := ComputeScore(sub, databaseQuestions())
+	if score.MaxPoints != 2 || score.Points != 2 {
+		t.Fatalf("ComputeScore() = %+v, want 2/2 once has-db unlocks has-backups", score)
+	}
+}
+
+func TestComputeBlockingIssuesIgnoresHiddenEssentialQuestion(t *testing.T) {
+	sub := Submission{Answers: []Answer{{QuestionID: "has-db", Value: "no"}}}
+	blocking := ComputeBlockingIssues(sub, databaseQuestions())
+	if len(blocking) != 1 || blocking[0] != "has-db" {
+		t.Fatalf("ComputeBlockingIssues() = %v, want only has-db (has-backups is hidden)", blocking)
+	}
+}
+
+func TestValidateAnswersRejectsHiddenQuestion(t *testing.T) {
+	answers := []Answer{
+		{QuestionID: "has-db", Value: "no"},
+		{QuestionID: "has-backups", Value: "yes"},
+	}
+	errs := ValidateAnswers(answers, databaseQuestions())
+	found := false
+	for _, e := range errs {
+		if e.QuestionID == "has-backups" && e.Reason == "question is hidden by its parent question's answer" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("ValidateAnswers() = %+v, want an error for answering a hidden follow-up question", errs)
+	}
+}
@@ -0,0 +1,49 @@
+package prr
+
+// ComputeBlockingIssues returns the IDs of essential questions in
+// questions that sub answers "no" to or leaves unanswered entirely. A
+// non-empty result means the submission should not be treated as
+// release-ready regardless of its overall score. An essential follow-up
+// question hidden by its parent's answer can't block release, since it
+// doesn't apply to this submission at all. "no" only has meaning for a
+// boolean question, so an essential non-boolean question (single-choice,
+// numeric, free-text) blocks only when left unanswered.
+func ComputeBlockingIssues(sub Submission, questions []Question) []string {
+	questions = VisibleQuestions(sub, questions)
+	answered := answeredValues(sub.Answers)
+
+	var blocking []string
+	for _, q := range questions {
+		if !q.IsEssential {
+			continue
+		}
+		value, ok := answered[q.ID]
+		if !ok || (q.Type.Scorable() && value == "no") {
+			blocking = append(blocking, q.ID)
+		}
+	}
+	return blocking
+}
+
+// MissingEssentialQuestions returns the IDs of essential questions in
+// questions that sub leaves unanswered entirely, unlike
+// ComputeBlockingIssues this does not also flag an essential question
+// answered "no": it exists to tell a client which required questions
+// still need a response, not which ones already fail the gate. A
+// question hidden by its parent's answer is excluded, since it can't be
+// answered at all.
+func MissingEssentialQuestions(sub Submission, questions []Question) []string {
+	questions = VisibleQuestions(sub, questions)
+	answered := answeredValues(sub.Answers)
+
+	var missing []string
+	for _, q := range questions {
+		if !q.IsEssential {
+			continue
+		}
+		if _, ok := answered[q.ID]; !ok {
+			missing = append(missing, q.ID)
+		}
+	}
+	return missing
+}
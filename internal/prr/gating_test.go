@@ -0,0 +1,24 @@
+package prr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeBlockingIssues(t *testing.T) {
+	questions := []Question{
+		{ID: "q1", IsEssential: true},
+		{ID: "q2", IsEssential: true},
+		{ID: "q3"},
+	}
+	sub := Submission{Answers: []Answer{
+		{QuestionID: "q1", Value: "yes"},
+		{QuestionID: "q3", Value: "no"},
+	}}
+
+	got := ComputeBlockingIssues(sub, questions)
+	want := []string{"q2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ComputeBlockingIssues() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,46 @@
+package prr
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync/atomic"
+)
+
+// IDGenerator produces unique identifiers for domain objects. It is
+// injected into handlers so that ID generation can be stubbed out in
+// tests instead of calling a global UUID source. Implementations must be
+// safe for concurrent use: NewID is called from concurrent request
+// handlers, from job-queue worker goroutines processing async
+// submissions, and alongside long-lived SSE streams, so handler
+// execution is never single-threaded in practice.
+type IDGenerator interface {
+	NewID() string
+}
+
+// UUIDGenerator is an IDGenerator that produces random version 4 UUIDs.
+type UUIDGenerator struct{}
+
+// NewID returns a new random UUID v4 string.
+func (UUIDGenerator) NewID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("prr: failed to read random bytes for UUID: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// SequentialIDGenerator is a deterministic IDGenerator for tests. Each call
+// to NewID returns "id-<n>" with an incrementing counter starting at 1.
+// The counter is incremented atomically so concurrent callers (tests
+// that exercise concurrent handlers or async job processing) never
+// collide on the same ID.
+type SequentialIDGenerator struct {
+	next int64
+}
+
+// NewID returns the next sequential test ID.
+func (g *SequentialIDGenerator) NewID() string {
+	return fmt.Sprintf("id-%d", atomic.AddInt64(&g.next, 1))
+}
@@ -0,0 +1,54 @@
+package prr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// hashedSubmission is the canonical, stable subset of a Submission's
+// content that ContentHash and SignSubmission cover. It excludes
+// ContentHash and Signature themselves (hashing can't be
+// self-referential) and everything that legitimately changes after the
+// fact — Status, Approval, Approvals, Grade, BlockingIssues,
+// JiraIssueKeys — since tamper-evidence is about whether the submitted
+// answers were altered, not about the submission's later review state.
+type hashedSubmission struct {
+	ID         string    `json:"id"`
+	ServiceID  string    `json:"service_id"`
+	TemplateID string    `json:"template_id,omitempty"`
+	Answers    []Answer  `json:"answers"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ContentHash returns the hex-encoded SHA-256 digest of sub's submitted
+// content. Two submissions with identical IDs, service, template,
+// answers and creation time hash identically regardless of grading or
+// approval state.
+func ContentHash(sub Submission) string {
+	sum := sha256.Sum256(canonicalSubmissionBytes(sub))
+	return hex.EncodeToString(sum[:])
+}
+
+// SignSubmission returns the hex-encoded HMAC-SHA256 of sub's content
+// hash, keyed with key. It's an extra layer over ContentHash alone:
+// anyone can recompute a hash, but only someone holding key could have
+// produced a signature that verifies against it.
+func SignSubmission(sub Submission, key string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(ContentHash(sub)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func canonicalSubmissionBytes(sub Submission) []byte {
+	raw, _ := json.Marshal(hashedSubmission{
+		ID:         sub.ID,
+		ServiceID:  sub.ServiceID,
+		TemplateID: sub.TemplateID,
+		Answers:    sub.Answers,
+		CreatedAt:  sub.CreatedAt,
+	})
+	return raw
+}
@@ -0,0 +1,32 @@
+package prr
+
+import "testing"
+
+func TestContentHashChangesWithAnswersNotWithApproval(t *testing.T) {
+	sub := Submission{ID: "sub-1", ServiceID: "svc-1", Answers: []Answer{{QuestionID: "q1", Value: "yes"}}}
+	hash := ContentHash(sub)
+
+	approved := sub
+	approved.Approval = ApprovalApproved
+	approved.Grade = GradeGreen
+	if got := ContentHash(approved); got != hash {
+		t.Fatalf("ContentHash changed after approval/grading: got %q, want %q", got, hash)
+	}
+
+	tampered := sub
+	tampered.Answers = []Answer{{QuestionID: "q1", Value: "no"}}
+	if got := ContentHash(tampered); got == hash {
+		t.Fatalf("ContentHash did not change after answers were altered")
+	}
+}
+
+func TestSignSubmissionRequiresMatchingKey(t *testing.T) {
+	sub := Submission{ID: "sub-1", ServiceID: "svc-1", Answers: []Answer{{QuestionID: "q1", Value: "yes"}}}
+	sig := SignSubmission(sub, "key-a")
+	if got := SignSubmission(sub, "key-a"); got != sig {
+		t.Fatalf("SignSubmission is not deterministic for the same key")
+	}
+	if got := SignSubmission(sub, "key-b"); got == sig {
+		t.Fatalf("SignSubmission produced the same signature for a different key")
+	}
+}
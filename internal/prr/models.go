@@ -0,0 +1,687 @@
+package prr
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ServiceTier rates how critical a service is to the business, which
+// guides how strictly its PRR is reviewed.
+type ServiceTier string
+
+const (
+	TierCritical ServiceTier = "critical"
+	TierHigh     ServiceTier = "high"
+	TierMedium   ServiceTier = "medium"
+	TierLow      ServiceTier = "low"
+)
+
+// Service is a team-owned system that goes through production readiness
+// reviews.
+type Service struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// SlackChannel, if set, overrides the default channel configured on
+	// notify.SlackClient when posting this service's PRR notifications,
+	// so a team can route its own alerts to its own channel.
+	SlackChannel string `json:"slack_channel,omitempty"`
+	// Description is a short free-text summary of what the service does.
+	Description string `json:"description,omitempty"`
+	// OwnerTeam is the team accountable for the service's PRR.
+	OwnerTeam string `json:"owner_team,omitempty"`
+	// OwnerEmail is the contact address for PRR follow-ups.
+	OwnerEmail string `json:"owner_email,omitempty"`
+	// Tier rates how critical the service is to the business. Empty
+	// means it hasn't been rated yet.
+	Tier ServiceTier `json:"tier,omitempty"`
+	// RepositoryURL links to the service's source repository.
+	RepositoryURL string `json:"repository_url,omitempty"`
+	// RunbookURL links to the service's operational runbook.
+	RunbookURL string `json:"runbook_url,omitempty"`
+	// Archived marks a service as retired: hidden from default listings
+	// and search, but its PRR history is retained rather than deleted.
+	Archived bool `json:"archived,omitempty"`
+	// TenantID scopes this service to a Tenant on a multi-tenant
+	// deployment. Empty means the service belongs to no tenant, which is
+	// the only state possible on a single-tenant deployment.
+	TenantID string `json:"tenant_id,omitempty"`
+	// ReviewIntervalDays overrides, for this service only, how many days
+	// a submission stays current before the service is due for another
+	// PRR. Zero means this service follows the deployment-wide
+	// ExpiryPolicy instead.
+	ReviewIntervalDays int `json:"review_interval_days,omitempty"`
+}
+
+// ValidTier reports whether tier is empty (unrated) or one of the known
+// ServiceTier values.
+func ValidTier(tier ServiceTier) bool {
+	switch tier {
+	case "", TierCritical, TierHigh, TierMedium, TierLow:
+		return true
+	default:
+		return false
+	}
+}
+
+// ServiceSearchResult is a Service returned from a search query, carrying
+// how well it matched the query alongside the service's own fields.
+type ServiceSearchResult struct {
+	Service
+	// Score is the backend's relevance ranking for this result (e.g. an
+	// Elasticsearch _score or a pg_trgm similarity), not comparable
+	// across different search backends.
+	Score float64 `json:"score"`
+}
+
+// ComplianceStatus summarizes how a service stands against its PRR
+// review interval: whether its latest submission is still current, due
+// soon, or overdue.
+type ComplianceStatus string
+
+const (
+	ComplianceCurrent ComplianceStatus = "current"
+	ComplianceDueSoon ComplianceStatus = "due_soon"
+	ComplianceOverdue ComplianceStatus = "overdue"
+)
+
+// dueSoonWindowDays is how close to its review interval a service must
+// be, without yet being overdue, to be flagged as due soon.
+func dueSoonWindowDays(intervalDays int) float64 {
+	window := float64(intervalDays) * 0.2
+	if window < 1 {
+		window = 1
+	}
+	return window
+}
+
+// ComputeComplianceStatus reports a service's ComplianceStatus as of
+// now, given how many days its latest submission stays current
+// (intervalDays) and when it last submitted. hasSubmission is false for
+// a service that has never submitted a PRR, which is always overdue
+// regardless of interval.
+func ComputeComplianceStatus(intervalDays int, lastSubmittedAt time.Time, hasSubmission bool, now time.Time) ComplianceStatus {
+	if !hasSubmission {
+		return ComplianceOverdue
+	}
+	age := now.Sub(lastSubmittedAt).Hours() / 24
+	if age > float64(intervalDays) {
+		return ComplianceOverdue
+	}
+	if age > float64(intervalDays)-dueSoonWindowDays(intervalDays) {
+		return ComplianceDueSoon
+	}
+	return ComplianceCurrent
+}
+
+// ServiceCompliance wraps a Service with its computed PRR review
+// compliance status, combining its own ReviewIntervalDays override (or
+// the deployment's ExpiryPolicy default) with its most recent
+// submission.
+type ServiceCompliance struct {
+	Service
+	ComplianceStatus  ComplianceStatus `json:"compliance_status"`
+	LatestSubmittedAt *string          `json:"latest_submitted_at,omitempty"`
+}
+
+// Section groups related questions in a PRR questionnaire.
+type Section struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Order positions the section relative to other sections for
+	// display purposes. Sections with equal Order sort by ID.
+	Order int `json:"order,omitempty"`
+	// Translations maps a locale (e.g. "fr", "pt-BR") to that
+	// language's wording of this section, for reviewers working in a
+	// language other than the one Name is written in. A missing or
+	// empty entry falls back to Name.
+	Translations map[string]SectionTranslation `json:"translations,omitempty"`
+}
+
+// SectionTranslation is one locale's wording of a Section.
+type SectionTranslation struct {
+	Name string `json:"name"`
+}
+
+// Attachment is a piece of evidence (an architecture diagram, a runbook
+// PDF) uploaded alongside a submission. The file contents live in the
+// configured blob backend, keyed by ID; this is only the metadata
+// record.
+type Attachment struct {
+	ID           string    `json:"id"`
+	SubmissionID string    `json:"submission_id"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ActionItemStatus tracks whether a remediation task is still
+// outstanding.
+type ActionItemStatus string
+
+const (
+	ActionItemOpen   ActionItemStatus = "open"
+	ActionItemClosed ActionItemStatus = "closed"
+)
+
+// ActionItem is a remediation task a reviewer attached to a submission
+// (optionally to one specific answer) to track closing a gap the PRR
+// uncovered, e.g. "add backups before next review".
+type ActionItem struct {
+	ID           string `json:"id"`
+	SubmissionID string `json:"submission_id"`
+	ServiceID    string `json:"service_id"`
+	// QuestionID, if set, ties this action item to the answer that
+	// prompted it. Empty means it applies to the submission as a whole.
+	QuestionID  string           `json:"question_id,omitempty"`
+	Description string           `json:"description"`
+	Owner       string           `json:"owner"`
+	DueDate     time.Time        `json:"due_date"`
+	Status      ActionItemStatus `json:"status"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// Team is an engineering team that owns one or more services. A
+// Service is assigned to a team by setting its OwnerTeam to the
+// Team's ID; OwnerTeam predates Team as a free-text label and is left
+// that way for services that haven't been reassigned to a real Team
+// yet, so readiness rollups match on either.
+type Team struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Tenant is a business unit hosted on a shared PRR deployment. Services
+// created by a caller resolved to a Tenant (see auth.Principal.TenantID
+// and the X-Tenant-ID header) are stamped with that Tenant's ID and are
+// only visible to callers resolved to the same Tenant. A deployment that
+// never provisions a Tenant behaves as a single-tenant one: TenantID is
+// left empty everywhere and no scoping is applied.
+type Tenant struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Template selects the subset of a question bank that applies to a
+// particular kind of service (a batch job, a UI, a data pipeline, ...),
+// so its submissions are scored only against the questions it actually
+// needs to answer. QuestionIDs is the authoritative selection; SectionIDs
+// is kept alongside it for convenience when building a template from
+// whole sections, but scoring only ever consults QuestionIDs.
+type Template struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	SectionIDs  []string `json:"section_ids,omitempty"`
+	QuestionIDs []string `json:"question_ids"`
+}
+
+// QuestionType classifies how a Question is answered and scored.
+type QuestionType string
+
+const (
+	// QuestionBoolean questions are answered "yes", "no" or "n/a" and
+	// scored like every question was before QuestionType existed.
+	QuestionBoolean QuestionType = "boolean"
+	// QuestionSingleChoice questions are answered with exactly one of
+	// Question.Options.
+	QuestionSingleChoice QuestionType = "single_choice"
+	// QuestionNumeric questions are answered with a number.
+	QuestionNumeric QuestionType = "numeric"
+	// QuestionFreeText questions are answered with arbitrary text.
+	QuestionFreeText QuestionType = "free_text"
+)
+
+// ValidQuestionType reports whether t is empty (treated as
+// QuestionBoolean) or one of the known QuestionType values.
+func ValidQuestionType(t QuestionType) bool {
+	switch t {
+	case "", QuestionBoolean, QuestionSingleChoice, QuestionNumeric, QuestionFreeText:
+		return true
+	default:
+		return false
+	}
+}
+
+// Scorable reports whether an answer to a question of this type counts
+// towards a submission's points. Only boolean questions do; the others
+// capture information for a reviewer to read, not a pass/fail signal.
+func (t QuestionType) Scorable() bool {
+	return t == "" || t == QuestionBoolean
+}
+
+// ValidVocabulary reports whether vocab is a usable set of
+// VocabularyOptions: every Value is non-empty and unique, and every
+// Points is within [0, 1]. A nil or empty vocab is always valid, since
+// it just means the question keeps its default "yes"/"no"/"n/a" values.
+func ValidVocabulary(vocab []VocabularyOption) bool {
+	seen := make(map[string]bool, len(vocab))
+	for _, opt := range vocab {
+		if opt.Value == "" || seen[opt.Value] {
+			return false
+		}
+		seen[opt.Value] = true
+		if opt.Points < 0 || opt.Points > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Question is a single item answered as part of a PRR submission.
+type Question struct {
+	ID        string `json:"id"`
+	SectionID string `json:"section_id"`
+	Text      string `json:"text"`
+	// Type classifies how the question is answered and scored. Empty is
+	// treated as QuestionBoolean, so existing question banks keep
+	// scoring exactly as before.
+	Type QuestionType `json:"type,omitempty"`
+	// Options lists the allowed answer values for a QuestionSingleChoice
+	// question. Ignored for every other type.
+	Options []string `json:"options,omitempty"`
+	// Weight controls how much this question counts towards its
+	// section's score relative to other questions. Zero is treated as
+	// the default weight of 1, so existing question banks without a
+	// weight keep scoring the same as before.
+	Weight int `json:"weight,omitempty"`
+	// IsEssential marks a question as release-blocking: a submission
+	// that answers "no" or leaves it unanswered is flagged with a
+	// BlockingIssue rather than allowed to pass silently.
+	IsEssential bool `json:"is_essential,omitempty"`
+	// Version increments every time the question's Text is edited,
+	// starting at 0 for a question that has never been edited. It lets
+	// an Answer record which wording it actually answered, so a later
+	// edit doesn't silently rewrite the meaning of old submissions.
+	Version int `json:"version,omitempty"`
+	// Order positions the question within its section for display
+	// purposes. Questions with equal Order sort by ID. The question bank
+	// import stores Order as given, without checking for duplicates
+	// within a section; POST /admin/questions/reorder rewrites a whole
+	// section's Order values as distinct sequential positions.
+	Order int `json:"order,omitempty"`
+	// ParentQuestionID, if set, makes this a follow-up question that
+	// only applies when the parent was answered ShowWhenAnswer. Empty
+	// means the question always applies.
+	ParentQuestionID string `json:"parent_question_id,omitempty"`
+	// ShowWhenAnswer is the parent's answer value that unlocks this
+	// question. Ignored when ParentQuestionID is empty.
+	ShowWhenAnswer string `json:"show_when_answer,omitempty"`
+	// Archived marks a question as retired: hidden from default listings
+	// and rejected on new submissions, but its wording and historical
+	// answers are retained rather than deleted.
+	Archived bool `json:"archived,omitempty"`
+	// Blurb is optional explanatory text shown alongside Text on the
+	// review form, for guidance that's too long to fit in the question
+	// itself. Empty means no extra guidance is shown.
+	Blurb string `json:"blurb,omitempty"`
+	// SupportingLink is an optional URL to further reading (a runbook,
+	// a design doc) shown alongside the question on the review form.
+	SupportingLink string `json:"supporting_link,omitempty"`
+	// Translations maps a locale (e.g. "fr", "pt-BR") to that
+	// language's wording of this question, for reviewers working in a
+	// language other than the one Text/Blurb are written in. A missing
+	// entry, or one with an empty Text, falls back to Text/Blurb.
+	Translations map[string]QuestionTranslation `json:"translations,omitempty"`
+	// Vocabulary, if set, replaces the default "yes"/"no"/"n/a" answer
+	// values and their scoring for this question. Ignored for any type
+	// other than QuestionBoolean (the default), which has no other
+	// source of answer values.
+	Vocabulary []VocabularyOption `json:"vocabulary,omitempty"`
+}
+
+// VocabularyOption is one allowed response value for a question whose
+// Vocabulary is set.
+type VocabularyOption struct {
+	// Value is the literal response Answer.Value must equal to select
+	// this option.
+	Value string `json:"value"`
+	// Label is the human-readable text shown for this option on the
+	// review form. Empty falls back to Value.
+	Label string `json:"label,omitempty"`
+	// Points is the fraction (0 to 1) of the question's Weight awarded
+	// when this option is chosen, e.g. 1 for a full pass, 0.5 for a
+	// partial one, 0 for a fail.
+	Points float64 `json:"points"`
+	// CountsTowardTotal reports whether this option's weight should be
+	// included in the denominator at all, for values like "n/a" that a
+	// question shouldn't be penalized or credited for either way.
+	CountsTowardTotal bool `json:"counts_toward_total"`
+}
+
+// QuestionTranslation is one locale's wording of a Question's Text and
+// Blurb.
+type QuestionTranslation struct {
+	Text  string `json:"text"`
+	Blurb string `json:"blurb,omitempty"`
+}
+
+// CommentDiff records how an answer's Comment changed between two
+// submissions of the same question, for surfacing in a comparison
+// report.
+type CommentDiff struct {
+	QuestionID string `json:"question_id"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+}
+
+// WebhookEvent identifies a PRR lifecycle event a Webhook can subscribe
+// to.
+type WebhookEvent string
+
+const (
+	WebhookEventSubmissionCreated  WebhookEvent = "submission.created"
+	WebhookEventSubmissionApproved WebhookEvent = "submission.approved"
+	WebhookEventSubmissionRejected WebhookEvent = "submission.rejected"
+	WebhookEventSubmissionAmended  WebhookEvent = "submission.amended"
+	WebhookEventReadinessDegraded  WebhookEvent = "readiness.degraded"
+)
+
+// Webhook is an integrator-registered URL notified of PRR lifecycle
+// events.
+type Webhook struct {
+	ID     string         `json:"id"`
+	URL    string         `json:"url"`
+	Events []WebhookEvent `json:"events"`
+	// Secret signs each delivery's payload (see internal/webhook), so
+	// the receiving end can verify it actually came from this server.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Subscribes reports whether w is registered for event.
+func (w Webhook) Subscribes(event WebhookEvent) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is a single attempt (successful or not) to deliver an
+// event to a Webhook, kept so integrators and operators can see what was
+// sent and whether it arrived.
+type WebhookDelivery struct {
+	ID         string       `json:"id"`
+	WebhookID  string       `json:"webhook_id"`
+	Event      WebhookEvent `json:"event"`
+	Payload    string       `json:"payload"`
+	Error      string       `json:"error,omitempty"`
+	Attempts   int          `json:"attempts"`
+	DeadLetter bool         `json:"dead_letter"`
+	At         time.Time    `json:"at"`
+}
+
+// QuestionVersion is a snapshot of a Question's wording that was
+// superseded by a later edit, kept so historical answers can still be
+// compared against the text they were actually given in response to.
+type QuestionVersion struct {
+	QuestionID string `json:"question_id"`
+	Version    int    `json:"version"`
+	Text       string `json:"text"`
+}
+
+// Answer records a response to a single Question within a Submission.
+type Answer struct {
+	QuestionID string `json:"question_id"`
+	Value      string `json:"value"`
+	// QuestionVersion is the Question.Version that was current when
+	// this answer was submitted, stamped by the server. A submission
+	// whose answer predates the question's current version answered a
+	// wording that has since changed.
+	QuestionVersion int `json:"question_version,omitempty"`
+	// QuestionText and SectionID are snapshotted from the question bank
+	// at submission time, stamped alongside QuestionVersion. Questions
+	// (and the sections attached to a deleted section) can later be
+	// hard-deleted, at which point a lookup by QuestionID no longer
+	// resolves anything; these fields let old submissions and their
+	// comparison reports stay readable even then.
+	QuestionText string `json:"question_text,omitempty"`
+	SectionID    string `json:"section_id,omitempty"`
+	// Comment is optional free-text context an auditor can read
+	// alongside the bare Yes/No/N/A response.
+	Comment string `json:"comment,omitempty"`
+	// EvidenceLinks are URLs supporting the answer, such as a dashboard,
+	// runbook or test report.
+	EvidenceLinks []string `json:"evidence_links,omitempty"`
+}
+
+// SubmissionStatus tracks whether a submission is still being filled out
+// or has been completed.
+type SubmissionStatus string
+
+const (
+	// SubmissionDraft submissions can still be edited and resumed.
+	SubmissionDraft SubmissionStatus = "draft"
+	// SubmissionFinal submissions are complete and scored.
+	SubmissionFinal SubmissionStatus = "final"
+)
+
+// ApprovalDecision is a reviewer's sign-off decision on a submission.
+type ApprovalDecision string
+
+const (
+	ApprovalPending  ApprovalDecision = "pending"
+	ApprovalApproved ApprovalDecision = "approved"
+	ApprovalRejected ApprovalDecision = "rejected"
+)
+
+// Approval records a single reviewer's sign-off on a submission.
+type Approval struct {
+	Reviewer string           `json:"reviewer"`
+	Decision ApprovalDecision `json:"decision"`
+	Comment  string           `json:"comment,omitempty"`
+	At       time.Time        `json:"at"`
+}
+
+// Grade is a traffic-light readiness rating derived from a submission's
+// overall score percentage.
+type Grade string
+
+const (
+	GradeRed   Grade = "red"
+	GradeAmber Grade = "amber"
+	GradeGreen Grade = "green"
+)
+
+// ScoringThresholds configures the score percentage cutoffs used to
+// compute a Grade. A percentage at or above GreenMin is green, at or
+// above AmberMin but below GreenMin is amber, and anything lower is red.
+type ScoringThresholds struct {
+	GreenMin float64 `json:"green_min"`
+	AmberMin float64 `json:"amber_min"`
+}
+
+// DefaultScoringThresholds are used until an admin configures custom
+// ones via the scoring config endpoint.
+var DefaultScoringThresholds = ScoringThresholds{GreenMin: 80, AmberMin: 50}
+
+// ExpiryPolicy configures how often a service must re-run its PRR
+// before its latest submission is considered stale.
+type ExpiryPolicy struct {
+	// IntervalDays is how many days a submission stays current before
+	// the service is considered due for another PRR.
+	IntervalDays int `json:"interval_days"`
+}
+
+// DefaultExpiryPolicy requires a PRR every six months, until an admin
+// configures a different interval via the expiry config endpoint.
+var DefaultExpiryPolicy = ExpiryPolicy{IntervalDays: 182}
+
+// JiraConfig configures the Jira instance and project that failing
+// essential questions are auto-filed against. A zero value (empty
+// BaseURL) means Jira ticket creation is disabled.
+type JiraConfig struct {
+	// BaseURL is the Jira instance, e.g. "https://example.atlassian.net".
+	// Empty disables ticket creation.
+	BaseURL string `json:"base_url"`
+	// Email is the Atlassian account email used for API token auth.
+	Email string `json:"email"`
+	// APIToken authenticates as Email via HTTP Basic auth, Jira Cloud's
+	// convention for API tokens.
+	APIToken string `json:"api_token,omitempty"`
+	// ProjectKey is the Jira project new issues are filed under, e.g.
+	// "PRR".
+	ProjectKey string `json:"project_key"`
+	// IssueType is the Jira issue type new issues are created as, e.g.
+	// "Task". Empty defaults to "Task".
+	IssueType string `json:"issue_type,omitempty"`
+}
+
+// ComputeGrade maps a score percentage to a Grade using thresholds.
+func ComputeGrade(percent float64, thresholds ScoringThresholds) Grade {
+	switch {
+	case percent >= thresholds.GreenMin:
+		return GradeGreen
+	case percent >= thresholds.AmberMin:
+		return GradeAmber
+	default:
+		return GradeRed
+	}
+}
+
+// Submission is a completed (or in-progress) PRR questionnaire for a
+// Service, captured at a point in time.
+type Submission struct {
+	ID        string `json:"id"`
+	ServiceID string `json:"service_id"`
+	// TemplateID, if set, is the Template this submission was answered
+	// against. Scoring and gating only consider the template's
+	// QuestionIDs instead of the whole question bank.
+	TemplateID string           `json:"template_id,omitempty"`
+	Answers    []Answer         `json:"answers"`
+	Status     SubmissionStatus `json:"status"`
+	Approval   ApprovalDecision `json:"approval"`
+	Approvals  []Approval       `json:"approvals,omitempty"`
+	Grade      Grade            `json:"grade,omitempty"`
+	// BlockingIssues lists the essential questions that were answered
+	// "no" or left unanswered, computed at submission time. A non-empty
+	// list means the service is not actually production ready, even if
+	// its overall score or grade looks acceptable.
+	BlockingIssues []string  `json:"blocking_issues,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	// IdempotencyKey, if set, is the client-supplied key a retried POST
+	// /submissions reuses to get back this submission instead of
+	// creating a duplicate. Empty means the submission was created
+	// without one.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// IsBaseline marks this as the service's pinned comparison point,
+	// set via POST /prr/{id}/baseline. At most one submission per
+	// service should carry this at a time; GET /prr/compare/latest
+	// falls back to it when a service doesn't yet have two submissions
+	// to compare.
+	IsBaseline bool `json:"is_baseline,omitempty"`
+	// AmendsSubmissionID, if set, names the submission this one
+	// corrects, created via PATCH /prr/{id}/answers rather than a new
+	// PRR cycle. The amended submission is a new record; the original
+	// is never mutated. Empty means this submission is not an
+	// amendment.
+	AmendsSubmissionID string `json:"amends_submission_id,omitempty"`
+	// Amendment records who made this amendment and why. Nil unless
+	// AmendsSubmissionID is set.
+	Amendment *Amendment `json:"amendment,omitempty"`
+	// IsPartial marks this as a scoped re-review created via
+	// POST /prr/partial rather than a full PRR cycle: it only re-answers
+	// the sections a team remediated, carrying every other answer over
+	// from AmendsSubmissionID unchanged. Always false for a full
+	// submission.
+	IsPartial bool `json:"is_partial,omitempty"`
+	// SubmitterEmail, if set, is where approval/rejection email
+	// notifications for this submission are sent. Empty means no
+	// submitter contact is on file, in which case those notifications
+	// are silently skipped.
+	SubmitterEmail string `json:"submitter_email,omitempty"`
+	// JiraIssueKeys maps a blocking question ID (see BlockingIssues) to
+	// the Jira issue auto-created for it, so a re-scored submission
+	// updates the existing ticket instead of opening a duplicate. Empty
+	// when Jira integration is not configured or nothing has blocked
+	// yet.
+	JiraIssueKeys map[string]string `json:"jira_issue_keys,omitempty"`
+	// ContentHash is the hex-encoded SHA-256 of this submission's
+	// content (see ContentHash), stamped whenever its Answers change.
+	// GET /prr/{id}/verify recomputes it and reports a mismatch,
+	// proving the stored answers weren't altered outside this API.
+	ContentHash string `json:"content_hash,omitempty"`
+	// Signature is the hex-encoded HMAC-SHA256 of ContentHash, keyed
+	// with the server's signing key (see SignSubmission). Empty when no
+	// signing key is configured, in which case tamper-evidence relies
+	// on ContentHash alone.
+	Signature string `json:"signature,omitempty"`
+	// Locked marks an approved submission as immutable: the store
+	// refuses to create an amendment or partial resubmission against it
+	// (AmendsSubmissionID pointing at it) or an attachment on it, until
+	// an admin unlocks it via POST /submissions/{id}/unlock. Set automatically
+	// when a submission is approved (see recordApproval).
+	Locked bool `json:"locked,omitempty"`
+	// LastUnlock records who most recently cleared Locked and why. Nil
+	// if the submission has never been unlocked.
+	LastUnlock *Unlock `json:"last_unlock,omitempty"`
+}
+
+// Unlock records an admin's justification for clearing a submission's
+// Locked flag via POST /submissions/{id}/unlock.
+type Unlock struct {
+	By     string    `json:"by"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// Amendment records a correction made to a submission's answers via
+// PATCH /prr/{id}/answers, without mutating the submission it corrects.
+type Amendment struct {
+	By     string    `json:"by"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+	// Changed lists the IDs of the questions whose answer this
+	// amendment changed.
+	Changed []string `json:"changed"`
+}
+
+// ServiceReadiness is a materialized summary of a service's latest PRR
+// submission: its score, grade, approval status, and when it was last
+// updated. The API refreshes it transactionally whenever a submission
+// or approval could change what "latest" means for a service (see
+// API.refreshServiceReadiness), so dashboard and search reads can look
+// it up directly instead of re-listing and re-scoring every submission
+// to find the newest one.
+type ServiceReadiness struct {
+	ServiceID    string           `json:"service_id"`
+	SubmissionID string           `json:"submission_id"`
+	Percent      float64          `json:"percent"`
+	Grade        Grade            `json:"grade,omitempty"`
+	Approval     ApprovalDecision `json:"approval,omitempty"`
+	// BlockingIssues mirrors the latest submission's BlockingIssues.
+	BlockingIssues []string  `json:"blocking_issues,omitempty"`
+	SubmittedAt    time.Time `json:"submitted_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// AuditAction identifies what kind of mutation an AuditEntry recorded.
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditEntry records a single create/update/delete mutation against an
+// audited entity (a question, section, service, or submission), for
+// compliance review via GET /admin/audit.
+type AuditEntry struct {
+	ID       string      `json:"id"`
+	Entity   string      `json:"entity"`
+	EntityID string      `json:"entity_id"`
+	Action   AuditAction `json:"action"`
+	// Actor is the authenticated principal's subject, or "" if the
+	// server is running without authentication.
+	Actor string `json:"actor,omitempty"`
+	// Before and After are JSON snapshots of the entity immediately
+	// before and after the mutation. Before is empty for a create;
+	// After is empty for a delete.
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+	At     time.Time       `json:"at"`
+}
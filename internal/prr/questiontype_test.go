@@ -0,0 +1,73 @@
+package prr
+
+import "testing"
+
+func TestComputeScoreExcludesNonScorableQuestion(t *testing.T) {
+	sub := Submission{Answers: []Answer{
+		{QuestionID: "q1", Value: "yes"},
+		{QuestionID: "q2", Value: "42"},
+	}}
+	questions := []Question{
+		{ID: "q1", SectionID: "sec-1"},
+		{ID: "q2", SectionID: "sec-1", Type: QuestionNumeric},
+	}
+
+	score := ComputeScore(sub, questions)
+	if score.Points != 1 || score.MaxPoints != 1 {
+		t.Fatalf("ComputeScore() = %+v, want 1/1 (numeric question doesn't count)", score)
+	}
+	if len(score.Breakdown) != 2 {
+		t.Fatalf("len(Breakdown) = %d, want 2", len(score.Breakdown))
+	}
+	if score.SectionScores[0].MaxPoints != 1 {
+		t.Fatalf("SectionScores[0].MaxPoints = %d, want 1", score.SectionScores[0].MaxPoints)
+	}
+}
+
+func TestComputeBlockingIssuesOnlyUnansweredForNonBooleanEssential(t *testing.T) {
+	questions := []Question{
+		{ID: "q1", SectionID: "sec-1", Type: QuestionFreeText, IsEssential: true},
+	}
+
+	answered := ComputeBlockingIssues(Submission{Answers: []Answer{{QuestionID: "q1", Value: "no"}}}, questions)
+	if len(answered) != 0 {
+		t.Fatalf("ComputeBlockingIssues() = %v, want none ('no' is a valid free-text answer)", answered)
+	}
+
+	unanswered := ComputeBlockingIssues(Submission{}, questions)
+	if len(unanswered) != 1 || unanswered[0] != "q1" {
+		t.Fatalf("ComputeBlockingIssues() = %v, want [q1] when left unanswered", unanswered)
+	}
+}
+
+func TestValidateAnswersSingleChoice(t *testing.T) {
+	questions := []Question{
+		{ID: "q1", SectionID: "sec-1", Type: QuestionSingleChoice, Options: []string{"red", "blue"}},
+	}
+
+	if errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "red"}}, questions); len(errs) != 0 {
+		t.Fatalf("ValidateAnswers() = %+v, want none for a valid option", errs)
+	}
+	if errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "green"}}, questions); len(errs) != 1 {
+		t.Fatalf("ValidateAnswers() = %+v, want one error for an out-of-option value", errs)
+	}
+}
+
+func TestValidateAnswersNumeric(t *testing.T) {
+	questions := []Question{{ID: "q1", SectionID: "sec-1", Type: QuestionNumeric}}
+
+	if errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "3.5"}}, questions); len(errs) != 0 {
+		t.Fatalf("ValidateAnswers() = %+v, want none for a numeric value", errs)
+	}
+	if errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "not-a-number"}}, questions); len(errs) != 1 {
+		t.Fatalf("ValidateAnswers() = %+v, want one error for a non-numeric value", errs)
+	}
+}
+
+func TestValidateAnswersFreeText(t *testing.T) {
+	questions := []Question{{ID: "q1", SectionID: "sec-1", Type: QuestionFreeText}}
+
+	if errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "anything goes here"}}, questions); len(errs) != 0 {
+		t.Fatalf("ValidateAnswers() = %+v, want none for free text", errs)
+	}
+}
@@ -0,0 +1,182 @@
+package prr
+
+import "math"
+
+// AnswerScore is the scoring contribution of a single answer.
+type AnswerScore struct {
+	QuestionID string `json:"question_id"`
+	Value      string `json:"value"`
+	Points     int    `json:"points"`
+	MaxPoints  int    `json:"max_points"`
+	// Stale is true when the answer was given against an earlier
+	// version of the question than its current wording, so reviewers
+	// know to double check it rather than trust it against stale text.
+	Stale bool `json:"stale,omitempty"`
+}
+
+// SectionScore is the weighted score for a single section, aggregated
+// from the answers to its questions.
+type SectionScore struct {
+	SectionID string  `json:"section_id"`
+	Points    int     `json:"points"`
+	MaxPoints int     `json:"max_points"`
+	Percent   float64 `json:"percent"`
+}
+
+// Score is the result of scoring a submission.
+type Score struct {
+	Points        int            `json:"points"`
+	MaxPoints     int            `json:"max_points"`
+	Percent       float64        `json:"percent"`
+	Grade         Grade          `json:"grade,omitempty"`
+	Breakdown     []AnswerScore  `json:"breakdown"`
+	SectionScores []SectionScore `json:"section_scores,omitempty"`
+}
+
+// pointsForAnswer awards the question's full weight for "yes", zero
+// otherwise.
+func pointsForAnswer(value string, weight int) int {
+	if value == "yes" {
+		return weight
+	}
+	return 0
+}
+
+// scoreAnswer returns the points and max points an answer to a scorable
+// question contributes. With no Vocabulary configured, this is the
+// legacy "yes" scores full weight, "no"/"n/a" score zero but still
+// count towards the denominator. With a Vocabulary, the matched
+// option's Points fraction of weight is awarded, and its
+// CountsTowardTotal decides whether weight counts towards the
+// denominator at all. A value matching no option in the vocabulary
+// scores zero and doesn't count towards the denominator, the same
+// treatment a hidden follow-up question gets.
+func scoreAnswer(q Question, value string, weight int) (points int, maxPoints int) {
+	if len(q.Vocabulary) == 0 {
+		return pointsForAnswer(value, weight), weight
+	}
+	for _, opt := range q.Vocabulary {
+		if opt.Value != value {
+			continue
+		}
+		if !opt.CountsTowardTotal {
+			return 0, 0
+		}
+		return int(math.Round(opt.Points * float64(weight))), weight
+	}
+	return 0, 0
+}
+
+// questionWeight returns q.Weight, treating the zero value as the
+// default weight of 1 so unweighted question banks score unchanged.
+func questionWeight(q Question) int {
+	if q.Weight <= 0 {
+		return 1
+	}
+	return q.Weight
+}
+
+// QuestionsForTemplate narrows questions down to those selected by
+// tmpl.QuestionIDs, so scoring and gating a templated submission only
+// consider questions the template actually asks. A nil tmpl returns
+// questions unchanged.
+func QuestionsForTemplate(questions []Question, tmpl *Template) []Question {
+	if tmpl == nil {
+		return questions
+	}
+	allowed := make(map[string]bool, len(tmpl.QuestionIDs))
+	for _, id := range tmpl.QuestionIDs {
+		allowed[id] = true
+	}
+	out := make([]Question, 0, len(questions))
+	for _, q := range questions {
+		if allowed[q.ID] {
+			out = append(out, q)
+		}
+	}
+	return out
+}
+
+// ComputeScore scores a submission by weighting each answer by its
+// question's Weight (defaulting to 1), and aggregates per-section
+// weighted scores alongside the overall total. questions is used to
+// look up each answer's weight and section; answers whose question is
+// not found score with weight 1 and no section. A follow-up question
+// hidden by its parent's answer is excluded from the denominator
+// entirely, as if it weren't part of the question bank at all. An
+// answer to a non-scorable question type (anything but
+// QuestionBoolean) still gets a Breakdown entry, but contributes no
+// points to the submission, its section, or either's max, since
+// there's no pass/fail signal to weigh in a single-choice, numeric or
+// free-text response. A question with a Vocabulary scores using its
+// matched option's Points and CountsTowardTotal (see scoreAnswer)
+// instead of the legacy "yes"/"no"/"n/a" rule.
+func ComputeScore(sub Submission, questions []Question) Score {
+	byID := make(map[string]Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+	answered := answeredValues(sub.Answers)
+
+	score := Score{Breakdown: make([]AnswerScore, 0, len(sub.Answers))}
+	sectionByID := make(map[string]*SectionScore)
+	var sectionOrder []string
+
+	for _, ans := range sub.Answers {
+		q, known := byID[ans.QuestionID]
+		if known && !q.IsVisible(answered) {
+			continue
+		}
+		weight := 1
+		sectionID := ""
+		if known {
+			weight = questionWeight(q)
+			sectionID = q.SectionID
+		}
+		scorable := !known || q.Type.Scorable()
+
+		points := 0
+		maxPoints := 0
+		if scorable {
+			if known {
+				points, maxPoints = scoreAnswer(q, ans.Value, weight)
+			} else {
+				points, maxPoints = pointsForAnswer(ans.Value, weight), weight
+			}
+			score.Points += points
+			score.MaxPoints += maxPoints
+		}
+		score.Breakdown = append(score.Breakdown, AnswerScore{
+			QuestionID: ans.QuestionID,
+			Value:      ans.Value,
+			Points:     points,
+			MaxPoints:  maxPoints,
+			Stale:      known && ans.QuestionVersion != q.Version,
+		})
+
+		if sectionID == "" || !scorable {
+			continue
+		}
+		sec, ok := sectionByID[sectionID]
+		if !ok {
+			sec = &SectionScore{SectionID: sectionID}
+			sectionByID[sectionID] = sec
+			sectionOrder = append(sectionOrder, sectionID)
+		}
+		sec.Points += points
+		sec.MaxPoints += maxPoints
+	}
+
+	for _, id := range sectionOrder {
+		sec := sectionByID[id]
+		if sec.MaxPoints > 0 {
+			sec.Percent = 100 * float64(sec.Points) / float64(sec.MaxPoints)
+		}
+		score.SectionScores = append(score.SectionScores, *sec)
+	}
+
+	if score.MaxPoints > 0 {
+		score.Percent = 100 * float64(score.Points) / float64(score.MaxPoints)
+	}
+	return score
+}
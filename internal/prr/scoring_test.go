@@ -0,0 +1,103 @@
+package prr
+
+import "testing"
+
+func TestComputeScore(t *testing.T) {
+	sub := Submission{Answers: []Answer{
+		{QuestionID: "q1", Value: "yes"},
+		{QuestionID: "q2", Value: "no"},
+	}}
+
+	score := ComputeScore(sub, nil)
+	if score.Points != 1 || score.MaxPoints != 2 {
+		t.Fatalf("ComputeScore() = %+v, want 1/2", score)
+	}
+	if score.Percent != 50 {
+		t.Fatalf("Percent = %v, want 50", score.Percent)
+	}
+	if len(score.Breakdown) != 2 {
+		t.Fatalf("len(Breakdown) = %d, want 2", len(score.Breakdown))
+	}
+}
+
+func TestComputeScoreWeighted(t *testing.T) {
+	sub := Submission{Answers: []Answer{
+		{QuestionID: "q1", Value: "yes"},
+		{QuestionID: "q2", Value: "no"},
+		{QuestionID: "q3", Value: "yes"},
+	}}
+	questions := []Question{
+		{ID: "q1", SectionID: "sec-1", Weight: 3},
+		{ID: "q2", SectionID: "sec-1", Weight: 1},
+		{ID: "q3", SectionID: "sec-2", Weight: 2},
+	}
+
+	score := ComputeScore(sub, questions)
+	if score.Points != 5 || score.MaxPoints != 6 {
+		t.Fatalf("ComputeScore() = %+v, want 5/6", score)
+	}
+	if len(score.SectionScores) != 2 {
+		t.Fatalf("len(SectionScores) = %d, want 2", len(score.SectionScores))
+	}
+	for _, sec := range score.SectionScores {
+		switch sec.SectionID {
+		case "sec-1":
+			if sec.Points != 3 || sec.MaxPoints != 4 {
+				t.Fatalf("sec-1 = %+v, want 3/4", sec)
+			}
+		case "sec-2":
+			if sec.Points != 2 || sec.MaxPoints != 2 {
+				t.Fatalf("sec-2 = %+v, want 2/2", sec)
+			}
+		default:
+			t.Fatalf("unexpected section %q", sec.SectionID)
+		}
+	}
+}
+
+func TestComputeScoreWithVocabulary(t *testing.T) {
+	sub := Submission{Answers: []Answer{
+		{QuestionID: "q1", Value: "partial"},
+		{QuestionID: "q2", Value: "exempt"},
+	}}
+	questions := []Question{
+		{ID: "q1", SectionID: "sec-1", Weight: 4, Vocabulary: []VocabularyOption{
+			{Value: "full", Points: 1, CountsTowardTotal: true},
+			{Value: "partial", Points: 0.5, CountsTowardTotal: true},
+			{Value: "none", Points: 0, CountsTowardTotal: true},
+		}},
+		{ID: "q2", SectionID: "sec-1", Weight: 2, Vocabulary: []VocabularyOption{
+			{Value: "exempt", Points: 0, CountsTowardTotal: false},
+		}},
+	}
+
+	score := ComputeScore(sub, questions)
+	if score.Points != 2 || score.MaxPoints != 4 {
+		t.Fatalf("ComputeScore() = %+v, want 2/4 (q1 half credit, q2 excluded)", score)
+	}
+}
+
+func TestComputeScoreFlagsStaleAnswers(t *testing.T) {
+	sub := Submission{Answers: []Answer{
+		{QuestionID: "q1", Value: "yes", QuestionVersion: 0},
+		{QuestionID: "q2", Value: "yes", QuestionVersion: 2},
+	}}
+	questions := []Question{
+		{ID: "q1", Version: 1},
+		{ID: "q2", Version: 2},
+	}
+
+	score := ComputeScore(sub, questions)
+	for _, ans := range score.Breakdown {
+		switch ans.QuestionID {
+		case "q1":
+			if !ans.Stale {
+				t.Fatalf("q1 answered against version 0 of a version-1 question should be stale")
+			}
+		case "q2":
+			if ans.Stale {
+				t.Fatalf("q2 answered against its current version should not be stale")
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package prr
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// ValidAnswerValues are the response values a QuestionBoolean question
+// may be answered with. Anything else fails ValidateAnswers.
+var ValidAnswerValues = map[string]bool{"yes": true, "no": true, "n/a": true}
+
+// maxCommentLength bounds Answer.Comment so a runaway paste can't bloat
+// a submission indefinitely.
+const maxCommentLength = 2000
+
+// maxFreeTextLength bounds a QuestionFreeText answer's Value for the
+// same reason maxCommentLength bounds Comment.
+const maxFreeTextLength = 4000
+
+// validAnswerValue reports whether value is an acceptable response to
+// q, given q.Type, along with the reason to report when it isn't.
+func validAnswerValue(q Question, value string) (bool, string) {
+	switch q.Type {
+	case QuestionSingleChoice:
+		for _, opt := range q.Options {
+			if value == opt {
+				return true, ""
+			}
+		}
+		return false, fmt.Sprintf("invalid response value %q: must be one of the question's options", value)
+	case QuestionNumeric:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return false, fmt.Sprintf("invalid response value %q: must be numeric", value)
+		}
+		return true, ""
+	case QuestionFreeText:
+		if len(value) > maxFreeTextLength {
+			return false, fmt.Sprintf("response exceeds %d characters", maxFreeTextLength)
+		}
+		return true, ""
+	default:
+		if len(q.Vocabulary) > 0 {
+			for _, opt := range q.Vocabulary {
+				if value == opt.Value {
+					return true, ""
+				}
+			}
+			return false, fmt.Sprintf("invalid response value %q: must be one of the question's vocabulary", value)
+		}
+		if !ValidAnswerValues[value] {
+			return false, fmt.Sprintf("invalid response value %q", value)
+		}
+		return true, ""
+	}
+}
+
+// ValidationError describes why a single answer was rejected by
+// ValidateAnswers.
+type ValidationError struct {
+	QuestionID string `json:"question_id"`
+	Reason     string `json:"reason"`
+}
+
+// ValidateAnswers checks answers in strict mode: every QuestionID must
+// be in questions, must not reference an archived question, must
+// appear at most once, must not be hidden by its parent question's
+// answer, Value must be a valid response for the
+// question's Type (one of ValidAnswerValues, or the question's own
+// Vocabulary if set, for QuestionBoolean; one of
+// Options for QuestionSingleChoice, a number for QuestionNumeric, or
+// any string up to maxFreeTextLength for QuestionFreeText), Comment
+// must not exceed maxCommentLength, and every EvidenceLinks entry must
+// be an absolute http(s) URL. An answer to an unknown question is
+// checked as QuestionBoolean, since there's no declared type to defer
+// to. It returns one ValidationError per violation found, in the order
+// the answers were given, or nil if all pass.
+func ValidateAnswers(answers []Answer, questions []Question) []ValidationError {
+	byID := make(map[string]Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+	answered := answeredValues(answers)
+
+	seen := make(map[string]bool, len(answers))
+	var errs []ValidationError
+	for _, ans := range answers {
+		q, known := byID[ans.QuestionID]
+		if !known {
+			errs = append(errs, ValidationError{QuestionID: ans.QuestionID, Reason: "unknown question id"})
+		} else if q.Archived {
+			errs = append(errs, ValidationError{QuestionID: ans.QuestionID, Reason: "question has been archived"})
+		} else if !q.IsVisible(answered) {
+			errs = append(errs, ValidationError{QuestionID: ans.QuestionID, Reason: "question is hidden by its parent question's answer"})
+		}
+		if seen[ans.QuestionID] {
+			errs = append(errs, ValidationError{QuestionID: ans.QuestionID, Reason: "duplicate answer for this question"})
+		}
+		seen[ans.QuestionID] = true
+
+		if ok, reason := validAnswerValue(q, ans.Value); !ok {
+			errs = append(errs, ValidationError{QuestionID: ans.QuestionID, Reason: reason})
+		}
+		if len(ans.Comment) > maxCommentLength {
+			errs = append(errs, ValidationError{QuestionID: ans.QuestionID, Reason: fmt.Sprintf("comment exceeds %d characters", maxCommentLength)})
+		}
+		for _, link := range ans.EvidenceLinks {
+			if !isEvidenceLink(link) {
+				errs = append(errs, ValidationError{QuestionID: ans.QuestionID, Reason: fmt.Sprintf("invalid evidence link %q", link)})
+			}
+		}
+	}
+	return errs
+}
+
+// isEvidenceLink reports whether link is an absolute http(s) URL, the
+// only kind worth storing as evidence since anything else (a relative
+// path, a bare string) can't be opened by an auditor later.
+func isEvidenceLink(link string) bool {
+	u, err := url.Parse(link)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,90 @@
+package prr
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAnswers(t *testing.T) {
+	questions := []Question{{ID: "q1"}, {ID: "q2"}}
+
+	if errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "yes"}}, questions); errs != nil {
+		t.Fatalf("ValidateAnswers() = %v, want nil for a valid answer", errs)
+	}
+
+	errs := ValidateAnswers([]Answer{
+		{QuestionID: "q-unknown", Value: "yes"},
+		{QuestionID: "q1", Value: "yes"},
+		{QuestionID: "q1", Value: "no"},
+		{QuestionID: "q2", Value: "maybe"},
+	}, questions)
+	if len(errs) != 3 {
+		t.Fatalf("len(errs) = %d, want 3: %+v", len(errs), errs)
+	}
+	if errs[0].QuestionID != "q-unknown" || errs[0].Reason != "unknown question id" {
+		t.Fatalf("errs[0] = %+v", errs[0])
+	}
+	if errs[1].QuestionID != "q1" || errs[1].Reason != "duplicate answer for this question" {
+		t.Fatalf("errs[1] = %+v", errs[1])
+	}
+	if errs[2].QuestionID != "q2" || errs[2].Reason == "" {
+		t.Fatalf("errs[2] = %+v", errs[2])
+	}
+}
+
+func TestValidateAnswersCommentAndEvidence(t *testing.T) {
+	questions := []Question{{ID: "q1"}}
+
+	if errs := ValidateAnswers([]Answer{{
+		QuestionID:    "q1",
+		Value:         "yes",
+		Comment:       "load tested against 10x peak traffic",
+		EvidenceLinks: []string{"https://dash.example.com/q1"},
+	}}, questions); errs != nil {
+		t.Fatalf("ValidateAnswers() = %v, want nil for a valid comment/evidence", errs)
+	}
+
+	errs := ValidateAnswers([]Answer{{
+		QuestionID:    "q1",
+		Value:         "yes",
+		Comment:       strings.Repeat("x", maxCommentLength+1),
+		EvidenceLinks: []string{"not-a-url", "ftp://example.com/file"},
+	}}, questions)
+	if len(errs) != 3 {
+		t.Fatalf("len(errs) = %d, want 3: %+v", len(errs), errs)
+	}
+}
+
+func TestValidateAnswersVocabulary(t *testing.T) {
+	questions := []Question{{ID: "q1", Vocabulary: []VocabularyOption{
+		{Value: "full", Points: 1, CountsTowardTotal: true},
+		{Value: "exempt", Points: 0, CountsTowardTotal: false},
+	}}}
+
+	if errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "exempt"}}, questions); errs != nil {
+		t.Fatalf("ValidateAnswers() = %v, want nil for a value in the question's vocabulary", errs)
+	}
+
+	errs := ValidateAnswers([]Answer{{QuestionID: "q1", Value: "yes"}}, questions)
+	if len(errs) != 1 {
+		t.Fatalf("len(errs) = %d, want 1 for a value outside the question's vocabulary: %+v", len(errs), errs)
+	}
+}
+
+func TestValidVocabulary(t *testing.T) {
+	if !ValidVocabulary(nil) {
+		t.Fatal("ValidVocabulary(nil) = false, want true")
+	}
+	if !ValidVocabulary([]VocabularyOption{{Value: "full", Points: 1}}) {
+		t.Fatal("ValidVocabulary() = false for a valid option, want true")
+	}
+	if ValidVocabulary([]VocabularyOption{{Value: "full"}, {Value: "full"}}) {
+		t.Fatal("ValidVocabulary() = true for duplicate values, want false")
+	}
+	if ValidVocabulary([]VocabularyOption{{Value: ""}}) {
+		t.Fatal("ValidVocabulary() = true for an empty value, want false")
+	}
+	if ValidVocabulary([]VocabularyOption{{Value: "full", Points: 1.5}}) {
+		t.Fatal("ValidVocabulary() = true for out-of-range points, want false")
+	}
+}
@@ -0,0 +1,98 @@
+// Package report renders PRR submissions and comparisons as
+// human-readable documents for sharing outside the API, such as in
+// Confluence pages or PDF exports.
+package report
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// RenderSubmissionHTML renders a submission as a Confluence
+// "storage format" compatible HTML fragment.
+func RenderSubmissionHTML(svc prr.Service, sub prr.Submission) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<h1>%s &mdash; PRR submitted %s</h1>\n", html.EscapeString(svc.Name), sub.CreatedAt.Format("2006-01-02 15:04 MST"))
+	b.WriteString("<table><tbody>\n")
+	b.WriteString("<tr><th>Question</th><th>Answer</th><th>Comment</th><th>Evidence</th></tr>\n")
+	for _, ans := range sub.Answers {
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			html.EscapeString(ans.QuestionID), html.EscapeString(ans.Value), html.EscapeString(ans.Comment), html.EscapeString(strings.Join(ans.EvidenceLinks, ", ")))
+	}
+	b.WriteString("</tbody></table>\n")
+	if sub.ContentHash != "" {
+		fmt.Fprintf(&b, "<p>Content hash (SHA-256): %s</p>\n", html.EscapeString(sub.ContentHash))
+		if sub.Signature != "" {
+			fmt.Fprintf(&b, "<p>Signature (HMAC-SHA256): %s</p>\n", html.EscapeString(sub.Signature))
+		}
+	}
+	return b.String()
+}
+
+// RenderComparisonHTML renders a prr.Comparison as a Confluence "storage
+// format" compatible HTML fragment: overall and per-section score
+// deltas, followed by a table of every changed answer.
+func RenderComparisonHTML(svc prr.Service, cmp prr.Comparison) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<h1>%s &mdash; PRR comparison</h1>\n", html.EscapeString(svc.Name))
+	fmt.Fprintf(&b, "<p>%s &rarr; %s</p>\n", html.EscapeString(cmp.FromSubmissionID), html.EscapeString(cmp.ToSubmissionID))
+	fmt.Fprintf(&b, "<p>Overall score: %.1f%% &rarr; %.1f%% (%+.1f%%)</p>\n", cmp.FromScore.Percent, cmp.ToScore.Percent, cmp.ReadinessDelta)
+
+	if len(cmp.SectionDeltas) > 0 {
+		b.WriteString("<table><tbody>\n")
+		b.WriteString("<tr><th>Section</th><th>Before</th><th>After</th><th>Delta</th></tr>\n")
+		for _, d := range cmp.SectionDeltas {
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%.1f%%</td><td>%.1f%%</td><td>%+.1f%%</td></tr>\n",
+				html.EscapeString(d.SectionID), d.BeforePercent, d.AfterPercent, d.DeltaPercent)
+		}
+		b.WriteString("</tbody></table>\n")
+	}
+
+	if len(cmp.ChangedAnswers) > 0 {
+		b.WriteString("<h2>Changed answers</h2>\n")
+		b.WriteString("<table><tbody>\n")
+		b.WriteString("<tr><th>Question</th><th>Before</th><th>After</th></tr>\n")
+		for _, a := range cmp.ChangedAnswers {
+			question := a.QuestionText
+			if question == "" {
+				question = a.QuestionID
+			}
+			if a.QuestionDeleted {
+				question += " (deleted)"
+			}
+			fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+				html.EscapeString(question), html.EscapeString(a.Before), html.EscapeString(a.After))
+		}
+		b.WriteString("</tbody></table>\n")
+	}
+	return b.String()
+}
+
+// DiffAnswerComments compares two submissions for the same service and
+// returns the questions whose Comment changed between them, for
+// surfacing in a comparison report. Answers present in only one
+// submission are not reported here; ComputeBlockingIssues and the score
+// breakdown already cover missing/changed answers themselves.
+func DiffAnswerComments(from, to prr.Submission) []prr.CommentDiff {
+	commentsFrom := make(map[string]string, len(from.Answers))
+	for _, ans := range from.Answers {
+		commentsFrom[ans.QuestionID] = ans.Comment
+	}
+
+	var diffs []prr.CommentDiff
+	for _, ans := range to.Answers {
+		before, ok := commentsFrom[ans.QuestionID]
+		if !ok || before == ans.Comment {
+			continue
+		}
+		diffs = append(diffs, prr.CommentDiff{
+			QuestionID: ans.QuestionID,
+			Before:     before,
+			After:      ans.Comment,
+		})
+	}
+	return diffs
+}
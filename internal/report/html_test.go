@@ -0,0 +1,66 @@
+package report
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+func TestRenderSubmissionHTMLIncludesCommentsAndEvidence(t *testing.T) {
+	svc := prr.Service{Name: "checkout"}
+	sub := prr.Submission{Answers: []prr.Answer{
+		{QuestionID: "q1", Value: "yes", Comment: "load tested last week", EvidenceLinks: []string{"https://dash.example.com/q1"}},
+	}}
+
+	out := RenderSubmissionHTML(svc, sub)
+	if !strings.Contains(out, "load tested last week") {
+		t.Fatalf("output missing comment: %s", out)
+	}
+	if !strings.Contains(out, "https://dash.example.com/q1") {
+		t.Fatalf("output missing evidence link: %s", out)
+	}
+}
+
+func TestRenderComparisonHTMLIncludesSectionDeltasAndChangedAnswers(t *testing.T) {
+	svc := prr.Service{Name: "checkout"}
+	cmp := prr.Comparison{
+		FromSubmissionID: "sub-1",
+		ToSubmissionID:   "sub-2",
+		FromScore:        prr.Score{Percent: 50},
+		ToScore:          prr.Score{Percent: 75},
+		ReadinessDelta:   25,
+		SectionDeltas:    []prr.SectionScoreDelta{{SectionID: "sec-1", BeforePercent: 50, AfterPercent: 100, DeltaPercent: 50}},
+		ChangedAnswers:   []prr.AnswerChange{{QuestionID: "q1", QuestionText: "Is data encrypted?", Before: "no", After: "yes"}},
+	}
+
+	out := RenderComparisonHTML(svc, cmp)
+	if !strings.Contains(out, "sub-1") || !strings.Contains(out, "sub-2") {
+		t.Fatalf("output missing submission ids: %s", out)
+	}
+	if !strings.Contains(out, "Is data encrypted?") {
+		t.Fatalf("output missing changed answer's question text: %s", out)
+	}
+	if !strings.Contains(out, "sec-1") {
+		t.Fatalf("output missing section delta row: %s", out)
+	}
+}
+
+func TestDiffAnswerComments(t *testing.T) {
+	from := prr.Submission{Answers: []prr.Answer{
+		{QuestionID: "q1", Comment: "initial"},
+		{QuestionID: "q2", Comment: "unchanged"},
+	}}
+	to := prr.Submission{Answers: []prr.Answer{
+		{QuestionID: "q1", Comment: "updated after incident"},
+		{QuestionID: "q2", Comment: "unchanged"},
+		{QuestionID: "q3", Comment: "new answer, no prior comment to diff against"},
+	}}
+
+	got := DiffAnswerComments(from, to)
+	want := []prr.CommentDiff{{QuestionID: "q1", Before: "initial", After: "updated after incident"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DiffAnswerComments() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// RenderComparisonMarkdown renders a prr.Comparison as GitHub-flavoured
+// Markdown, suitable for pasting into a change ticket or a pull request
+// description.
+func RenderComparisonMarkdown(svc prr.Service, cmp prr.Comparison) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s — PRR comparison\n\n", svc.Name)
+	fmt.Fprintf(&b, "%s → %s\n\n", cmp.FromSubmissionID, cmp.ToSubmissionID)
+	fmt.Fprintf(&b, "Overall score: %.1f%% → %.1f%% (%+.1f%%)\n\n", cmp.FromScore.Percent, cmp.ToScore.Percent, cmp.ReadinessDelta)
+
+	if len(cmp.SectionDeltas) > 0 {
+		b.WriteString("| Section | Before | After | Delta |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, d := range cmp.SectionDeltas {
+			fmt.Fprintf(&b, "| %s | %.1f%% | %.1f%% | %+.1f%% |\n", d.SectionID, d.BeforePercent, d.AfterPercent, d.DeltaPercent)
+		}
+		b.WriteString("\n")
+	}
+
+	if len(cmp.ChangedAnswers) > 0 {
+		b.WriteString("## Changed answers\n\n")
+		b.WriteString("| Question | Before | After |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, a := range cmp.ChangedAnswers {
+			question := a.QuestionText
+			if question == "" {
+				question = a.QuestionID
+			}
+			if a.QuestionDeleted {
+				question += " (deleted)"
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", markdownEscape(question), markdownEscape(a.Before), markdownEscape(a.After))
+		}
+	}
+	return b.String()
+}
+
+// markdownEscape escapes the pipe character, which would otherwise break
+// out of a Markdown table cell.
+func markdownEscape(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
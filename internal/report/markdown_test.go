@@ -0,0 +1,32 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+func TestRenderComparisonMarkdownIncludesDeltasAndChangedAnswers(t *testing.T) {
+	svc := prr.Service{Name: "checkout"}
+	cmp := prr.Comparison{
+		FromSubmissionID: "sub-1",
+		ToSubmissionID:   "sub-2",
+		FromScore:        prr.Score{Percent: 50},
+		ToScore:          prr.Score{Percent: 75},
+		ReadinessDelta:   25,
+		SectionDeltas:    []prr.SectionScoreDelta{{SectionID: "sec-1", BeforePercent: 50, AfterPercent: 100, DeltaPercent: 50}},
+		ChangedAnswers:   []prr.AnswerChange{{QuestionID: "q1", QuestionText: "Is data encrypted?", Before: "no", After: "yes"}},
+	}
+
+	out := RenderComparisonMarkdown(svc, cmp)
+	if !strings.Contains(out, "sub-1 → sub-2") {
+		t.Fatalf("output missing submission ids: %s", out)
+	}
+	if !strings.Contains(out, "50.0% → 75.0%") {
+		t.Fatalf("output missing overall score delta: %s", out)
+	}
+	if !strings.Contains(out, "Is data encrypted?") {
+		t.Fatalf("output missing changed answer's question text: %s", out)
+	}
+}
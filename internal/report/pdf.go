@@ -0,0 +1,102 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// RenderSubmissionPDF renders a submission as a minimal single-page PDF:
+// service name, per-answer breakdown, overall score/grade and blocking
+// issues. There is no PDF library in the module's dependency graph (the
+// project only depends on the standard library), so this hand-rolls the
+// small subset of the PDF 1.4 object model needed for a page of text
+// rather than pulling one in.
+func RenderSubmissionPDF(svc prr.Service, sub prr.Submission, score prr.Score) []byte {
+	lines := submissionPDFLines(svc, sub, score)
+	content := pdfContentStream(lines)
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 5 0 R >> >> /MediaBox [0 0 612 792] /Contents 4 0 R >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xrefStart := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n", len(objects)+1)
+	buf.WriteString("0000000000 65535 f \n")
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF", len(objects)+1, xrefStart)
+
+	return buf.Bytes()
+}
+
+func submissionPDFLines(svc prr.Service, sub prr.Submission, score prr.Score) []string {
+	lines := []string{
+		fmt.Sprintf("PRR Submission - %s", svc.Name),
+		fmt.Sprintf("Submitted: %s", sub.CreatedAt.Format("2006-01-02 15:04 MST")),
+		fmt.Sprintf("Status: %s  Approval: %s", sub.Status, sub.Approval),
+		fmt.Sprintf("Score: %d/%d (%.1f%%)  Grade: %s", score.Points, score.MaxPoints, score.Percent, score.Grade),
+		"",
+		"Answers:",
+	}
+	for _, ans := range sub.Answers {
+		lines = append(lines, fmt.Sprintf("  %s: %s", ans.QuestionID, ans.Value))
+		if ans.Comment != "" {
+			lines = append(lines, fmt.Sprintf("    comment: %s", ans.Comment))
+		}
+		for _, link := range ans.EvidenceLinks {
+			lines = append(lines, fmt.Sprintf("    evidence: %s", link))
+		}
+	}
+	if len(sub.BlockingIssues) > 0 {
+		lines = append(lines, "", "Blocking issues:")
+		for _, id := range sub.BlockingIssues {
+			lines = append(lines, fmt.Sprintf("  %s", id))
+		}
+	}
+	if sub.ContentHash != "" {
+		lines = append(lines, "", fmt.Sprintf("Content hash (SHA-256): %s", sub.ContentHash))
+		if sub.Signature != "" {
+			lines = append(lines, fmt.Sprintf("Signature (HMAC-SHA256): %s", sub.Signature))
+		}
+	}
+	return lines
+}
+
+// pdfContentStream builds a PDF content stream that draws lines as
+// successive rows of 12pt Helvetica text, top to bottom.
+func pdfContentStream(lines []string) string {
+	var b strings.Builder
+	b.WriteString("BT\n/F1 12 Tf\n14 TL\n72 760 Td\n")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("T*\n")
+		}
+		fmt.Fprintf(&b, "(%s) Tj\n", pdfEscape(line))
+	}
+	b.WriteString("ET")
+	return b.String()
+}
+
+// pdfEscape escapes the characters that are special inside a PDF
+// literal string: backslash and the parentheses used to delimit it.
+func pdfEscape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+	return r.Replace(s)
+}
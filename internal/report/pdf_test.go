@@ -0,0 +1,28 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+func TestRenderSubmissionPDF(t *testing.T) {
+	svc := prr.Service{Name: "checkout"}
+	sub := prr.Submission{
+		Answers:        []prr.Answer{{QuestionID: "q1", Value: "yes"}},
+		BlockingIssues: []string{"q2"},
+	}
+	score := prr.Score{Points: 1, MaxPoints: 2, Percent: 50, Grade: prr.GradeAmber}
+
+	pdf := RenderSubmissionPDF(svc, sub, score)
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) {
+		t.Fatalf("output does not start with a PDF header: %q", pdf[:20])
+	}
+	if !bytes.Contains(pdf, []byte("%%EOF")) {
+		t.Fatal("output is missing the PDF trailer")
+	}
+	if !bytes.Contains(pdf, []byte("checkout")) {
+		t.Fatal("output does not mention the service name")
+	}
+}
@@ -0,0 +1,62 @@
+// Package seed loads a curated default question bank embedded in the
+// binary, so a fresh deployment has a usable PRR form immediately
+// instead of an empty one.
+package seed
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+//go:embed default_questions.json
+var defaultFS embed.FS
+
+// bank is the on-disk representation of the embedded default question
+// bank, the same shape as a questionBankDump export from
+// GET /admin/questions/export.
+type bank struct {
+	Sections  []prr.Section  `json:"sections"`
+	Questions []prr.Question `json:"questions"`
+}
+
+// Summary reports what a Load call did.
+type Summary struct {
+	Sections  int
+	Questions int
+}
+
+// Load upserts the embedded default question bank into st. Upserting by
+// ID makes it safe to call more than once: re-running it just rewrites
+// the same sections and questions rather than duplicating them.
+func Load(ctx context.Context, st store.Store) (Summary, error) {
+	var summary Summary
+
+	data, err := defaultFS.ReadFile("default_questions.json")
+	if err != nil {
+		return summary, fmt.Errorf("seed: read default question bank: %w", err)
+	}
+	var b bank
+	if err := json.Unmarshal(data, &b); err != nil {
+		return summary, fmt.Errorf("seed: parse default question bank: %w", err)
+	}
+
+	for _, s := range b.Sections {
+		if err := st.UpsertSection(ctx, s); err != nil {
+			return summary, fmt.Errorf("seed: upsert section %s: %w", s.ID, err)
+		}
+		summary.Sections++
+	}
+	for _, q := range b.Questions {
+		if err := st.UpsertQuestion(ctx, q); err != nil {
+			return summary, fmt.Errorf("seed: upsert question %s: %w", q.ID, err)
+		}
+		summary.Questions++
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,49 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestLoadIsIdempotent(t *testing.T) {
+	st := memory.New()
+	ctx := context.Background()
+
+	first, err := Load(ctx, st)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if first.Sections == 0 || first.Questions == 0 {
+		t.Fatalf("Load() summary = %+v, want sections and questions", first)
+	}
+
+	second, err := Load(ctx, st)
+	if err != nil {
+		t.Fatalf("second Load() error = %v", err)
+	}
+	if second != first {
+		t.Fatalf("second Load() summary = %+v, want %+v", second, first)
+	}
+
+	sections, err := st.ListSections(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sections) != first.Sections {
+		t.Fatalf("ListSections() = %d sections, want %d", len(sections), first.Sections)
+	}
+
+	questions, err := st.ListQuestions(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(questions) != first.Questions {
+		t.Fatalf("ListQuestions() = %d questions, want %d", len(questions), first.Questions)
+	}
+
+	if _, err := st.GetQuestion(ctx, "q-oncall"); err != nil {
+		t.Fatalf("GetQuestion(q-oncall) error = %v, want the embedded default bank to define it", err)
+	}
+}
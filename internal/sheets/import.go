@@ -0,0 +1,82 @@
+// Package sheets imports questions from a Google Sheet. Sheets are
+// consumed via their "publish to the web -> CSV" export, so importing
+// requires no Google API credentials.
+package sheets
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// expected CSV header: section_id,section_name,question_id,question_text
+var wantHeader = []string{"section_id", "section_name", "question_id", "question_text"}
+
+// Summary reports what an Import call did.
+type Summary struct {
+	Sections  int
+	Questions int
+}
+
+// Import reads a CSV export of a Google Sheet question bank from r and
+// upserts the sections and questions it describes into st.
+func Import(ctx context.Context, st store.Store, r io.Reader) (Summary, error) {
+	var summary Summary
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return summary, fmt.Errorf("sheets: read header: %w", err)
+	}
+	if !equalHeader(header, wantHeader) {
+		return summary, fmt.Errorf("sheets: unexpected header %v, want %v", header, wantHeader)
+	}
+
+	seenSections := make(map[string]bool)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return summary, fmt.Errorf("sheets: read row: %w", err)
+		}
+
+		sectionID, sectionName, questionID, questionText := record[0], record[1], record[2], record[3]
+		if sectionID == "" || questionID == "" {
+			return summary, fmt.Errorf("sheets: row %v is missing section_id or question_id", record)
+		}
+
+		if !seenSections[sectionID] {
+			if err := st.UpsertSection(ctx, prr.Section{ID: sectionID, Name: sectionName}); err != nil {
+				return summary, fmt.Errorf("sheets: upsert section %s: %w", sectionID, err)
+			}
+			seenSections[sectionID] = true
+			summary.Sections++
+		}
+
+		q := prr.Question{ID: questionID, SectionID: sectionID, Text: questionText}
+		if err := st.UpsertQuestion(ctx, q); err != nil {
+			return summary, fmt.Errorf("sheets: upsert question %s: %w", questionID, err)
+		}
+		summary.Questions++
+	}
+
+	return summary, nil
+}
+
+func equalHeader(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
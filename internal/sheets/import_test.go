@@ -0,0 +1,37 @@
+package sheets
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+func TestImport(t *testing.T) {
+	csv := "section_id,section_name,question_id,question_text\n" +
+		"security,Security,q-oncall,Is there an on-call rotation?\n" +
+		"security,Security,q-runbook,Is there a runbook?\n"
+
+	st := memory.New()
+	summary, err := Import(context.Background(), st, strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if summary.Sections != 1 || summary.Questions != 2 {
+		t.Fatalf("Import() summary = %+v, want 1 section, 2 questions", summary)
+	}
+
+	q, err := st.GetQuestion(context.Background(), "q-runbook")
+	if err != nil || q.Text != "Is there a runbook?" {
+		t.Fatalf("GetQuestion() = %+v, %v", q, err)
+	}
+}
+
+func TestImportRejectsUnexpectedHeader(t *testing.T) {
+	st := memory.New()
+	_, err := Import(context.Background(), st, strings.NewReader("a,b\n1,2\n"))
+	if err == nil {
+		t.Fatal("Import() expected an error for a bad header, got nil")
+	}
+}
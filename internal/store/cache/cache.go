@@ -0,0 +1,145 @@
+// Package cache provides a store.Store decorator that caches the
+// question bank (questions and sections) in memory for a short TTL, so
+// the hot path of scoring a submission or diffing two submissions
+// doesn't refetch the whole bank from the backing store every time.
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// defaultTTL is used when New is given a non-positive ttl.
+const defaultTTL = 30 * time.Second
+
+// Store wraps a store.Store, caching ListQuestions and ListSections for
+// up to ttl before transparently refetching from the underlying store.
+// Any mutation made through this Store (UpsertQuestion, DeleteQuestion,
+// UpsertSection, DeleteSection) invalidates the relevant cache
+// immediately, so a write is visible to the next read regardless of how
+// much of the TTL is left; a mutation made directly against the
+// underlying store, bypassing this wrapper, is not observed until the
+// TTL expires on its own.
+type Store struct {
+	store.Store
+	clock prr.Clock
+	ttl   time.Duration
+
+	mu          sync.Mutex
+	questions   []prr.Question
+	questionsAt time.Time
+	sections    []prr.Section
+	sectionsAt  time.Time
+}
+
+// New wraps underlying with a cache of the given ttl. A non-positive ttl
+// falls back to defaultTTL.
+func New(underlying store.Store, clock prr.Clock, ttl time.Duration) *Store {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &Store{Store: underlying, clock: clock, ttl: ttl}
+}
+
+// ListQuestions returns the cached question bank if it was fetched
+// within ttl, or refetches and recaches it from the underlying store
+// otherwise.
+func (s *Store) ListQuestions(ctx context.Context) ([]prr.Question, error) {
+	s.mu.Lock()
+	if s.questions != nil && s.clock.Now().Sub(s.questionsAt) < s.ttl {
+		cached := append([]prr.Question(nil), s.questions...)
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	questions, err := s.Store.ListQuestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.questions = append([]prr.Question(nil), questions...)
+	s.questionsAt = s.clock.Now()
+	s.mu.Unlock()
+	return questions, nil
+}
+
+// UpsertQuestion upserts question through to the underlying store and
+// invalidates the cached question bank.
+func (s *Store) UpsertQuestion(ctx context.Context, question prr.Question) error {
+	if err := s.Store.UpsertQuestion(ctx, question); err != nil {
+		return err
+	}
+	s.invalidateQuestions()
+	return nil
+}
+
+// DeleteQuestion deletes through to the underlying store and invalidates
+// the cached question bank.
+func (s *Store) DeleteQuestion(ctx context.Context, id string) error {
+	if err := s.Store.DeleteQuestion(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateQuestions()
+	return nil
+}
+
+// ListSections returns the cached section list if it was fetched within
+// ttl, or refetches and recaches it from the underlying store otherwise.
+func (s *Store) ListSections(ctx context.Context) ([]prr.Section, error) {
+	s.mu.Lock()
+	if s.sections != nil && s.clock.Now().Sub(s.sectionsAt) < s.ttl {
+		cached := append([]prr.Section(nil), s.sections...)
+		s.mu.Unlock()
+		return cached, nil
+	}
+	s.mu.Unlock()
+
+	sections, err := s.Store.ListSections(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.sections = append([]prr.Section(nil), sections...)
+	s.sectionsAt = s.clock.Now()
+	s.mu.Unlock()
+	return sections, nil
+}
+
+// UpsertSection upserts section through to the underlying store and
+// invalidates the cached section list.
+func (s *Store) UpsertSection(ctx context.Context, section prr.Section) error {
+	if err := s.Store.UpsertSection(ctx, section); err != nil {
+		return err
+	}
+	s.invalidateSections()
+	return nil
+}
+
+// DeleteSection deletes through to the underlying store and invalidates
+// the cached section list.
+func (s *Store) DeleteSection(ctx context.Context, id string) error {
+	if err := s.Store.DeleteSection(ctx, id); err != nil {
+		return err
+	}
+	s.invalidateSections()
+	return nil
+}
+
+func (s *Store) invalidateQuestions() {
+	s.mu.Lock()
+	s.questions = nil
+	s.mu.Unlock()
+}
+
+func (s *Store) invalidateSections() {
+	s.mu.Lock()
+	s.sections = nil
+	s.mu.Unlock()
+}
+
+var _ store.Store = (*Store)(nil)
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store/memory"
+)
+
+// mutableClock is a prr.Clock whose Now can be advanced mid-test, unlike
+// prr.FixedClock, so TTL expiry can be exercised deterministically.
+type mutableClock struct{ at time.Time }
+
+func (c *mutableClock) Now() time.Time { return c.at }
+
+func TestListQuestionsServesFromCacheUntilTTLExpires(t *testing.T) {
+	underlying := memory.New()
+	ctx := context.Background()
+	if err := underlying.UpsertQuestion(ctx, prr.Question{ID: "q1", Text: "v1"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	clock := &mutableClock{at: time.Unix(0, 0)}
+	c := New(underlying, clock, time.Minute)
+
+	got, err := c.ListQuestions(ctx)
+	if err != nil || len(got) != 1 || got[0].Text != "v1" {
+		t.Fatalf("ListQuestions() = %+v, %v", got, err)
+	}
+
+	// Mutate the underlying store directly, bypassing the cache: the
+	// cached copy should still be served until the TTL expires.
+	if err := underlying.UpsertQuestion(ctx, prr.Question{ID: "q1", Text: "v2"}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	got, _ = c.ListQuestions(ctx)
+	if got[0].Text != "v1" {
+		t.Fatalf("ListQuestions() = %q, want cached %q before TTL expiry", got[0].Text, "v1")
+	}
+
+	clock.at = clock.at.Add(time.Minute + time.Second)
+	got, _ = c.ListQuestions(ctx)
+	if got[0].Text != "v2" {
+		t.Fatalf("ListQuestions() = %q, want refetched %q after TTL expiry", got[0].Text, "v2")
+	}
+}
+
+func TestUpsertQuestionInvalidatesCacheImmediately(t *testing.T) {
+	underlying := memory.New()
+	ctx := context.Background()
+	clock := &mutableClock{at: time.Unix(0, 0)}
+	c := New(underlying, clock, time.Minute)
+
+	if _, err := c.ListQuestions(ctx); err != nil {
+		t.Fatalf("ListQuestions() error = %v", err)
+	}
+	if err := c.UpsertQuestion(ctx, prr.Question{ID: "q1", Text: "v1"}); err != nil {
+		t.Fatalf("UpsertQuestion() error = %v", err)
+	}
+
+	got, err := c.ListQuestions(ctx)
+	if err != nil || len(got) != 1 || got[0].Text != "v1" {
+		t.Fatalf("ListQuestions() = %+v, %v, want the question just upserted without waiting out the TTL", got, err)
+	}
+}
+
+func TestListQuestionsReturnsIndependentCopiesOfCachedSlice(t *testing.T) {
+	underlying := memory.New()
+	ctx := context.Background()
+	if err := underlying.UpsertQuestion(ctx, prr.Question{ID: "q1", Order: 1}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+	if err := underlying.UpsertQuestion(ctx, prr.Question{ID: "q2", Order: 0}); err != nil {
+		t.Fatalf("upsert question: %v", err)
+	}
+
+	clock := &mutableClock{at: time.Unix(0, 0)}
+	c := New(underlying, clock, time.Minute)
+
+	first, _ := c.ListQuestions(ctx)
+	wantSecondFirstID := first[0].ID
+	first[0], first[1] = first[1], first[0]
+
+	second, _ := c.ListQuestions(ctx)
+	if second[0].ID != wantSecondFirstID {
+		t.Fatalf("second call's slice order = %+v, want unaffected by caller mutating the first call's slice", second)
+	}
+}
+
+func TestUpsertSectionInvalidatesCacheImmediately(t *testing.T) {
+	underlying := memory.New()
+	ctx := context.Background()
+	clock := &mutableClock{at: time.Unix(0, 0)}
+	c := New(underlying, clock, time.Minute)
+
+	if _, err := c.ListSections(ctx); err != nil {
+		t.Fatalf("ListSections() error = %v", err)
+	}
+	if err := c.UpsertSection(ctx, prr.Section{ID: "sec-1", Name: "Security"}); err != nil {
+		t.Fatalf("UpsertSection() error = %v", err)
+	}
+
+	got, err := c.ListSections(ctx)
+	if err != nil || len(got) != 1 || got[0].Name != "Security" {
+		t.Fatalf("ListSections() = %+v, %v, want the section just upserted without waiting out the TTL", got, err)
+	}
+}
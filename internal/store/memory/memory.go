@@ -0,0 +1,686 @@
+// Package memory provides an in-memory implementation of store.Store,
+// useful for tests, local development and the --demo mode.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+// Store is an in-memory, goroutine-safe implementation of store.Store.
+type Store struct {
+	mu          sync.RWMutex
+	services    map[string]prr.Service
+	submissions map[string]prr.Submission
+	sections    map[string]prr.Section
+	questions   map[string]prr.Question
+	templates   map[string]prr.Template
+	teams       map[string]prr.Team
+	tenants     map[string]prr.Tenant
+	attachments map[string]prr.Attachment
+	actionItems map[string]prr.ActionItem
+	thresholds  *prr.ScoringThresholds
+	expiry      *prr.ExpiryPolicy
+	jira        *prr.JiraConfig
+	webhooks    map[string]prr.Webhook
+	// questionVersions accumulates superseded wordings per question ID,
+	// oldest first, appended to whenever UpsertQuestion changes Text.
+	questionVersions map[string][]prr.QuestionVersion
+	// webhookDeliveries accumulates delivery attempts per webhook ID,
+	// oldest first.
+	webhookDeliveries map[string][]prr.WebhookDelivery
+	// auditLog accumulates every recorded mutation, oldest first.
+	auditLog  []prr.AuditEntry
+	readiness map[string]prr.ServiceReadiness
+}
+
+// New returns an empty in-memory Store.
+func New() *Store {
+	return &Store{
+		services:          make(map[string]prr.Service),
+		submissions:       make(map[string]prr.Submission),
+		sections:          make(map[string]prr.Section),
+		questions:         make(map[string]prr.Question),
+		templates:         make(map[string]prr.Template),
+		teams:             make(map[string]prr.Team),
+		tenants:           make(map[string]prr.Tenant),
+		attachments:       make(map[string]prr.Attachment),
+		actionItems:       make(map[string]prr.ActionItem),
+		webhooks:          make(map[string]prr.Webhook),
+		questionVersions:  make(map[string][]prr.QuestionVersion),
+		webhookDeliveries: make(map[string][]prr.WebhookDelivery),
+		readiness:         make(map[string]prr.ServiceReadiness),
+	}
+}
+
+// CreateService stores a new service.
+func (s *Store) CreateService(ctx context.Context, svc prr.Service) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.services[svc.ID] = svc
+	return nil
+}
+
+// UpdateService replaces an existing service's metadata. It returns
+// store.ErrNotFound if the service does not exist.
+func (s *Store) UpdateService(ctx context.Context, svc prr.Service) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.services[svc.ID]; !ok {
+		return store.ErrNotFound
+	}
+	s.services[svc.ID] = svc
+	return nil
+}
+
+// GetService returns a service by ID.
+func (s *Store) GetService(ctx context.Context, id string) (prr.Service, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	svc, ok := s.services[id]
+	if !ok {
+		return prr.Service{}, store.ErrNotFound
+	}
+	return svc, nil
+}
+
+// ListServices returns all known services.
+func (s *Store) ListServices(ctx context.Context) ([]prr.Service, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.Service, 0, len(s.services))
+	for _, svc := range s.services {
+		out = append(out, svc)
+	}
+	return out, nil
+}
+
+// CreateSubmission stores a new submission. If sub.IdempotencyKey is
+// set, the uniqueness check and the insert happen under the same lock,
+// the same way Postgres's submissions_idempotency_key_idx unique index
+// enforces it at the schema level, so two concurrent retries carrying
+// the same key can never both succeed.
+func (s *Store) CreateSubmission(ctx context.Context, sub prr.Submission) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub.AmendsSubmissionID != "" {
+		if original, ok := s.submissions[sub.AmendsSubmissionID]; ok && original.Locked {
+			return store.ErrLocked
+		}
+	}
+	if sub.IdempotencyKey != "" {
+		for _, existing := range s.submissions {
+			if existing.IdempotencyKey == sub.IdempotencyKey {
+				return store.ErrDuplicateIdempotencyKey
+			}
+		}
+	}
+	s.submissions[sub.ID] = sub
+	return nil
+}
+
+// UpdateSubmission replaces an existing submission. It returns
+// store.ErrNotFound if the submission does not exist.
+func (s *Store) UpdateSubmission(ctx context.Context, sub prr.Submission) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.submissions[sub.ID]; !ok {
+		return store.ErrNotFound
+	}
+	s.submissions[sub.ID] = sub
+	return nil
+}
+
+// GetSubmission returns a submission by ID.
+func (s *Store) GetSubmission(ctx context.Context, id string) (prr.Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sub, ok := s.submissions[id]
+	if !ok {
+		return prr.Submission{}, store.ErrNotFound
+	}
+	return sub, nil
+}
+
+// ListSubmissionsByService returns all submissions for a given service.
+func (s *Store) ListSubmissionsByService(ctx context.Context, serviceID string) ([]prr.Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []prr.Submission
+	for _, sub := range s.submissions {
+		if sub.ServiceID == serviceID {
+			out = append(out, sub)
+		}
+	}
+	return out, nil
+}
+
+// GetSubmissionByIdempotencyKey returns the submission created with the
+// given IdempotencyKey. It returns store.ErrNotFound if no submission
+// was created with that key.
+func (s *Store) GetSubmissionByIdempotencyKey(ctx context.Context, key string) (prr.Submission, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, sub := range s.submissions {
+		if sub.IdempotencyKey == key {
+			return sub, nil
+		}
+	}
+	return prr.Submission{}, store.ErrNotFound
+}
+
+// UpsertSection creates or replaces a section.
+func (s *Store) UpsertSection(ctx context.Context, section prr.Section) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sections[section.ID] = section
+	return nil
+}
+
+// GetSection returns a section by ID.
+func (s *Store) GetSection(ctx context.Context, id string) (prr.Section, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sec, ok := s.sections[id]
+	if !ok {
+		return prr.Section{}, store.ErrNotFound
+	}
+	return sec, nil
+}
+
+// ListSections returns all known sections.
+func (s *Store) ListSections(ctx context.Context) ([]prr.Section, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.Section, 0, len(s.sections))
+	for _, sec := range s.sections {
+		out = append(out, sec)
+	}
+	return out, nil
+}
+
+// DeleteSection removes a section by ID.
+func (s *Store) DeleteSection(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sections[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.sections, id)
+	return nil
+}
+
+// UpsertTeam creates or replaces a team.
+func (s *Store) UpsertTeam(ctx context.Context, team prr.Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.teams[team.ID] = team
+	return nil
+}
+
+// GetTeam returns a team by ID.
+func (s *Store) GetTeam(ctx context.Context, id string) (prr.Team, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	team, ok := s.teams[id]
+	if !ok {
+		return prr.Team{}, store.ErrNotFound
+	}
+	return team, nil
+}
+
+// ListTeams returns all known teams.
+func (s *Store) ListTeams(ctx context.Context) ([]prr.Team, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.Team, 0, len(s.teams))
+	for _, team := range s.teams {
+		out = append(out, team)
+	}
+	return out, nil
+}
+
+// DeleteTeam removes a team by ID.
+func (s *Store) DeleteTeam(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.teams[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.teams, id)
+	return nil
+}
+
+// UpsertTenant creates or replaces a tenant.
+func (s *Store) UpsertTenant(ctx context.Context, tenant prr.Tenant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tenants[tenant.ID] = tenant
+	return nil
+}
+
+// GetTenant returns a tenant by ID.
+func (s *Store) GetTenant(ctx context.Context, id string) (prr.Tenant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tenant, ok := s.tenants[id]
+	if !ok {
+		return prr.Tenant{}, store.ErrNotFound
+	}
+	return tenant, nil
+}
+
+// ListTenants returns all known tenants.
+func (s *Store) ListTenants(ctx context.Context) ([]prr.Tenant, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.Tenant, 0, len(s.tenants))
+	for _, tenant := range s.tenants {
+		out = append(out, tenant)
+	}
+	return out, nil
+}
+
+// DeleteTenant removes a tenant by ID.
+func (s *Store) DeleteTenant(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.tenants[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.tenants, id)
+	return nil
+}
+
+// CreateAttachment stores a new attachment's metadata.
+func (s *Store) CreateAttachment(ctx context.Context, att prr.Attachment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sub, ok := s.submissions[att.SubmissionID]; ok && sub.Locked {
+		return store.ErrLocked
+	}
+	s.attachments[att.ID] = att
+	return nil
+}
+
+// GetAttachment returns an attachment's metadata by ID.
+func (s *Store) GetAttachment(ctx context.Context, id string) (prr.Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	att, ok := s.attachments[id]
+	if !ok {
+		return prr.Attachment{}, store.ErrNotFound
+	}
+	return att, nil
+}
+
+// ListAttachmentsBySubmission returns all attachments for a given
+// submission.
+func (s *Store) ListAttachmentsBySubmission(ctx context.Context, submissionID string) ([]prr.Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []prr.Attachment
+	for _, att := range s.attachments {
+		if att.SubmissionID == submissionID {
+			out = append(out, att)
+		}
+	}
+	return out, nil
+}
+
+// DeleteAttachment removes an attachment's metadata by ID.
+func (s *Store) DeleteAttachment(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.attachments[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.attachments, id)
+	return nil
+}
+
+// CreateActionItem stores a new remediation task.
+func (s *Store) CreateActionItem(ctx context.Context, item prr.ActionItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actionItems[item.ID] = item
+	return nil
+}
+
+// UpdateActionItem replaces an existing action item.
+func (s *Store) UpdateActionItem(ctx context.Context, item prr.ActionItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.actionItems[item.ID]; !ok {
+		return store.ErrNotFound
+	}
+	s.actionItems[item.ID] = item
+	return nil
+}
+
+// GetActionItem returns an action item by ID.
+func (s *Store) GetActionItem(ctx context.Context, id string) (prr.ActionItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	item, ok := s.actionItems[id]
+	if !ok {
+		return prr.ActionItem{}, store.ErrNotFound
+	}
+	return item, nil
+}
+
+// ListActionItemsBySubmission returns all action items for a given
+// submission.
+func (s *Store) ListActionItemsBySubmission(ctx context.Context, submissionID string) ([]prr.ActionItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []prr.ActionItem
+	for _, item := range s.actionItems {
+		if item.SubmissionID == submissionID {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// ListActionItemsByService returns all action items attached to any
+// submission for a given service.
+func (s *Store) ListActionItemsByService(ctx context.Context, serviceID string) ([]prr.ActionItem, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []prr.ActionItem
+	for _, item := range s.actionItems {
+		if item.ServiceID == serviceID {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// UpsertQuestion creates or replaces a question. If it replaces an
+// existing question whose Text differs from the new one, the previous
+// wording is archived as a QuestionVersion and the new question's
+// Version is bumped, so callers editing question text don't need to
+// manage versioning themselves.
+func (s *Store) UpsertQuestion(ctx context.Context, question prr.Question) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.questions[question.ID]; ok && existing.Text != question.Text {
+		s.questionVersions[question.ID] = append(s.questionVersions[question.ID], prr.QuestionVersion{
+			QuestionID: question.ID,
+			Version:    existing.Version,
+			Text:       existing.Text,
+		})
+		question.Version = existing.Version + 1
+	}
+	s.questions[question.ID] = question
+	return nil
+}
+
+// GetQuestion returns a question by ID.
+func (s *Store) GetQuestion(ctx context.Context, id string) (prr.Question, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	q, ok := s.questions[id]
+	if !ok {
+		return prr.Question{}, store.ErrNotFound
+	}
+	return q, nil
+}
+
+// ListQuestions returns all known questions.
+func (s *Store) ListQuestions(ctx context.Context) ([]prr.Question, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.Question, 0, len(s.questions))
+	for _, q := range s.questions {
+		out = append(out, q)
+	}
+	return out, nil
+}
+
+// DeleteQuestion removes a question by ID.
+func (s *Store) DeleteQuestion(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.questions[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.questions, id)
+	return nil
+}
+
+// UpsertTemplate creates or replaces a template.
+func (s *Store) UpsertTemplate(ctx context.Context, tmpl prr.Template) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.templates[tmpl.ID] = tmpl
+	return nil
+}
+
+// GetTemplate returns a template by ID.
+func (s *Store) GetTemplate(ctx context.Context, id string) (prr.Template, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tmpl, ok := s.templates[id]
+	if !ok {
+		return prr.Template{}, store.ErrNotFound
+	}
+	return tmpl, nil
+}
+
+// ListTemplates returns all known templates.
+func (s *Store) ListTemplates(ctx context.Context) ([]prr.Template, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.Template, 0, len(s.templates))
+	for _, tmpl := range s.templates {
+		out = append(out, tmpl)
+	}
+	return out, nil
+}
+
+// DeleteTemplate removes a template by ID.
+func (s *Store) DeleteTemplate(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.templates[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.templates, id)
+	return nil
+}
+
+// ListQuestionVersions returns the superseded wordings of a question,
+// oldest first.
+func (s *Store) ListQuestionVersions(ctx context.Context, questionID string) ([]prr.QuestionVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.QuestionVersion, len(s.questionVersions[questionID]))
+	copy(out, s.questionVersions[questionID])
+	return out, nil
+}
+
+// GetScoringThresholds returns the configured grade thresholds, falling
+// back to prr.DefaultScoringThresholds if none have been set yet.
+func (s *Store) GetScoringThresholds(ctx context.Context) (prr.ScoringThresholds, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.thresholds == nil {
+		return prr.DefaultScoringThresholds, nil
+	}
+	return *s.thresholds, nil
+}
+
+// SetScoringThresholds replaces the configured grade thresholds.
+func (s *Store) SetScoringThresholds(ctx context.Context, thresholds prr.ScoringThresholds) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.thresholds = &thresholds
+	return nil
+}
+
+// GetExpiryPolicy returns the configured expiry policy, falling back to
+// prr.DefaultExpiryPolicy if none has been set yet.
+func (s *Store) GetExpiryPolicy(ctx context.Context) (prr.ExpiryPolicy, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.expiry == nil {
+		return prr.DefaultExpiryPolicy, nil
+	}
+	return *s.expiry, nil
+}
+
+// SetExpiryPolicy replaces the configured expiry policy.
+func (s *Store) SetExpiryPolicy(ctx context.Context, policy prr.ExpiryPolicy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expiry = &policy
+	return nil
+}
+
+// GetJiraConfig returns the configured Jira integration, falling back to
+// a zero prr.JiraConfig (ticket creation disabled) if none has been set
+// yet.
+func (s *Store) GetJiraConfig(ctx context.Context) (prr.JiraConfig, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.jira == nil {
+		return prr.JiraConfig{}, nil
+	}
+	return *s.jira, nil
+}
+
+// SetJiraConfig replaces the configured Jira integration.
+func (s *Store) SetJiraConfig(ctx context.Context, config prr.JiraConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jira = &config
+	return nil
+}
+
+// CreateWebhook registers a new webhook.
+func (s *Store) CreateWebhook(ctx context.Context, hook prr.Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhooks[hook.ID] = hook
+	return nil
+}
+
+// GetWebhook returns a webhook by ID.
+func (s *Store) GetWebhook(ctx context.Context, id string) (prr.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hook, ok := s.webhooks[id]
+	if !ok {
+		return prr.Webhook{}, store.ErrNotFound
+	}
+	return hook, nil
+}
+
+// ListWebhooks returns all registered webhooks.
+func (s *Store) ListWebhooks(ctx context.Context) ([]prr.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.Webhook, 0, len(s.webhooks))
+	for _, hook := range s.webhooks {
+		out = append(out, hook)
+	}
+	return out, nil
+}
+
+// DeleteWebhook removes a webhook by ID.
+func (s *Store) DeleteWebhook(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.webhooks[id]; !ok {
+		return store.ErrNotFound
+	}
+	delete(s.webhooks, id)
+	return nil
+}
+
+// RecordWebhookDelivery appends a delivery attempt for a webhook.
+func (s *Store) RecordWebhookDelivery(ctx context.Context, delivery prr.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhookDeliveries[delivery.WebhookID] = append(s.webhookDeliveries[delivery.WebhookID], delivery)
+	return nil
+}
+
+// ListWebhookDeliveries returns every recorded delivery attempt for a
+// webhook, oldest first.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, webhookID string) ([]prr.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.WebhookDelivery, len(s.webhookDeliveries[webhookID]))
+	copy(out, s.webhookDeliveries[webhookID])
+	return out, nil
+}
+
+// RecordAudit appends entry to the audit log.
+func (s *Store) RecordAudit(ctx context.Context, entry prr.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.auditLog = append(s.auditLog, entry)
+	return nil
+}
+
+// ListAuditEntries returns entries matching filter, newest first.
+func (s *Store) ListAuditEntries(ctx context.Context, filter store.AuditFilter) ([]prr.AuditEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := []prr.AuditEntry{}
+	for i := len(s.auditLog) - 1; i >= 0; i-- {
+		entry := s.auditLog[i]
+		if filter.Entity != "" && entry.Entity != filter.Entity {
+			continue
+		}
+		if filter.ID != "" && entry.EntityID != filter.ID {
+			continue
+		}
+		if !filter.From.IsZero() && entry.At.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && entry.At.After(filter.To) {
+			continue
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// UpsertServiceReadiness creates or replaces the readiness summary for
+// readiness.ServiceID.
+func (s *Store) UpsertServiceReadiness(ctx context.Context, readiness prr.ServiceReadiness) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.readiness[readiness.ServiceID] = readiness
+	return nil
+}
+
+// GetServiceReadiness returns the readiness summary for serviceID.
+func (s *Store) GetServiceReadiness(ctx context.Context, serviceID string) (prr.ServiceReadiness, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	readiness, ok := s.readiness[serviceID]
+	if !ok {
+		return prr.ServiceReadiness{}, store.ErrNotFound
+	}
+	return readiness, nil
+}
+
+// ListServiceReadiness returns every recorded readiness summary, in no
+// particular order.
+func (s *Store) ListServiceReadiness(ctx context.Context) ([]prr.ServiceReadiness, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]prr.ServiceReadiness, 0, len(s.readiness))
+	for _, readiness := range s.readiness {
+		out = append(out, readiness)
+	}
+	return out, nil
+}
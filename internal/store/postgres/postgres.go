@@ -0,0 +1,1283 @@
+// Package postgres provides a PostgreSQL implementation of store.Store,
+// for deployments that don't want to run Elasticsearch just to persist
+// PRR data. It talks to the database through the standard library's
+// database/sql, so the program that wires this backend in must
+// blank-import an actual driver package (e.g. lib/pq or pgx/stdlib) and
+// pass its registered name to Open; this module otherwise has no
+// third-party dependencies, so none is imported here.
+//
+// Full-text service search, which an Elasticsearch-backed deployment
+// gets for free, is emulated with the pg_trgm extension (see schema.sql)
+// instead; SearchServices uses it for a trigram similarity match.
+package postgres
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"database/sql"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+	"github.com/Eambo/vxcvxvc/internal/store"
+)
+
+//go:embed schema.sql
+var schema string
+
+// Store is a PostgreSQL-backed implementation of store.Store.
+type Store struct {
+	db *sql.DB
+}
+
+// Open connects to the database at dsn using driverName (the name the
+// caller registered a database/sql driver under), applies schema.sql,
+// and returns a ready-to-use Store.
+func Open(ctx context.Context, driverName, dsn string) (*Store, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: open: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("postgres: ping: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("postgres: apply schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying connection pool.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+const serviceColumns = `id, name, created_at, slack_channel, description, owner_team, owner_email, tier, repository_url, runbook_url, archived, tenant_id, review_interval_days`
+
+// CreateService stores a new service.
+func (s *Store) CreateService(ctx context.Context, svc prr.Service) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO services (`+serviceColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		svc.ID, svc.Name, svc.CreatedAt, svc.SlackChannel, svc.Description, svc.OwnerTeam, svc.OwnerEmail, svc.Tier, svc.RepositoryURL, svc.RunbookURL, svc.Archived, svc.TenantID, svc.ReviewIntervalDays)
+	if err != nil {
+		return fmt.Errorf("postgres: create service: %w", err)
+	}
+	return nil
+}
+
+// UpdateService replaces an existing service's metadata. It returns
+// store.ErrNotFound if the service does not exist.
+func (s *Store) UpdateService(ctx context.Context, svc prr.Service) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE services SET name = $2, slack_channel = $3, description = $4, owner_team = $5,
+		   owner_email = $6, tier = $7, repository_url = $8, runbook_url = $9, archived = $10, tenant_id = $11,
+		   review_interval_days = $12
+		 WHERE id = $1`,
+		svc.ID, svc.Name, svc.SlackChannel, svc.Description, svc.OwnerTeam, svc.OwnerEmail, svc.Tier, svc.RepositoryURL, svc.RunbookURL, svc.Archived, svc.TenantID, svc.ReviewIntervalDays)
+	if err != nil {
+		return fmt.Errorf("postgres: update service: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: update service: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// GetService returns a service by ID.
+func (s *Store) GetService(ctx context.Context, id string) (prr.Service, error) {
+	var svc prr.Service
+	err := s.db.QueryRowContext(ctx,
+		`SELECT `+serviceColumns+` FROM services WHERE id = $1`, id,
+	).Scan(&svc.ID, &svc.Name, &svc.CreatedAt, &svc.SlackChannel, &svc.Description, &svc.OwnerTeam, &svc.OwnerEmail, &svc.Tier, &svc.RepositoryURL, &svc.RunbookURL, &svc.Archived, &svc.TenantID, &svc.ReviewIntervalDays)
+	if err == sql.ErrNoRows {
+		return prr.Service{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Service{}, fmt.Errorf("postgres: get service: %w", err)
+	}
+	return svc, nil
+}
+
+// ListServices returns all known services.
+func (s *Store) ListServices(ctx context.Context) ([]prr.Service, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+serviceColumns+` FROM services`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list services: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Service{}
+	for rows.Next() {
+		var svc prr.Service
+		if err := rows.Scan(&svc.ID, &svc.Name, &svc.CreatedAt, &svc.SlackChannel, &svc.Description, &svc.OwnerTeam, &svc.OwnerEmail, &svc.Tier, &svc.RepositoryURL, &svc.RunbookURL, &svc.Archived, &svc.TenantID, &svc.ReviewIntervalDays); err != nil {
+			return nil, fmt.Errorf("postgres: scan service: %w", err)
+		}
+		out = append(out, svc)
+	}
+	return out, rows.Err()
+}
+
+// SearchServices returns services whose name trigram-matches term, along
+// with their similarity score, ranked highest first. This emulates the
+// fuzzy name search an Elasticsearch-backed deployment gets for free.
+func (s *Store) SearchServices(ctx context.Context, term string) ([]prr.ServiceSearchResult, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+serviceColumns+`, similarity(name, $1) AS score FROM services
+		 WHERE name % $1 AND archived = FALSE
+		 ORDER BY score DESC`, term)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: search services: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.ServiceSearchResult{}
+	for rows.Next() {
+		var res prr.ServiceSearchResult
+		if err := rows.Scan(&res.ID, &res.Name, &res.CreatedAt, &res.SlackChannel, &res.Description, &res.OwnerTeam, &res.OwnerEmail, &res.Tier, &res.RepositoryURL, &res.RunbookURL, &res.Archived, &res.TenantID, &res.ReviewIntervalDays, &res.Score); err != nil {
+			return nil, fmt.Errorf("postgres: scan service: %w", err)
+		}
+		out = append(out, res)
+	}
+	return out, rows.Err()
+}
+
+// CreateSubmission stores a new submission.
+func (s *Store) CreateSubmission(ctx context.Context, sub prr.Submission) error {
+	if sub.AmendsSubmissionID != "" {
+		var locked bool
+		err := s.db.QueryRowContext(ctx, `SELECT locked FROM submissions WHERE id = $1`, sub.AmendsSubmissionID).Scan(&locked)
+		if err != nil && err != sql.ErrNoRows {
+			return fmt.Errorf("postgres: create submission: %w", err)
+		}
+		if locked {
+			return store.ErrLocked
+		}
+	}
+
+	answers, approvals, blocking, amendment, jiraKeys, err := marshalSubmissionJSON(sub)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO submissions (id, service_id, template_id, answers, status, approval, approvals, grade, blocking_issues, created_at, updated_at, idempotency_key, is_baseline, amends_submission_id, amendment, submitter_email, jira_issue_keys, content_hash, signature, is_partial, locked)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)`,
+		sub.ID, sub.ServiceID, sub.TemplateID, answers, sub.Status, sub.Approval, approvals, sub.Grade, blocking, sub.CreatedAt, sub.UpdatedAt, sub.IdempotencyKey, sub.IsBaseline, sub.AmendsSubmissionID, amendment, sub.SubmitterEmail, jiraKeys, sub.ContentHash, sub.Signature, sub.IsPartial, sub.Locked)
+	if err != nil {
+		// Drivers name the violated constraint in the error text.
+		if sub.IdempotencyKey != "" && strings.Contains(err.Error(), "submissions_idempotency_key_idx") {
+			return store.ErrDuplicateIdempotencyKey
+		}
+		return fmt.Errorf("postgres: create submission: %w", err)
+	}
+	return nil
+}
+
+// UpdateSubmission replaces an existing submission. It returns
+// store.ErrNotFound if the submission does not exist.
+func (s *Store) UpdateSubmission(ctx context.Context, sub prr.Submission) error {
+	answers, approvals, blocking, amendment, jiraKeys, err := marshalSubmissionJSON(sub)
+	if err != nil {
+		return err
+	}
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE submissions SET service_id = $2, template_id = $3, answers = $4, status = $5,
+		   approval = $6, approvals = $7, grade = $8, blocking_issues = $9, updated_at = $10, is_baseline = $11,
+		   amends_submission_id = $12, amendment = $13, submitter_email = $14, jira_issue_keys = $15,
+		   content_hash = $16, signature = $17, is_partial = $18, locked = $19
+		 WHERE id = $1`,
+		sub.ID, sub.ServiceID, sub.TemplateID, answers, sub.Status, sub.Approval, approvals, sub.Grade, blocking, sub.UpdatedAt, sub.IsBaseline, sub.AmendsSubmissionID, amendment, sub.SubmitterEmail, jiraKeys, sub.ContentHash, sub.Signature, sub.IsPartial, sub.Locked)
+	if err != nil {
+		return fmt.Errorf("postgres: update submission: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: update submission: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// GetSubmission returns a submission by ID.
+func (s *Store) GetSubmission(ctx context.Context, id string) (prr.Submission, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, service_id, template_id, answers, status, approval, approvals, grade, blocking_issues, created_at, updated_at, idempotency_key, is_baseline, amends_submission_id, amendment, submitter_email, jira_issue_keys, content_hash, signature, is_partial, locked
+		 FROM submissions WHERE id = $1`, id)
+	sub, err := scanSubmission(row.Scan)
+	if err == sql.ErrNoRows {
+		return prr.Submission{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Submission{}, fmt.Errorf("postgres: get submission: %w", err)
+	}
+	return sub, nil
+}
+
+// ListSubmissionsByService returns all submissions for a given service.
+func (s *Store) ListSubmissionsByService(ctx context.Context, serviceID string) ([]prr.Submission, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, service_id, template_id, answers, status, approval, approvals, grade, blocking_issues, created_at, updated_at, idempotency_key, is_baseline, amends_submission_id, amendment, submitter_email, jira_issue_keys, content_hash, signature, is_partial, locked
+		 FROM submissions WHERE service_id = $1`, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list submissions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []prr.Submission
+	for rows.Next() {
+		sub, err := scanSubmission(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("postgres: scan submission: %w", err)
+		}
+		out = append(out, sub)
+	}
+	return out, rows.Err()
+}
+
+// GetSubmissionByIdempotencyKey returns the submission created with the
+// given IdempotencyKey. It returns store.ErrNotFound if no submission
+// was created with that key.
+func (s *Store) GetSubmissionByIdempotencyKey(ctx context.Context, key string) (prr.Submission, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT id, service_id, template_id, answers, status, approval, approvals, grade, blocking_issues, created_at, updated_at, idempotency_key, is_baseline, amends_submission_id, amendment, submitter_email, jira_issue_keys, content_hash, signature, is_partial, locked
+		 FROM submissions WHERE idempotency_key = $1`, key)
+	sub, err := scanSubmission(row.Scan)
+	if err == sql.ErrNoRows {
+		return prr.Submission{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Submission{}, fmt.Errorf("postgres: get submission by idempotency key: %w", err)
+	}
+	return sub, nil
+}
+
+func marshalSubmissionJSON(sub prr.Submission) (answers, approvals, blocking, amendment, jiraKeys []byte, err error) {
+	if answers, err = json.Marshal(sub.Answers); err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("postgres: marshal answers: %w", err)
+	}
+	if approvals, err = json.Marshal(sub.Approvals); err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("postgres: marshal approvals: %w", err)
+	}
+	if blocking, err = json.Marshal(sub.BlockingIssues); err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("postgres: marshal blocking issues: %w", err)
+	}
+	if sub.Amendment != nil {
+		if amendment, err = json.Marshal(sub.Amendment); err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf("postgres: marshal amendment: %w", err)
+		}
+	}
+	keys := sub.JiraIssueKeys
+	if keys == nil {
+		keys = map[string]string{}
+	}
+	if jiraKeys, err = json.Marshal(keys); err != nil {
+		return nil, nil, nil, nil, nil, fmt.Errorf("postgres: marshal jira issue keys: %w", err)
+	}
+	return answers, approvals, blocking, amendment, jiraKeys, nil
+}
+
+// scanSubmission scans a submissions row using scan (either
+// *sql.Row.Scan or *sql.Rows.Scan, which share a signature but no
+// common interface), and unmarshals its JSONB columns.
+func scanSubmission(scan func(dest ...any) error) (prr.Submission, error) {
+	var sub prr.Submission
+	var answers, approvals, blocking, jiraKeys []byte
+	var amendment sql.NullString
+	err := scan(&sub.ID, &sub.ServiceID, &sub.TemplateID, &answers, &sub.Status, &sub.Approval, &approvals, &sub.Grade, &blocking, &sub.CreatedAt, &sub.UpdatedAt, &sub.IdempotencyKey, &sub.IsBaseline, &sub.AmendsSubmissionID, &amendment, &sub.SubmitterEmail, &jiraKeys, &sub.ContentHash, &sub.Signature, &sub.IsPartial, &sub.Locked)
+	if err != nil {
+		return prr.Submission{}, err
+	}
+	if err := json.Unmarshal(answers, &sub.Answers); err != nil {
+		return prr.Submission{}, fmt.Errorf("unmarshal answers: %w", err)
+	}
+	if err := json.Unmarshal(approvals, &sub.Approvals); err != nil {
+		return prr.Submission{}, fmt.Errorf("unmarshal approvals: %w", err)
+	}
+	if err := json.Unmarshal(blocking, &sub.BlockingIssues); err != nil {
+		return prr.Submission{}, fmt.Errorf("unmarshal blocking issues: %w", err)
+	}
+	if amendment.Valid {
+		sub.Amendment = &prr.Amendment{}
+		if err := json.Unmarshal([]byte(amendment.String), sub.Amendment); err != nil {
+			return prr.Submission{}, fmt.Errorf("unmarshal amendment: %w", err)
+		}
+	}
+	if len(jiraKeys) > 0 {
+		if err := json.Unmarshal(jiraKeys, &sub.JiraIssueKeys); err != nil {
+			return prr.Submission{}, fmt.Errorf("unmarshal jira issue keys: %w", err)
+		}
+		if len(sub.JiraIssueKeys) == 0 {
+			sub.JiraIssueKeys = nil
+		}
+	}
+	return sub, nil
+}
+
+// UpsertSection creates or replaces a section.
+func (s *Store) UpsertSection(ctx context.Context, section prr.Section) error {
+	translations, err := marshalSectionTranslations(section.Translations)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO sections (id, name, order_index, translations) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, order_index = EXCLUDED.order_index, translations = EXCLUDED.translations`,
+		section.ID, section.Name, section.Order, translations)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert section: %w", err)
+	}
+	return nil
+}
+
+// GetSection returns a section by ID.
+func (s *Store) GetSection(ctx context.Context, id string) (prr.Section, error) {
+	var sec prr.Section
+	var translations []byte
+	err := s.db.QueryRowContext(ctx, `SELECT id, name, order_index, translations FROM sections WHERE id = $1`, id).Scan(&sec.ID, &sec.Name, &sec.Order, &translations)
+	if err == sql.ErrNoRows {
+		return prr.Section{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Section{}, fmt.Errorf("postgres: get section: %w", err)
+	}
+	if err := unmarshalSectionTranslations(translations, &sec); err != nil {
+		return prr.Section{}, err
+	}
+	return sec, nil
+}
+
+// ListSections returns all known sections.
+func (s *Store) ListSections(ctx context.Context) ([]prr.Section, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, order_index, translations FROM sections`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list sections: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Section{}
+	for rows.Next() {
+		var sec prr.Section
+		var translations []byte
+		if err := rows.Scan(&sec.ID, &sec.Name, &sec.Order, &translations); err != nil {
+			return nil, fmt.Errorf("postgres: scan section: %w", err)
+		}
+		if err := unmarshalSectionTranslations(translations, &sec); err != nil {
+			return nil, err
+		}
+		out = append(out, sec)
+	}
+	return out, rows.Err()
+}
+
+// marshalSectionTranslations JSON-encodes translations, defaulting a
+// nil map to "{}" so the column is never NULL.
+func marshalSectionTranslations(translations map[string]prr.SectionTranslation) ([]byte, error) {
+	if translations == nil {
+		translations = map[string]prr.SectionTranslation{}
+	}
+	raw, err := json.Marshal(translations)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: marshal section translations: %w", err)
+	}
+	return raw, nil
+}
+
+// unmarshalSectionTranslations decodes raw into sec.Translations,
+// leaving it nil when there are no translations so an untranslated
+// section round-trips without an empty map.
+func unmarshalSectionTranslations(raw []byte, sec *prr.Section) error {
+	if err := json.Unmarshal(raw, &sec.Translations); err != nil {
+		return fmt.Errorf("postgres: unmarshal section translations: %w", err)
+	}
+	if len(sec.Translations) == 0 {
+		sec.Translations = nil
+	}
+	return nil
+}
+
+// DeleteSection removes a section by ID. It returns store.ErrNotFound if
+// the section does not exist.
+func (s *Store) DeleteSection(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM sections WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete section: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: delete section: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// UpsertTeam creates or replaces a team.
+func (s *Store) UpsertTeam(ctx context.Context, team prr.Team) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO teams (id, name) VALUES ($1, $2)
+		 ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name`,
+		team.ID, team.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert team: %w", err)
+	}
+	return nil
+}
+
+// GetTeam returns a team by ID.
+func (s *Store) GetTeam(ctx context.Context, id string) (prr.Team, error) {
+	var team prr.Team
+	err := s.db.QueryRowContext(ctx, `SELECT id, name FROM teams WHERE id = $1`, id).Scan(&team.ID, &team.Name)
+	if err == sql.ErrNoRows {
+		return prr.Team{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Team{}, fmt.Errorf("postgres: get team: %w", err)
+	}
+	return team, nil
+}
+
+// ListTeams returns all known teams.
+func (s *Store) ListTeams(ctx context.Context) ([]prr.Team, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name FROM teams`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list teams: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Team{}
+	for rows.Next() {
+		var team prr.Team
+		if err := rows.Scan(&team.ID, &team.Name); err != nil {
+			return nil, fmt.Errorf("postgres: scan team: %w", err)
+		}
+		out = append(out, team)
+	}
+	return out, rows.Err()
+}
+
+// DeleteTeam removes a team by ID. It returns store.ErrNotFound if the
+// team does not exist.
+func (s *Store) DeleteTeam(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM teams WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete team: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: delete team: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// UpsertTenant creates or replaces a tenant.
+func (s *Store) UpsertTenant(ctx context.Context, tenant prr.Tenant) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO tenants (id, name) VALUES ($1, $2)
+		 ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name`,
+		tenant.ID, tenant.Name)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert tenant: %w", err)
+	}
+	return nil
+}
+
+// GetTenant returns a tenant by ID.
+func (s *Store) GetTenant(ctx context.Context, id string) (prr.Tenant, error) {
+	var tenant prr.Tenant
+	err := s.db.QueryRowContext(ctx, `SELECT id, name FROM tenants WHERE id = $1`, id).Scan(&tenant.ID, &tenant.Name)
+	if err == sql.ErrNoRows {
+		return prr.Tenant{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Tenant{}, fmt.Errorf("postgres: get tenant: %w", err)
+	}
+	return tenant, nil
+}
+
+// ListTenants returns all known tenants.
+func (s *Store) ListTenants(ctx context.Context) ([]prr.Tenant, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name FROM tenants`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Tenant{}
+	for rows.Next() {
+		var tenant prr.Tenant
+		if err := rows.Scan(&tenant.ID, &tenant.Name); err != nil {
+			return nil, fmt.Errorf("postgres: scan tenant: %w", err)
+		}
+		out = append(out, tenant)
+	}
+	return out, rows.Err()
+}
+
+// DeleteTenant removes a tenant by ID. It returns store.ErrNotFound if the
+// tenant does not exist.
+func (s *Store) DeleteTenant(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM tenants WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete tenant: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: delete tenant: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// CreateAttachment stores a new attachment's metadata.
+func (s *Store) CreateAttachment(ctx context.Context, att prr.Attachment) error {
+	var locked bool
+	err := s.db.QueryRowContext(ctx, `SELECT locked FROM submissions WHERE id = $1`, att.SubmissionID).Scan(&locked)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("postgres: create attachment: %w", err)
+	}
+	if locked {
+		return store.ErrLocked
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO attachments (id, submission_id, filename, content_type, size, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		att.ID, att.SubmissionID, att.Filename, att.ContentType, att.Size, att.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres: create attachment: %w", err)
+	}
+	return nil
+}
+
+// GetAttachment returns an attachment's metadata by ID.
+func (s *Store) GetAttachment(ctx context.Context, id string) (prr.Attachment, error) {
+	var att prr.Attachment
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, submission_id, filename, content_type, size, created_at FROM attachments WHERE id = $1`, id).
+		Scan(&att.ID, &att.SubmissionID, &att.Filename, &att.ContentType, &att.Size, &att.CreatedAt)
+	if err == sql.ErrNoRows {
+		return prr.Attachment{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Attachment{}, fmt.Errorf("postgres: get attachment: %w", err)
+	}
+	return att, nil
+}
+
+// ListAttachmentsBySubmission returns all attachments for a given
+// submission.
+func (s *Store) ListAttachmentsBySubmission(ctx context.Context, submissionID string) ([]prr.Attachment, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, submission_id, filename, content_type, size, created_at FROM attachments WHERE submission_id = $1`,
+		submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list attachments: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Attachment{}
+	for rows.Next() {
+		var att prr.Attachment
+		if err := rows.Scan(&att.ID, &att.SubmissionID, &att.Filename, &att.ContentType, &att.Size, &att.CreatedAt); err != nil {
+			return nil, fmt.Errorf("postgres: scan attachment: %w", err)
+		}
+		out = append(out, att)
+	}
+	return out, rows.Err()
+}
+
+// DeleteAttachment removes an attachment's metadata by ID. It returns
+// store.ErrNotFound if the attachment does not exist.
+func (s *Store) DeleteAttachment(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM attachments WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete attachment: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: delete attachment: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+const actionItemColumns = `id, submission_id, service_id, question_id, description, owner, due_date, status, created_at, updated_at`
+
+// CreateActionItem stores a new remediation task.
+func (s *Store) CreateActionItem(ctx context.Context, item prr.ActionItem) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO action_items (`+actionItemColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		item.ID, item.SubmissionID, item.ServiceID, item.QuestionID, item.Description, item.Owner, item.DueDate, item.Status, item.CreatedAt, item.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres: create action item: %w", err)
+	}
+	return nil
+}
+
+// UpdateActionItem replaces an existing action item. It returns
+// store.ErrNotFound if the action item does not exist.
+func (s *Store) UpdateActionItem(ctx context.Context, item prr.ActionItem) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE action_items SET question_id = $2, description = $3, owner = $4, due_date = $5, status = $6, updated_at = $7
+		 WHERE id = $1`,
+		item.ID, item.QuestionID, item.Description, item.Owner, item.DueDate, item.Status, item.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres: update action item: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: update action item: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// GetActionItem returns an action item by ID.
+func (s *Store) GetActionItem(ctx context.Context, id string) (prr.ActionItem, error) {
+	var item prr.ActionItem
+	err := s.db.QueryRowContext(ctx, `SELECT `+actionItemColumns+` FROM action_items WHERE id = $1`, id).
+		Scan(&item.ID, &item.SubmissionID, &item.ServiceID, &item.QuestionID, &item.Description, &item.Owner, &item.DueDate, &item.Status, &item.CreatedAt, &item.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return prr.ActionItem{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.ActionItem{}, fmt.Errorf("postgres: get action item: %w", err)
+	}
+	return item, nil
+}
+
+// ListActionItemsBySubmission returns all action items for a given
+// submission.
+func (s *Store) ListActionItemsBySubmission(ctx context.Context, submissionID string) ([]prr.ActionItem, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+actionItemColumns+` FROM action_items WHERE submission_id = $1`, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list action items: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.ActionItem{}
+	for rows.Next() {
+		var item prr.ActionItem
+		if err := rows.Scan(&item.ID, &item.SubmissionID, &item.ServiceID, &item.QuestionID, &item.Description, &item.Owner, &item.DueDate, &item.Status, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("postgres: scan action item: %w", err)
+		}
+		out = append(out, item)
+	}
+	return out, rows.Err()
+}
+
+// ListActionItemsByService returns all action items attached to any
+// submission for a given service.
+func (s *Store) ListActionItemsByService(ctx context.Context, serviceID string) ([]prr.ActionItem, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+actionItemColumns+` FROM action_items WHERE service_id = $1`, serviceID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list action items: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.ActionItem{}
+	for rows.Next() {
+		var item prr.ActionItem
+		if err := rows.Scan(&item.ID, &item.SubmissionID, &item.ServiceID, &item.QuestionID, &item.Description, &item.Owner, &item.DueDate, &item.Status, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("postgres: scan action item: %w", err)
+		}
+		out = append(out, item)
+	}
+	return out, rows.Err()
+}
+
+// UpsertQuestion creates or replaces a question. If it replaces an
+// existing question whose Text differs from the new one, the previous
+// wording is archived as a QuestionVersion and the new question's
+// Version is bumped, matching memory.Store's behavior.
+func (s *Store) UpsertQuestion(ctx context.Context, question prr.Question) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert question: %w", err)
+	}
+	defer tx.Rollback()
+
+	var existingText string
+	var existingVersion int
+	err = tx.QueryRowContext(ctx, `SELECT text, version FROM questions WHERE id = $1`, question.ID).Scan(&existingText, &existingVersion)
+	switch {
+	case err == sql.ErrNoRows:
+		// No prior question: leave Version at whatever the caller set.
+	case err != nil:
+		return fmt.Errorf("postgres: upsert question: %w", err)
+	case existingText != question.Text:
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO question_versions (question_id, version, text) VALUES ($1, $2, $3)`,
+			question.ID, existingVersion, existingText); err != nil {
+			return fmt.Errorf("postgres: archive question version: %w", err)
+		}
+		question.Version = existingVersion + 1
+	}
+
+	options, err := json.Marshal(question.Options)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal question options: %w", err)
+	}
+	translations, err := marshalQuestionTranslations(question.Translations)
+	if err != nil {
+		return err
+	}
+	vocabulary, err := json.Marshal(question.Vocabulary)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal question vocabulary: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO questions (id, section_id, text, type, options, weight, is_essential, version, order_index, parent_question_id, show_when_answer, archived, blurb, supporting_link, translations, vocabulary) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		 ON CONFLICT (id) DO UPDATE SET section_id = EXCLUDED.section_id, text = EXCLUDED.text, type = EXCLUDED.type, options = EXCLUDED.options,
+		   weight = EXCLUDED.weight, is_essential = EXCLUDED.is_essential, version = EXCLUDED.version, order_index = EXCLUDED.order_index,
+		   parent_question_id = EXCLUDED.parent_question_id, show_when_answer = EXCLUDED.show_when_answer, archived = EXCLUDED.archived,
+		   blurb = EXCLUDED.blurb, supporting_link = EXCLUDED.supporting_link, translations = EXCLUDED.translations, vocabulary = EXCLUDED.vocabulary`,
+		question.ID, question.SectionID, question.Text, string(question.Type), options, question.Weight, question.IsEssential, question.Version, question.Order, question.ParentQuestionID, question.ShowWhenAnswer, question.Archived, question.Blurb, question.SupportingLink, translations, vocabulary)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert question: %w", err)
+	}
+	return tx.Commit()
+}
+
+// GetQuestion returns a question by ID.
+func (s *Store) GetQuestion(ctx context.Context, id string) (prr.Question, error) {
+	var q prr.Question
+	var qtype string
+	var options []byte
+	var translations []byte
+	var vocabulary []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, section_id, text, type, options, weight, is_essential, version, order_index, parent_question_id, show_when_answer, archived, blurb, supporting_link, translations, vocabulary FROM questions WHERE id = $1`, id,
+	).Scan(&q.ID, &q.SectionID, &q.Text, &qtype, &options, &q.Weight, &q.IsEssential, &q.Version, &q.Order, &q.ParentQuestionID, &q.ShowWhenAnswer, &q.Archived, &q.Blurb, &q.SupportingLink, &translations, &vocabulary)
+	if err == sql.ErrNoRows {
+		return prr.Question{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Question{}, fmt.Errorf("postgres: get question: %w", err)
+	}
+	q.Type = prr.QuestionType(qtype)
+	if err := json.Unmarshal(options, &q.Options); err != nil {
+		return prr.Question{}, fmt.Errorf("postgres: unmarshal question options: %w", err)
+	}
+	if err := unmarshalQuestionTranslations(translations, &q); err != nil {
+		return prr.Question{}, err
+	}
+	if err := json.Unmarshal(vocabulary, &q.Vocabulary); err != nil {
+		return prr.Question{}, fmt.Errorf("postgres: unmarshal question vocabulary: %w", err)
+	}
+	return q, nil
+}
+
+// ListQuestions returns all known questions.
+func (s *Store) ListQuestions(ctx context.Context) ([]prr.Question, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, section_id, text, type, options, weight, is_essential, version, order_index, parent_question_id, show_when_answer, archived, blurb, supporting_link, translations, vocabulary FROM questions`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list questions: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Question{}
+	for rows.Next() {
+		var q prr.Question
+		var qtype string
+		var options []byte
+		var translations []byte
+		var vocabulary []byte
+		if err := rows.Scan(&q.ID, &q.SectionID, &q.Text, &qtype, &options, &q.Weight, &q.IsEssential, &q.Version, &q.Order, &q.ParentQuestionID, &q.ShowWhenAnswer, &q.Archived, &q.Blurb, &q.SupportingLink, &translations, &vocabulary); err != nil {
+			return nil, fmt.Errorf("postgres: scan question: %w", err)
+		}
+		q.Type = prr.QuestionType(qtype)
+		if err := json.Unmarshal(options, &q.Options); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal question options: %w", err)
+		}
+		if err := unmarshalQuestionTranslations(translations, &q); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(vocabulary, &q.Vocabulary); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal question vocabulary: %w", err)
+		}
+		out = append(out, q)
+	}
+	return out, rows.Err()
+}
+
+// marshalQuestionTranslations JSON-encodes translations, defaulting a
+// nil map to "{}" so the column is never NULL.
+func marshalQuestionTranslations(translations map[string]prr.QuestionTranslation) ([]byte, error) {
+	if translations == nil {
+		translations = map[string]prr.QuestionTranslation{}
+	}
+	raw, err := json.Marshal(translations)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: marshal question translations: %w", err)
+	}
+	return raw, nil
+}
+
+// unmarshalQuestionTranslations decodes raw into q.Translations,
+// leaving it nil when there are no translations so an untranslated
+// question round-trips without an empty map.
+func unmarshalQuestionTranslations(raw []byte, q *prr.Question) error {
+	if err := json.Unmarshal(raw, &q.Translations); err != nil {
+		return fmt.Errorf("postgres: unmarshal question translations: %w", err)
+	}
+	if len(q.Translations) == 0 {
+		q.Translations = nil
+	}
+	return nil
+}
+
+// DeleteQuestion removes a question by ID. It returns store.ErrNotFound
+// if the question does not exist.
+func (s *Store) DeleteQuestion(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM questions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete question: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: delete question: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// ListQuestionVersions returns the superseded wordings of a question,
+// oldest first.
+func (s *Store) ListQuestionVersions(ctx context.Context, questionID string) ([]prr.QuestionVersion, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT question_id, version, text FROM question_versions WHERE question_id = $1 ORDER BY version ASC`, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list question versions: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.QuestionVersion{}
+	for rows.Next() {
+		var v prr.QuestionVersion
+		if err := rows.Scan(&v.QuestionID, &v.Version, &v.Text); err != nil {
+			return nil, fmt.Errorf("postgres: scan question version: %w", err)
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// UpsertTemplate creates or replaces a template.
+func (s *Store) UpsertTemplate(ctx context.Context, tmpl prr.Template) error {
+	sectionIDs, err := json.Marshal(tmpl.SectionIDs)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal section ids: %w", err)
+	}
+	questionIDs, err := json.Marshal(tmpl.QuestionIDs)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal question ids: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO templates (id, name, section_ids, question_ids) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, section_ids = EXCLUDED.section_ids, question_ids = EXCLUDED.question_ids`,
+		tmpl.ID, tmpl.Name, sectionIDs, questionIDs)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert template: %w", err)
+	}
+	return nil
+}
+
+// GetTemplate returns a template by ID.
+func (s *Store) GetTemplate(ctx context.Context, id string) (prr.Template, error) {
+	var tmpl prr.Template
+	var sectionIDs, questionIDs []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, name, section_ids, question_ids FROM templates WHERE id = $1`, id,
+	).Scan(&tmpl.ID, &tmpl.Name, &sectionIDs, &questionIDs)
+	if err == sql.ErrNoRows {
+		return prr.Template{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Template{}, fmt.Errorf("postgres: get template: %w", err)
+	}
+	if err := json.Unmarshal(sectionIDs, &tmpl.SectionIDs); err != nil {
+		return prr.Template{}, fmt.Errorf("postgres: unmarshal section ids: %w", err)
+	}
+	if err := json.Unmarshal(questionIDs, &tmpl.QuestionIDs); err != nil {
+		return prr.Template{}, fmt.Errorf("postgres: unmarshal question ids: %w", err)
+	}
+	return tmpl, nil
+}
+
+// ListTemplates returns all known templates.
+func (s *Store) ListTemplates(ctx context.Context) ([]prr.Template, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, name, section_ids, question_ids FROM templates`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list templates: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Template{}
+	for rows.Next() {
+		var tmpl prr.Template
+		var sectionIDs, questionIDs []byte
+		if err := rows.Scan(&tmpl.ID, &tmpl.Name, &sectionIDs, &questionIDs); err != nil {
+			return nil, fmt.Errorf("postgres: scan template: %w", err)
+		}
+		if err := json.Unmarshal(sectionIDs, &tmpl.SectionIDs); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal section ids: %w", err)
+		}
+		if err := json.Unmarshal(questionIDs, &tmpl.QuestionIDs); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal question ids: %w", err)
+		}
+		out = append(out, tmpl)
+	}
+	return out, rows.Err()
+}
+
+// DeleteTemplate removes a template by ID. It returns store.ErrNotFound
+// if the template does not exist.
+func (s *Store) DeleteTemplate(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM templates WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete template: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: delete template: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// GetScoringThresholds returns the configured grade thresholds, falling
+// back to prr.DefaultScoringThresholds if none have been set yet.
+func (s *Store) GetScoringThresholds(ctx context.Context) (prr.ScoringThresholds, error) {
+	var t prr.ScoringThresholds
+	err := s.db.QueryRowContext(ctx, `SELECT green_min, amber_min FROM scoring_config WHERE id = TRUE`).Scan(&t.GreenMin, &t.AmberMin)
+	if err == sql.ErrNoRows {
+		return prr.DefaultScoringThresholds, nil
+	}
+	if err != nil {
+		return prr.ScoringThresholds{}, fmt.Errorf("postgres: get scoring thresholds: %w", err)
+	}
+	return t, nil
+}
+
+// SetScoringThresholds replaces the configured grade thresholds.
+func (s *Store) SetScoringThresholds(ctx context.Context, thresholds prr.ScoringThresholds) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO scoring_config (id, green_min, amber_min) VALUES (TRUE, $1, $2)
+		 ON CONFLICT (id) DO UPDATE SET green_min = EXCLUDED.green_min, amber_min = EXCLUDED.amber_min`,
+		thresholds.GreenMin, thresholds.AmberMin)
+	if err != nil {
+		return fmt.Errorf("postgres: set scoring thresholds: %w", err)
+	}
+	return nil
+}
+
+// GetExpiryPolicy returns the configured expiry policy, falling back to
+// prr.DefaultExpiryPolicy if none has been set yet.
+func (s *Store) GetExpiryPolicy(ctx context.Context) (prr.ExpiryPolicy, error) {
+	var p prr.ExpiryPolicy
+	err := s.db.QueryRowContext(ctx, `SELECT interval_days FROM expiry_policy WHERE id = TRUE`).Scan(&p.IntervalDays)
+	if err == sql.ErrNoRows {
+		return prr.DefaultExpiryPolicy, nil
+	}
+	if err != nil {
+		return prr.ExpiryPolicy{}, fmt.Errorf("postgres: get expiry policy: %w", err)
+	}
+	return p, nil
+}
+
+// SetExpiryPolicy replaces the configured expiry policy.
+func (s *Store) SetExpiryPolicy(ctx context.Context, policy prr.ExpiryPolicy) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO expiry_policy (id, interval_days) VALUES (TRUE, $1)
+		 ON CONFLICT (id) DO UPDATE SET interval_days = EXCLUDED.interval_days`,
+		policy.IntervalDays)
+	if err != nil {
+		return fmt.Errorf("postgres: set expiry policy: %w", err)
+	}
+	return nil
+}
+
+// GetJiraConfig returns the configured Jira integration, falling back to
+// a zero prr.JiraConfig (ticket creation disabled) if none has been set
+// yet.
+func (s *Store) GetJiraConfig(ctx context.Context) (prr.JiraConfig, error) {
+	var c prr.JiraConfig
+	err := s.db.QueryRowContext(ctx, `SELECT base_url, email, api_token, project_key, issue_type FROM jira_config WHERE id = TRUE`).
+		Scan(&c.BaseURL, &c.Email, &c.APIToken, &c.ProjectKey, &c.IssueType)
+	if err == sql.ErrNoRows {
+		return prr.JiraConfig{}, nil
+	}
+	if err != nil {
+		return prr.JiraConfig{}, fmt.Errorf("postgres: get jira config: %w", err)
+	}
+	return c, nil
+}
+
+// SetJiraConfig replaces the configured Jira integration.
+func (s *Store) SetJiraConfig(ctx context.Context, config prr.JiraConfig) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO jira_config (id, base_url, email, api_token, project_key, issue_type) VALUES (TRUE, $1, $2, $3, $4, $5)
+		 ON CONFLICT (id) DO UPDATE SET base_url = EXCLUDED.base_url, email = EXCLUDED.email,
+		   api_token = EXCLUDED.api_token, project_key = EXCLUDED.project_key, issue_type = EXCLUDED.issue_type`,
+		config.BaseURL, config.Email, config.APIToken, config.ProjectKey, config.IssueType)
+	if err != nil {
+		return fmt.Errorf("postgres: set jira config: %w", err)
+	}
+	return nil
+}
+
+// CreateWebhook registers a new webhook.
+func (s *Store) CreateWebhook(ctx context.Context, hook prr.Webhook) error {
+	events, err := json.Marshal(hook.Events)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal webhook events: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO webhooks (id, url, events, secret) VALUES ($1, $2, $3, $4)`,
+		hook.ID, hook.URL, events, hook.Secret)
+	if err != nil {
+		return fmt.Errorf("postgres: create webhook: %w", err)
+	}
+	return nil
+}
+
+// GetWebhook returns a webhook by ID.
+func (s *Store) GetWebhook(ctx context.Context, id string) (prr.Webhook, error) {
+	var hook prr.Webhook
+	var events []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, url, events, secret FROM webhooks WHERE id = $1`, id,
+	).Scan(&hook.ID, &hook.URL, &events, &hook.Secret)
+	if err == sql.ErrNoRows {
+		return prr.Webhook{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.Webhook{}, fmt.Errorf("postgres: get webhook: %w", err)
+	}
+	if err := json.Unmarshal(events, &hook.Events); err != nil {
+		return prr.Webhook{}, fmt.Errorf("postgres: unmarshal webhook events: %w", err)
+	}
+	return hook, nil
+}
+
+// ListWebhooks returns all registered webhooks.
+func (s *Store) ListWebhooks(ctx context.Context) ([]prr.Webhook, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, url, events, secret FROM webhooks`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.Webhook{}
+	for rows.Next() {
+		var hook prr.Webhook
+		var events []byte
+		if err := rows.Scan(&hook.ID, &hook.URL, &events, &hook.Secret); err != nil {
+			return nil, fmt.Errorf("postgres: scan webhook: %w", err)
+		}
+		if err := json.Unmarshal(events, &hook.Events); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal webhook events: %w", err)
+		}
+		out = append(out, hook)
+	}
+	return out, rows.Err()
+}
+
+// DeleteWebhook removes a webhook by ID. It returns store.ErrNotFound if
+// the webhook does not exist.
+func (s *Store) DeleteWebhook(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("postgres: delete webhook: %w", err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("postgres: delete webhook: %w", err)
+	} else if n == 0 {
+		return store.ErrNotFound
+	}
+	return nil
+}
+
+// RecordWebhookDelivery appends a delivery attempt for a webhook.
+func (s *Store) RecordWebhookDelivery(ctx context.Context, delivery prr.WebhookDelivery) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (id, webhook_id, event, payload, error, attempts, dead_letter, at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		delivery.ID, delivery.WebhookID, delivery.Event, delivery.Payload, delivery.Error, delivery.Attempts, delivery.DeadLetter, delivery.At)
+	if err != nil {
+		return fmt.Errorf("postgres: record webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// ListWebhookDeliveries returns every recorded delivery attempt for a
+// webhook, oldest first.
+func (s *Store) ListWebhookDeliveries(ctx context.Context, webhookID string) ([]prr.WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, webhook_id, event, payload, error, attempts, dead_letter, at
+		 FROM webhook_deliveries WHERE webhook_id = $1 ORDER BY at ASC`, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.WebhookDelivery{}
+	for rows.Next() {
+		var d prr.WebhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.Event, &d.Payload, &d.Error, &d.Attempts, &d.DeadLetter, &d.At); err != nil {
+			return nil, fmt.Errorf("postgres: scan webhook delivery: %w", err)
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// RecordAudit appends entry to the audit log.
+func (s *Store) RecordAudit(ctx context.Context, entry prr.AuditEntry) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO audit_log (id, entity, entity_id, action, actor, before, after, at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		entry.ID, entry.Entity, entry.EntityID, entry.Action, entry.Actor, nullRawMessage(entry.Before), nullRawMessage(entry.After), entry.At)
+	if err != nil {
+		return fmt.Errorf("postgres: record audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries returns entries matching filter, newest first.
+func (s *Store) ListAuditEntries(ctx context.Context, filter store.AuditFilter) ([]prr.AuditEntry, error) {
+	query := `SELECT id, entity, entity_id, action, actor, before, after, at FROM audit_log WHERE TRUE`
+	var args []any
+	if filter.Entity != "" {
+		args = append(args, filter.Entity)
+		query += fmt.Sprintf(" AND entity = $%d", len(args))
+	}
+	if filter.ID != "" {
+		args = append(args, filter.ID)
+		query += fmt.Sprintf(" AND entity_id = $%d", len(args))
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		query += fmt.Sprintf(" AND at >= $%d", len(args))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		query += fmt.Sprintf(" AND at <= $%d", len(args))
+	}
+	query += " ORDER BY at DESC"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.AuditEntry{}
+	for rows.Next() {
+		var entry prr.AuditEntry
+		var before, after sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.Entity, &entry.EntityID, &entry.Action, &entry.Actor, &before, &after, &entry.At); err != nil {
+			return nil, fmt.Errorf("postgres: scan audit entry: %w", err)
+		}
+		if before.Valid {
+			entry.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			entry.After = json.RawMessage(after.String)
+		}
+		out = append(out, entry)
+	}
+	return out, rows.Err()
+}
+
+// UpsertServiceReadiness creates or replaces the readiness summary for
+// readiness.ServiceID.
+func (s *Store) UpsertServiceReadiness(ctx context.Context, readiness prr.ServiceReadiness) error {
+	blocking, err := json.Marshal(readiness.BlockingIssues)
+	if err != nil {
+		return fmt.Errorf("postgres: marshal readiness blocking issues: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO service_readiness (service_id, submission_id, percent, grade, approval, blocking_issues, submitted_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT (service_id) DO UPDATE SET
+			submission_id = EXCLUDED.submission_id,
+			percent = EXCLUDED.percent,
+			grade = EXCLUDED.grade,
+			approval = EXCLUDED.approval,
+			blocking_issues = EXCLUDED.blocking_issues,
+			submitted_at = EXCLUDED.submitted_at,
+			updated_at = EXCLUDED.updated_at`,
+		readiness.ServiceID, readiness.SubmissionID, readiness.Percent, readiness.Grade, readiness.Approval,
+		blocking, readiness.SubmittedAt, readiness.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("postgres: upsert service readiness: %w", err)
+	}
+	return nil
+}
+
+// GetServiceReadiness returns the readiness summary for serviceID.
+func (s *Store) GetServiceReadiness(ctx context.Context, serviceID string) (prr.ServiceReadiness, error) {
+	var readiness prr.ServiceReadiness
+	var blocking []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT service_id, submission_id, percent, grade, approval, blocking_issues, submitted_at, updated_at
+		 FROM service_readiness WHERE service_id = $1`, serviceID).
+		Scan(&readiness.ServiceID, &readiness.SubmissionID, &readiness.Percent, &readiness.Grade, &readiness.Approval,
+			&blocking, &readiness.SubmittedAt, &readiness.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return prr.ServiceReadiness{}, store.ErrNotFound
+	}
+	if err != nil {
+		return prr.ServiceReadiness{}, fmt.Errorf("postgres: get service readiness: %w", err)
+	}
+	if err := json.Unmarshal(blocking, &readiness.BlockingIssues); err != nil {
+		return prr.ServiceReadiness{}, fmt.Errorf("postgres: unmarshal readiness blocking issues: %w", err)
+	}
+	return readiness, nil
+}
+
+// ListServiceReadiness returns every recorded readiness summary.
+func (s *Store) ListServiceReadiness(ctx context.Context) ([]prr.ServiceReadiness, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT service_id, submission_id, percent, grade, approval, blocking_issues, submitted_at, updated_at
+		 FROM service_readiness`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: list service readiness: %w", err)
+	}
+	defer rows.Close()
+
+	out := []prr.ServiceReadiness{}
+	for rows.Next() {
+		var readiness prr.ServiceReadiness
+		var blocking []byte
+		if err := rows.Scan(&readiness.ServiceID, &readiness.SubmissionID, &readiness.Percent, &readiness.Grade, &readiness.Approval,
+			&blocking, &readiness.SubmittedAt, &readiness.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("postgres: scan service readiness: %w", err)
+		}
+		if err := json.Unmarshal(blocking, &readiness.BlockingIssues); err != nil {
+			return nil, fmt.Errorf("postgres: unmarshal readiness blocking issues: %w", err)
+		}
+		out = append(out, readiness)
+	}
+	return out, rows.Err()
+}
+
+// nullRawMessage converts an empty json.RawMessage into a SQL NULL, so
+// an audit entry with no before/after snapshot stores NULL rather than
+// the literal string "null".
+func nullRawMessage(raw json.RawMessage) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	return []byte(raw)
+}
+
+var _ store.Store = (*Store)(nil)
@@ -0,0 +1,250 @@
+// Package store defines the persistence abstraction used by the API
+// handlers, decoupling them from any particular backend.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Eambo/vxcvxvc/internal/prr"
+)
+
+// ErrNotFound is returned by Store methods when the requested object does
+// not exist.
+var ErrNotFound = errors.New("store: not found")
+
+// ErrLocked is returned when a write is refused because it targets a
+// locked submission (see prr.Submission.Locked) — an amendment or
+// partial resubmission naming it via AmendsSubmissionID, or an
+// attachment uploaded against it.
+var ErrLocked = errors.New("store: submission is locked")
+
+// ErrDuplicateIdempotencyKey is returned by CreateSubmission when
+// sub.IdempotencyKey is non-empty and already belongs to another
+// submission. Every backend must reject this under the same
+// synchronization that performs the insert (Postgres via
+// submissions_idempotency_key_idx, the in-memory store via a check
+// under its own lock) so two concurrent retries of the same request
+// can never both succeed.
+var ErrDuplicateIdempotencyKey = errors.New("store: idempotency key already used")
+
+// ServiceStore persists the services under review.
+type ServiceStore interface {
+	CreateService(ctx context.Context, svc prr.Service) error
+	// UpdateService replaces an existing service's metadata. It returns
+	// ErrNotFound if the service does not exist.
+	UpdateService(ctx context.Context, svc prr.Service) error
+	GetService(ctx context.Context, id string) (prr.Service, error)
+	ListServices(ctx context.Context) ([]prr.Service, error)
+}
+
+// SubmissionStore persists PRR submissions and their lifecycle.
+type SubmissionStore interface {
+	// CreateSubmission returns ErrLocked if sub.AmendsSubmissionID names
+	// a locked submission, or ErrDuplicateIdempotencyKey if
+	// sub.IdempotencyKey is non-empty and already belongs to another
+	// submission.
+	CreateSubmission(ctx context.Context, sub prr.Submission) error
+	UpdateSubmission(ctx context.Context, sub prr.Submission) error
+	GetSubmission(ctx context.Context, id string) (prr.Submission, error)
+	ListSubmissionsByService(ctx context.Context, serviceID string) ([]prr.Submission, error)
+	// GetSubmissionByIdempotencyKey returns the submission created with
+	// the given IdempotencyKey. It returns ErrNotFound if no submission
+	// was created with that key.
+	GetSubmissionByIdempotencyKey(ctx context.Context, key string) (prr.Submission, error)
+}
+
+// SectionStore persists the sections a question bank is organized into.
+type SectionStore interface {
+	// UpsertSection creates or replaces a section, keyed by Section.ID.
+	UpsertSection(ctx context.Context, section prr.Section) error
+	GetSection(ctx context.Context, id string) (prr.Section, error)
+	ListSections(ctx context.Context) ([]prr.Section, error)
+	// DeleteSection removes a section by ID. It returns ErrNotFound if
+	// the section does not exist.
+	DeleteSection(ctx context.Context, id string) error
+}
+
+// QuestionStore persists the question bank and its edit history.
+type QuestionStore interface {
+	// UpsertQuestion creates or replaces a question, keyed by Question.ID.
+	UpsertQuestion(ctx context.Context, question prr.Question) error
+	GetQuestion(ctx context.Context, id string) (prr.Question, error)
+	ListQuestions(ctx context.Context) ([]prr.Question, error)
+	// DeleteQuestion removes a question by ID. It returns ErrNotFound if
+	// the question does not exist.
+	DeleteQuestion(ctx context.Context, id string) error
+	// ListQuestionVersions returns the superseded wordings of a
+	// question, oldest first, recorded each time UpsertQuestion changed
+	// its Text. It returns an empty slice for a question that has never
+	// been edited.
+	ListQuestionVersions(ctx context.Context, questionID string) ([]prr.QuestionVersion, error)
+}
+
+// TemplateStore persists named subsets of the question bank.
+type TemplateStore interface {
+	// UpsertTemplate creates or replaces a template, keyed by Template.ID.
+	UpsertTemplate(ctx context.Context, tmpl prr.Template) error
+	GetTemplate(ctx context.Context, id string) (prr.Template, error)
+	ListTemplates(ctx context.Context) ([]prr.Template, error)
+	// DeleteTemplate removes a template by ID. It returns ErrNotFound if
+	// the template does not exist.
+	DeleteTemplate(ctx context.Context, id string) error
+}
+
+// AttachmentStore persists metadata for files uploaded alongside
+// submissions. The file contents themselves live in a blob.Store, not
+// here.
+type AttachmentStore interface {
+	// CreateAttachment returns ErrLocked if att.SubmissionID names a
+	// locked submission.
+	CreateAttachment(ctx context.Context, att prr.Attachment) error
+	GetAttachment(ctx context.Context, id string) (prr.Attachment, error)
+	ListAttachmentsBySubmission(ctx context.Context, submissionID string) ([]prr.Attachment, error)
+	// DeleteAttachment removes an attachment's metadata by ID. It
+	// returns ErrNotFound if the attachment does not exist.
+	DeleteAttachment(ctx context.Context, id string) error
+}
+
+// ActionItemStore persists remediation tasks reviewers attach to
+// submissions.
+type ActionItemStore interface {
+	CreateActionItem(ctx context.Context, item prr.ActionItem) error
+	// UpdateActionItem replaces an existing action item. It returns
+	// ErrNotFound if the action item does not exist.
+	UpdateActionItem(ctx context.Context, item prr.ActionItem) error
+	GetActionItem(ctx context.Context, id string) (prr.ActionItem, error)
+	ListActionItemsBySubmission(ctx context.Context, submissionID string) ([]prr.ActionItem, error)
+	// ListActionItemsByService returns every action item attached to any
+	// submission for serviceID.
+	ListActionItemsByService(ctx context.Context, serviceID string) ([]prr.ActionItem, error)
+}
+
+// TeamStore persists the engineering teams services can be assigned to.
+type TeamStore interface {
+	// UpsertTeam creates or replaces a team, keyed by Team.ID.
+	UpsertTeam(ctx context.Context, team prr.Team) error
+	GetTeam(ctx context.Context, id string) (prr.Team, error)
+	ListTeams(ctx context.Context) ([]prr.Team, error)
+	// DeleteTeam removes a team by ID. It returns ErrNotFound if the
+	// team does not exist.
+	DeleteTeam(ctx context.Context, id string) error
+}
+
+// TenantStore persists the tenants a multi-tenant deployment has
+// provisioned.
+type TenantStore interface {
+	// UpsertTenant creates or replaces a tenant, keyed by Tenant.ID.
+	UpsertTenant(ctx context.Context, tenant prr.Tenant) error
+	GetTenant(ctx context.Context, id string) (prr.Tenant, error)
+	ListTenants(ctx context.Context) ([]prr.Tenant, error)
+	// DeleteTenant removes a tenant by ID. It returns ErrNotFound if the
+	// tenant does not exist.
+	DeleteTenant(ctx context.Context, id string) error
+}
+
+// ScoringConfigStore persists the grade thresholds submissions are
+// scored against.
+type ScoringConfigStore interface {
+	// GetScoringThresholds returns the currently configured grade
+	// thresholds, or prr.DefaultScoringThresholds if none have been set.
+	GetScoringThresholds(ctx context.Context) (prr.ScoringThresholds, error)
+	// SetScoringThresholds replaces the configured grade thresholds.
+	SetScoringThresholds(ctx context.Context, thresholds prr.ScoringThresholds) error
+}
+
+// ExpiryPolicyStore persists how often a service must re-run its PRR.
+type ExpiryPolicyStore interface {
+	// GetExpiryPolicy returns the currently configured expiry policy, or
+	// prr.DefaultExpiryPolicy if none has been set.
+	GetExpiryPolicy(ctx context.Context) (prr.ExpiryPolicy, error)
+	// SetExpiryPolicy replaces the configured expiry policy.
+	SetExpiryPolicy(ctx context.Context, policy prr.ExpiryPolicy) error
+}
+
+// JiraConfigStore persists the Jira instance failing essential questions
+// are auto-filed against.
+type JiraConfigStore interface {
+	// GetJiraConfig returns the currently configured Jira integration, or
+	// a zero prr.JiraConfig (ticket creation disabled) if none has been
+	// set.
+	GetJiraConfig(ctx context.Context) (prr.JiraConfig, error)
+	// SetJiraConfig replaces the configured Jira integration.
+	SetJiraConfig(ctx context.Context, config prr.JiraConfig) error
+}
+
+// WebhookStore persists integrator-registered webhooks and a record of
+// their delivery attempts.
+type WebhookStore interface {
+	// CreateWebhook registers a new webhook, keyed by Webhook.ID.
+	CreateWebhook(ctx context.Context, hook prr.Webhook) error
+	GetWebhook(ctx context.Context, id string) (prr.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]prr.Webhook, error)
+	// DeleteWebhook removes a webhook by ID. It returns ErrNotFound if
+	// the webhook does not exist.
+	DeleteWebhook(ctx context.Context, id string) error
+	// RecordWebhookDelivery appends a delivery attempt, successful or
+	// not, for operators and integrators to inspect.
+	RecordWebhookDelivery(ctx context.Context, delivery prr.WebhookDelivery) error
+	// ListWebhookDeliveries returns every recorded delivery attempt for
+	// a webhook, oldest first.
+	ListWebhookDeliveries(ctx context.Context, webhookID string) ([]prr.WebhookDelivery, error)
+}
+
+// AuditFilter narrows ListAuditEntries to a subset of the log. A zero
+// value field is not filtered on.
+type AuditFilter struct {
+	Entity string
+	ID     string
+	From   time.Time
+	To     time.Time
+}
+
+// AuditStore persists the audit log of create/update/delete mutations
+// made through the admin and submission APIs.
+type AuditStore interface {
+	// RecordAudit appends entry to the audit log. It is append-only:
+	// entries are never updated or removed.
+	RecordAudit(ctx context.Context, entry prr.AuditEntry) error
+	// ListAuditEntries returns entries matching filter, newest first.
+	ListAuditEntries(ctx context.Context, filter AuditFilter) ([]prr.AuditEntry, error)
+}
+
+// ServiceReadinessStore persists the materialized per-service readiness
+// summary the API keeps up to date as submissions and approvals land
+// (see API.refreshServiceReadiness), so dashboard and search reads don't
+// have to recompute "the latest PRR for this service" on every request.
+type ServiceReadinessStore interface {
+	// UpsertServiceReadiness creates or replaces the readiness summary
+	// for readiness.ServiceID.
+	UpsertServiceReadiness(ctx context.Context, readiness prr.ServiceReadiness) error
+	// GetServiceReadiness returns the readiness summary for serviceID.
+	// It returns ErrNotFound if no summary has been recorded yet.
+	GetServiceReadiness(ctx context.Context, serviceID string) (prr.ServiceReadiness, error)
+	// ListServiceReadiness returns every recorded readiness summary, in
+	// no particular order.
+	ListServiceReadiness(ctx context.Context) ([]prr.ServiceReadiness, error)
+}
+
+// Store is the full persistence interface handlers depend on. It's
+// composed from the narrower per-resource interfaces above so a handler
+// (or a test fake) that only touches, say, templates can depend on just
+// TemplateStore instead of the whole thing.
+type Store interface {
+	ServiceStore
+	SubmissionStore
+	SectionStore
+	QuestionStore
+	TemplateStore
+	TeamStore
+	TenantStore
+	AttachmentStore
+	ActionItemStore
+	ScoringConfigStore
+	ExpiryPolicyStore
+	JiraConfigStore
+	WebhookStore
+	AuditStore
+	ServiceReadinessStore
+}
@@ -0,0 +1,98 @@
+// Package webhook signs and delivers PRR lifecycle event payloads to
+// integrator-registered URLs, retrying transient failures before giving
+// up.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	maxRetries     = 3
+	retryBaseDelay = 200 * time.Millisecond
+)
+
+// SignatureHeader carries the hex-encoded HMAC-SHA256 of the request body,
+// keyed with the webhook's secret, so the receiving end can verify the
+// delivery actually came from this server.
+const SignatureHeader = "X-PRR-Signature"
+
+// Deliverer POSTs signed event payloads to webhook URLs. A program builds
+// one Deliverer at startup and reuses it, same as notify.TeamsClient and
+// notify.OpsgenieClient.
+type Deliverer struct {
+	HTTPClient *http.Client
+}
+
+// NewDeliverer builds a Deliverer using http.DefaultClient.
+func NewDeliverer() *Deliverer {
+	return &Deliverer{HTTPClient: http.DefaultClient}
+}
+
+// Sign returns the hex-encoded HMAC-SHA256 of payload keyed with secret.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver POSTs payload to url, signing it with secret when non-empty. It
+// retries network errors and 429/502/503/504 responses up to maxRetries times with
+// exponential backoff before returning the last error, so the caller can
+// record a dead letter.
+func (d *Deliverer) Deliver(ctx context.Context, url, secret string, payload []byte) error {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			delay := retryBaseDelay * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("webhook: build request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if secret != "" {
+			req.Header.Set(SignatureHeader, Sign(secret, payload))
+		}
+
+		resp, err := d.HTTPClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("webhook: deliver to %s: %w", url, err)
+			continue
+		}
+		resp.Body.Close()
+		if isRetryableStatus(resp.StatusCode) && attempt < maxRetries {
+			lastErr = fmt.Errorf("webhook: deliver to %s: unexpected status %d", url, resp.StatusCode)
+			continue
+		}
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook: deliver to %s: unexpected status %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+	return lastErr
+}
+
+// isRetryableStatus reports whether status is one a retry can plausibly
+// resolve: an overloaded or momentarily unavailable receiver.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,41 @@
+// Package webui embeds and serves the companion admin single-page
+// application, so it can be shipped inside the same binary as the API
+// server for simple deployments.
+package webui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+//go:embed dist
+var distFS embed.FS
+
+// Handler returns an http.Handler that serves the embedded admin UI under
+// the given URL prefix. Any path that does not match a real asset falls
+// back to index.html, so client-side routing in the SPA works correctly.
+func Handler(prefix string) (http.Handler, error) {
+	assets, err := fs.Sub(distFS, "dist")
+	if err != nil {
+		return nil, err
+	}
+	fileServer := http.FileServer(http.FS(assets))
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	return http.StripPrefix(prefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		upath := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+		if upath == "." {
+			upath = ""
+		}
+		if upath != "" {
+			if _, err := fs.Stat(assets, upath); err != nil {
+				r = r.Clone(r.Context())
+				r.URL.Path = "/"
+			}
+		}
+		fileServer.ServeHTTP(w, r)
+	})), nil
+}
@@ -0,0 +1,28 @@
+package webui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandlerSPAFallback(t *testing.T) {
+	h, err := Handler("/ui/")
+	if err != nil {
+		t.Fatalf("Handler() error = %v", err)
+	}
+
+	for _, p := range []string{"/ui/", "/ui/some/client/route"} {
+		req := httptest.NewRequest(http.MethodGet, p, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s: status = %d, want 200", p, rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "<div id=\"app\">") {
+			t.Fatalf("GET %s: body does not look like index.html: %s", p, rec.Body.String())
+		}
+	}
+}